@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/config"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+func approveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approve --step=<name> [flags] /path/to/spec.yaml",
+		Short: "Approve or reject a step waiting for approval",
+		Long:  `dagu approve --step=deploy [--reject] [--comment="LGTM"] /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		RunE:  wrapRunE(runApprove),
+	}
+	cmd.Flags().StringP("step", "s", "", "name of the step waiting for approval")
+	cmd.Flags().Bool("reject", false, "reject the step instead of approving it")
+	cmd.Flags().StringP("comment", "c", "", "comment on the decision")
+	cmd.Flags().StringP("user", "u", "", "user who approves or rejects the step (default: $USER)")
+	_ = cmd.MarkFlagRequired("step")
+	return cmd
+}
+
+func runApprove(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	setup := newSetup(cfg)
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	step, err := cmd.Flags().GetString("step")
+	if err != nil {
+		return fmt.Errorf("failed to get step: %w", err)
+	}
+	reject, err := cmd.Flags().GetBool("reject")
+	if err != nil {
+		return fmt.Errorf("failed to get reject flag: %w", err)
+	}
+	comment, err := cmd.Flags().GetString("comment")
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+	user, err := cmd.Flags().GetString("user")
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == "" {
+		user = os.Getenv("USER")
+	}
+
+	dag, err := digraph.Load(cmd.Context(), args[0],
+		digraph.WithBaseConfig(cfg.Paths.BaseConfig),
+		digraph.WithTemplatesDir(cfg.Paths.TemplatesDir),
+	)
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG", "err", err)
+		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
+	}
+
+	cli, err := setup.client()
+	if err != nil {
+		logger.Error(ctx, "failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	opts := client.ApproveOptions{Reject: reject, User: user, Comment: comment}
+	if err := cli.Approve(cmd.Context(), dag, step, opts); err != nil {
+		logger.Error(ctx, "Failed to send the decision", "dag", dag.Name, "step", step, "err", err)
+		return fmt.Errorf("failed to send the decision: %w", err)
+	}
+
+	if reject {
+		logger.Info(ctx, "Step rejected", "dag", dag.Name, "step", step)
+	} else {
+		logger.Info(ctx, "Step approved", "dag", dag.Name, "step", step)
+	}
+	return nil
+}
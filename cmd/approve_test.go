@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+)
+
+func TestApproveCommand(t *testing.T) {
+	t.Run("ApproveStep", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAGFile("approval.yaml")
+
+		done := make(chan struct{})
+		go func() {
+			// Start the DAG waiting for approval.
+			args := []string{"start", dagFile.Path}
+			th.RunCommand(t, startCmd(), cmdTest{args: args})
+			close(done)
+		}()
+
+		// Wait for the DAG running.
+		dagFile.AssertLastStatus(t, scheduler.StatusRunning)
+
+		// Approve the step.
+		th.RunCommand(t, approveCmd(), cmdTest{
+			args:        []string{"approve", "--step=approve", "--user=alice", "--comment=LGTM", dagFile.Path},
+			expectedOut: []string{"Step approved"}})
+
+		// Check the DAG finished successfully.
+		<-done
+		dagFile.AssertLastStatus(t, scheduler.StatusSuccess)
+	})
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/config"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence/local/storage"
+	"github.com/spf13/cobra"
+)
+
+const backfillDateFormat = "2006-01-02"
+
+func backfillCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill --from=<date> --to=<date> [--concurrency=N] [--force] /path/to/spec.yaml",
+		Short: "Runs the DAG for the schedule ticks in a past date range",
+		Long:  `dagu backfill --from=2026-09-01 --to=2026-09-30 /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		RunE:  wrapRunE(runBackfill),
+	}
+
+	cmd.Flags().String("from", "", "start of the range (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().String("to", "", "end of the range, inclusive (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().IntP("concurrency", "c", 1, "maximum number of the runs at the same time")
+	cmd.Flags().Bool("force", false, "run the ticks that already have a successful run")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+func runBackfill(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	setup := newSetup(cfg)
+
+	fromValue, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return fmt.Errorf("failed to get from flag: %w", err)
+	}
+
+	toValue, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return fmt.Errorf("failed to get to flag: %w", err)
+	}
+
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		return fmt.Errorf("failed to get concurrency flag: %w", err)
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be positive: %d", concurrency)
+	}
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return fmt.Errorf("failed to get force flag: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	specFilePath := args[0]
+	absolutePath, err := filepath.Abs(specFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve absolute path for %s: %w", specFilePath, err)
+	}
+
+	dag, err := digraph.Load(ctx, absolutePath,
+		digraph.WithBaseConfig(cfg.Paths.BaseConfig),
+		digraph.WithTemplatesDir(cfg.Paths.TemplatesDir),
+		digraph.OnlyMetadata(),
+		digraph.WithoutEval(),
+	)
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG", "path", specFilePath, "err", err)
+		return fmt.Errorf("failed to load DAG from %s: %w", specFilePath, err)
+	}
+	if len(dag.Schedule) == 0 {
+		return fmt.Errorf("the DAG %s has no schedule to backfill", dag.Name)
+	}
+
+	// The dates are in the time zone of the schedules.
+	location := dag.ScheduleLocation(cfg.Location)
+	from, err := parseBackfillTime(fromValue, location, false)
+	if err != nil {
+		return fmt.Errorf("invalid from %q: %w", fromValue, err)
+	}
+	to, err := parseBackfillTime(toValue, location, true)
+	if err != nil {
+		return fmt.Errorf("invalid to %q: %w", toValue, err)
+	}
+	if !to.After(from) {
+		return fmt.Errorf("the range is empty: from %s to %s", fromValue, toValue)
+	}
+
+	cli, err := setup.client()
+	if err != nil {
+		logger.Error(ctx, "Failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	progressStore := storage.NewStorage(filepath.Join(cfg.Paths.DataDir, "backfill"))
+	progress := loadBackfillProgress(ctx, progressStore, dag, fromValue, toValue)
+
+	// The ticks that already succeeded are skipped unless forced. The ticks
+	// finished by the interrupted backfill of the same range are always
+	// skipped to resume it.
+	skip := make(map[int64]bool)
+	for _, tick := range progress.Done {
+		if t, err := time.Parse(time.RFC3339, tick); err == nil {
+			skip[t.Unix()] = true
+		}
+	}
+	if !force {
+		for _, status := range cli.GetRecentHistory(ctx, dag, math.MaxInt) {
+			if status.Status.Status == scheduler.StatusSuccess && status.Status.ScheduledTime != "" {
+				skip[status.Status.ScheduledAt().Unix()] = true
+			}
+		}
+	}
+
+	var ticks []time.Time
+	for _, tick := range dag.ScheduleTicks(from.Add(-time.Second), to) {
+		if !skip[tick.Unix()] {
+			ticks = append(ticks, tick)
+		}
+	}
+
+	logger.Info(ctx, "Backfill started", "DAG", dag.Name, "from", from.Format(time.RFC3339),
+		"to", to.Format(time.RFC3339), "runs", len(ticks), "concurrency", concurrency)
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed []string
+		sem    = make(chan struct{}, concurrency)
+	)
+
+	for _, tick := range ticks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(tick time.Time) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			scheduledTime := tick.Format(time.RFC3339)
+			logger.Info(ctx, "Backfill run started", "DAG", dag.Name, "scheduledTime", scheduledTime)
+
+			// The run is not canceled on the interrupt to finish it.
+			err := cli.Start(context.WithoutCancel(ctx), dag, client.StartOptions{
+				Quiet:         true,
+				ScheduledTime: tick,
+				Backfill:      true,
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				logger.Error(ctx, "Backfill run failed", "DAG", dag.Name, "scheduledTime", scheduledTime, "err", err)
+				failed = append(failed, scheduledTime)
+				return
+			}
+			logger.Info(ctx, "Backfill run finished", "DAG", dag.Name, "scheduledTime", scheduledTime)
+			progress.Done = append(progress.Done, scheduledTime)
+			if err := progress.save(progressStore); err != nil {
+				logger.Error(ctx, "Failed to save the backfill progress", "DAG", dag.Name, "err", err)
+			}
+		}(tick)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("backfill of %s interrupted: run the same command to resume", dag.Name)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("backfill of %s failed for %d of %d runs: %s",
+			dag.Name, len(failed), len(ticks), strings.Join(failed, ", "))
+	}
+
+	if err := progress.remove(progressStore); err != nil {
+		logger.Error(ctx, "Failed to remove the backfill progress", "DAG", dag.Name, "err", err)
+	}
+	logger.Info(ctx, "Backfill finished", "DAG", dag.Name, "runs", len(ticks))
+
+	return nil
+}
+
+// parseBackfillTime parses the date or the time of the range. The end date
+// includes the whole day.
+func parseBackfillTime(value string, location *time.Location, end bool) (time.Time, error) {
+	if location == nil {
+		location = time.Local
+	}
+	if t, err := time.ParseInLocation(backfillDateFormat, value, location); err == nil {
+		if end {
+			return t.AddDate(0, 0, 1), nil
+		}
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		// The end time is inclusive.
+		return t.Add(time.Second), nil
+	}
+	return t, nil
+}
+
+// backfillProgress is the ticks finished by the backfill of the range. It's
+// kept until all of the runs succeed so that the interrupted backfill can be
+// resumed.
+type backfillProgress struct {
+	File string   `json:"-"`
+	From string   `json:"From"`
+	To   string   `json:"To"`
+	Done []string `json:"Done"`
+}
+
+func loadBackfillProgress(ctx context.Context, s *storage.Storage, dag *digraph.DAG, from, to string) *backfillProgress {
+	file := strings.TrimSuffix(filepath.Base(dag.Location), filepath.Ext(dag.Location)) + ".json"
+	progress := &backfillProgress{File: file, From: from, To: to}
+
+	data, err := s.Read(file)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Error(ctx, "Failed to read the backfill progress", "DAG", dag.Name, "err", err)
+		}
+		return progress
+	}
+
+	var saved backfillProgress
+	if err := json.Unmarshal(data, &saved); err != nil {
+		logger.Error(ctx, "Failed to parse the backfill progress", "DAG", dag.Name, "err", err)
+		return progress
+	}
+	if saved.From != from || saved.To != to {
+		// The progress of the other range is discarded.
+		return progress
+	}
+
+	logger.Info(ctx, "Resuming the backfill", "DAG", dag.Name, "finished", len(saved.Done))
+	progress.Done = saved.Done
+	return progress
+}
+
+func (p *backfillProgress) save(s *storage.Storage) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return s.Write(p.File, data)
+}
+
+func (p *backfillProgress) remove(s *storage.Storage) error {
+	if !s.Exists(p.File) {
+		return nil
+	}
+	return s.Delete(p.File)
+}
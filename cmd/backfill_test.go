@@ -0,0 +1,42 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/fileutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillCommand(t *testing.T) {
+	th := testSetup(t)
+
+	// The runs are started by the dagu binary instead of the test binary.
+	t.Setenv("DAGU_EXECUTABLE", filepath.Join(fileutil.MustGetwd(), "../.local/bin/dagu"))
+
+	dagFile := th.DAGFile("backfill.yaml")
+	args := []string{"backfill", "--from=2026-09-01", "--to=2026-09-03", "--concurrency=2", dagFile.Path}
+
+	// Run the DAG for each day of the range.
+	th.RunCommand(t, backfillCmd(), cmdTest{args: args, expectedOut: []string{"Backfill finished"}})
+
+	history := th.HistoryStore.ReadStatusRecent(th.Context, dagFile.Path, 10)
+	require.Len(t, history, 3)
+
+	var scheduledTimes []string
+	for _, status := range history {
+		require.Equal(t, scheduler.StatusSuccess, status.Status.Status)
+		require.True(t, status.Status.Backfill)
+		scheduledTimes = append(scheduledTimes, status.Status.ScheduledAt().Format("2006-01-02"))
+	}
+	require.ElementsMatch(t, []string{"2026-09-01", "2026-09-02", "2026-09-03"}, scheduledTimes)
+
+	// The ticks that already succeeded are skipped.
+	th.RunCommand(t, backfillCmd(), cmdTest{args: args})
+	require.Len(t, th.HistoryStore.ReadStatusRecent(th.Context, dagFile.Path, 10), 3)
+
+	// They are run again with --force.
+	th.RunCommand(t, backfillCmd(), cmdTest{args: append([]string{"backfill", "--force"}, args[1:]...)})
+	require.Len(t, th.HistoryStore.ReadStatusRecent(th.Context, dagFile.Path, 10), 6)
+}
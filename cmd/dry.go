@@ -7,6 +7,7 @@ import (
 	"github.com/dagu-org/dagu/internal/agent"
 	"github.com/dagu-org/dagu/internal/config"
 	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
 	"github.com/spf13/cobra"
 )
 
@@ -37,6 +38,7 @@ func runDry(cmd *cobra.Command, args []string) error {
 
 	loadOpts := []digraph.LoadOption{
 		digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig),
+		digraph.WithTemplatesDir(setup.cfg.Paths.TemplatesDir),
 	}
 
 	var params string
@@ -70,6 +72,20 @@ func runDry(cmd *cobra.Command, args []string) error {
 
 	ctx = setup.loggerContextWithFile(ctx, false, logFile)
 
+	for _, step := range dag.Steps {
+		if step.Template == nil {
+			continue
+		}
+		logger.Info(ctx, "Step template resolved",
+			"step", step.Name,
+			"uses", step.Template.Uses,
+			"inputs", step.Template.Inputs,
+			"executor", step.ExecutorConfig.Type,
+			"command", step.CmdWithArgs,
+			"script", step.Script,
+		)
+	}
+
 	dagStore, err := setup.dagStore()
 	if err != nil {
 		return fmt.Errorf("failed to initialize DAG store: %w", err)
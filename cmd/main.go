@@ -48,6 +48,9 @@ func init() {
 func registerCommands() {
 	rootCmd.AddCommand(startCmd())
 	rootCmd.AddCommand(stopCmd())
+	rootCmd.AddCommand(pauseCmd())
+	rootCmd.AddCommand(resumeCmd())
+	rootCmd.AddCommand(approveCmd())
 	rootCmd.AddCommand(restartCmd())
 	rootCmd.AddCommand(dryCmd())
 	rootCmd.AddCommand(statusCmd())
@@ -55,5 +58,8 @@ func registerCommands() {
 	rootCmd.AddCommand(serverCmd())
 	rootCmd.AddCommand(schedulerCmd())
 	rootCmd.AddCommand(retryCmd())
+	rootCmd.AddCommand(recoverCmd())
+	rootCmd.AddCommand(backfillCmd())
 	rootCmd.AddCommand(startAllCmd())
+	rootCmd.AddCommand(secretCmd())
 }
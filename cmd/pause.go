@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/dagu-org/dagu/internal/config"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+func pauseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pause /path/to/spec.yaml",
+		Short: "Pause the running DAG",
+		Long:  `dagu pause /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		RunE:  wrapRunE(runPause),
+	}
+	return cmd
+}
+
+func runPause(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	setup := newSetup(cfg)
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	dag, err := digraph.Load(cmd.Context(), args[0],
+		digraph.WithBaseConfig(cfg.Paths.BaseConfig),
+		digraph.WithTemplatesDir(cfg.Paths.TemplatesDir),
+	)
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG", "err", err)
+		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
+	}
+
+	logger.Info(ctx, "DAG is pausing", "dag", dag.Name)
+
+	cli, err := setup.client()
+	if err != nil {
+		logger.Error(ctx, "failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	if err := cli.Pause(cmd.Context(), dag); err != nil {
+		logger.Error(ctx, "Failed to pause DAG", "dag", dag.Name, "err", err)
+		return fmt.Errorf("failed to pause DAG: %w", err)
+	}
+
+	logger.Info(ctx, "DAG paused", "dag", dag.Name)
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+)
+
+func TestPauseCommand(t *testing.T) {
+	t.Run("PauseAndResumeDAG", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAGFile("pause.yaml")
+
+		done := make(chan struct{})
+		go func() {
+			// Start the DAG to pause.
+			args := []string{"start", dagFile.Path}
+			th.RunCommand(t, startCmd(), cmdTest{args: args})
+			close(done)
+		}()
+
+		// Wait for the DAG running.
+		dagFile.AssertLastStatus(t, scheduler.StatusRunning)
+
+		// Pause the DAG.
+		th.RunCommand(t, pauseCmd(), cmdTest{
+			args:        []string{"pause", dagFile.Path},
+			expectedOut: []string{"DAG paused"}})
+
+		// Check the DAG is paused.
+		dagFile.AssertLastStatus(t, scheduler.StatusPaused)
+
+		// Resume the DAG.
+		th.RunCommand(t, resumeCmd(), cmdTest{
+			args:        []string{"resume", dagFile.Path},
+			expectedOut: []string{"DAG resumed"}})
+
+		// Check the DAG finished successfully.
+		<-done
+		dagFile.AssertLastStatus(t, scheduler.StatusSuccess)
+	})
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dagu-org/dagu/internal/agent"
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/config"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/spf13/cobra"
+)
+
+const (
+	recoverPrefix = "recover_"
+)
+
+func recoverCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recover [--req=<request-id>] /path/to/spec.yaml",
+		Short: "Recover the DAG execution whose process died",
+		Long:  `dagu recover [--req=<request-id>] /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		RunE:  wrapRunE(runRecover),
+	}
+
+	cmd.Flags().StringP("req", "r", "", "request-id (default: the latest execution)")
+	cmd.Flags().BoolP("quiet", "q", false, "suppress output")
+	return cmd
+}
+
+func runRecover(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	setup := newSetup(cfg)
+
+	quiet, err := cmd.Flags().GetBool("quiet")
+	if err != nil {
+		return fmt.Errorf("failed to get quiet flag: %w", err)
+	}
+
+	requestID, err := cmd.Flags().GetString("req")
+	if err != nil {
+		return fmt.Errorf("failed to get request ID: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), quiet)
+
+	specFilePath := args[0]
+
+	absolutePath, err := filepath.Abs(specFilePath)
+	if err != nil {
+		logger.Error(ctx, "Failed to resolve absolute path", "path", specFilePath, "err", err)
+		return fmt.Errorf("failed to resolve absolute path for %s: %w", specFilePath, err)
+	}
+
+	var status *model.StatusFile
+	if requestID != "" {
+		status, err = setup.historyStore().FindByRequestID(ctx, absolutePath, requestID)
+		if err != nil {
+			logger.Error(ctx, "Failed to retrieve historical execution", "requestID", requestID, "err", err)
+			return fmt.Errorf("failed to retrieve historical execution for request ID %s: %w", requestID, err)
+		}
+	} else {
+		recent := setup.historyStore().ReadStatusRecent(ctx, absolutePath, 1)
+		if len(recent) == 0 {
+			return fmt.Errorf("no execution found for %s", specFilePath)
+		}
+		status = &recent[0]
+	}
+
+	loadOpts := []digraph.LoadOption{
+		digraph.WithBaseConfig(cfg.Paths.BaseConfig),
+		digraph.WithTemplatesDir(cfg.Paths.TemplatesDir),
+	}
+
+	if status.Status.Params != "" {
+		// backward compatibility
+		loadOpts = append(loadOpts, digraph.WithParams(status.Status.Params))
+	} else {
+		loadOpts = append(loadOpts, digraph.WithParams(status.Status.ParamsList))
+	}
+
+	dag, err := digraph.Load(ctx, absolutePath, loadOpts...)
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG specification", "path", specFilePath, "err", err)
+		// nolint : staticcheck
+		return fmt.Errorf("failed to load DAG specification from %s with params %s: %w",
+			specFilePath, status.Status.Params, err)
+	}
+
+	if !client.IsOrphaned(dag, &status.Status) {
+		return fmt.Errorf("the execution %s is not orphaned (status: %s)",
+			status.Status.RequestID, status.Status.StatusText)
+	}
+
+	if err := executeRecover(ctx, dag, setup, status, quiet); err != nil {
+		logger.Error(ctx, "Failed to execute recovery", "path", specFilePath, "err", err)
+		return fmt.Errorf("failed to execute recovery: %w", err)
+	}
+
+	return nil
+}
+
+func executeRecover(ctx context.Context, dag *digraph.DAG, setup *setup, orphanedStatus *model.StatusFile, quiet bool) error {
+	requestID := orphanedStatus.Status.RequestID
+
+	logFile, err := setup.openLogFile(ctx, recoverPrefix, dag, requestID)
+	if err != nil {
+		return fmt.Errorf("failed to initialize log file for DAG %s: %w", dag.Name, err)
+	}
+	defer logFile.Close()
+
+	logger.Info(ctx, "DAG recovery initiated", "DAG", dag.Name, "requestID", requestID, "logFile", logFile.Name())
+
+	ctx = setup.loggerContextWithFile(ctx, quiet, logFile)
+
+	dagStore, err := setup.dagStore()
+	if err != nil {
+		logger.Error(ctx, "Failed to initialize DAG store", "err", err)
+		return fmt.Errorf("failed to initialize DAG store: %w", err)
+	}
+
+	cli, err := setup.client()
+	if err != nil {
+		logger.Error(ctx, "Failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	agt := agent.New(
+		requestID,
+		dag,
+		filepath.Dir(logFile.Name()),
+		logFile.Name(),
+		cli,
+		dagStore,
+		setup.historyStore(),
+		agent.Options{
+			RetryTarget:   &orphanedStatus.Status,
+			Recover:       true,
+			Secrets:       setup.secretResolver(),
+			TriggeredBy:   orphanedStatus.Status.TriggeredBy,
+			ScheduledTime: orphanedStatus.Status.ScheduledAt(),
+			Backfill:      orphanedStatus.Status.Backfill,
+		},
+	)
+
+	listenSignals(ctx, agt)
+
+	if err := agt.Run(ctx); err != nil {
+		if quiet {
+			os.Exit(1)
+		} else {
+			agt.PrintSummary(ctx)
+			return fmt.Errorf("failed to execute DAG %s (requestID: %s): %w", dag.Name, requestID, err)
+		}
+	}
+
+	if !quiet {
+		agt.PrintSummary(ctx)
+	}
+
+	return nil
+}
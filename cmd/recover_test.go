@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverCommand(t *testing.T) {
+	t.Run("RecoverOrphanedRun", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAGFile("recover.yaml")
+
+		// Run a DAG.
+		th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile.Path}})
+
+		cli := th.Client
+		ctx := context.Background()
+		status, err := cli.GetStatus(ctx, dagFile.Path)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusSuccess, status.Status.Status)
+		original := status.Status
+
+		// Make the run look like its process died while running the
+		// second step.
+		orphaned := original
+		orphaned.Status = scheduler.StatusRunning
+		orphaned.StatusText = scheduler.StatusRunning.String()
+		orphaned.PID = model.PID(0)
+		orphaned.Nodes = []*model.Node{original.Nodes[0], {
+			Step:       original.Nodes[1].Step,
+			Status:     scheduler.NodeStatusRunning,
+			StatusText: scheduler.NodeStatusRunning.String(),
+		}}
+		require.NoError(t, th.HistoryStore.Reopen(ctx, dagFile.Path, original.RequestID))
+		require.NoError(t, th.HistoryStore.Write(ctx, orphaned))
+		require.NoError(t, th.HistoryStore.Close(ctx))
+
+		// Recover the run.
+		th.RunCommand(t, recoverCmd(), cmdTest{
+			args:        []string{"recover", dagFile.Path},
+			expectedOut: []string{"DAG recovery initiated"},
+		})
+
+		status, err = cli.GetStatus(ctx, dagFile.Path)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusSuccess, status.Status.Status)
+		require.Equal(t, original.RequestID, status.Status.RequestID)
+
+		// The first step is not run again.
+		require.Equal(t, original.Nodes[0].Log, status.Status.Nodes[0].Log)
+		require.Equal(t, scheduler.NodeStatusSuccess, status.Status.Nodes[1].Status)
+		require.NotEmpty(t, status.Status.Nodes[1].Log)
+	})
+	t.Run("RecoverDuringDAGWait", func(t *testing.T) {
+		th := testSetup(t)
+
+		cli := th.Client
+		ctx := context.Background()
+
+		// The upstream DAG never runs, so the wait is skipped on the timeout.
+		_, err := cli.CreateDAG(ctx, "recover_upstream")
+		require.NoError(t, err)
+
+		dagFile := th.DAGFile("recover_wait.yaml")
+		th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile.Path}})
+
+		status, err := cli.GetStatus(ctx, dagFile.Path)
+		require.NoError(t, err)
+		original := status.Status
+		require.Equal(t, scheduler.NodeStatusSkipped, original.Nodes[0].Status)
+
+		// Make the run look like its process died while waiting for the
+		// upstream DAG, before any step started.
+		orphaned := original
+		orphaned.Status = scheduler.StatusRunning
+		orphaned.StatusText = scheduler.StatusRunning.String()
+		orphaned.PID = model.PID(0)
+		orphaned.Nodes = []*model.Node{{
+			Step:       original.Nodes[0].Step,
+			Status:     scheduler.NodeStatusNone,
+			StatusText: scheduler.NodeStatusNone.String(),
+		}}
+		require.NoError(t, th.HistoryStore.Reopen(ctx, dagFile.Path, original.RequestID))
+		require.NoError(t, th.HistoryStore.Write(ctx, orphaned))
+		require.NoError(t, th.HistoryStore.Close(ctx))
+
+		th.RunCommand(t, recoverCmd(), cmdTest{
+			args:        []string{"recover", dagFile.Path},
+			expectedOut: []string{"DAG recovery initiated"},
+		})
+
+		// The recovered run waits for the upstream DAG again instead of
+		// running the step.
+		status, err = cli.GetStatus(ctx, dagFile.Path)
+		require.NoError(t, err)
+		require.Equal(t, original.RequestID, status.Status.RequestID)
+		require.Equal(t, scheduler.NodeStatusSkipped, status.Status.Nodes[0].Status)
+	})
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/config"
 	"github.com/dagu-org/dagu/internal/digraph"
-	"github.com/dagu-org/dagu/internal/digraph/scheduler"
 	"github.com/dagu-org/dagu/internal/logger"
 	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/spf13/cobra"
@@ -51,7 +50,10 @@ func runRestart(cmd *cobra.Command, args []string) error {
 	specFilePath := args[0]
 
 	// Load initial DAG configuration
-	dag, err := digraph.Load(ctx, specFilePath, digraph.WithBaseConfig(cfg.Paths.BaseConfig))
+	dag, err := digraph.Load(ctx, specFilePath,
+		digraph.WithBaseConfig(cfg.Paths.BaseConfig),
+		digraph.WithTemplatesDir(cfg.Paths.TemplatesDir),
+	)
 	if err != nil {
 		logger.Error(ctx, "Failed to load DAG", "path", specFilePath, "err", err)
 		return fmt.Errorf("failed to load DAG from %s: %w", specFilePath, err)
@@ -88,6 +90,7 @@ func handleRestartProcess(ctx context.Context, setup *setup, dag *digraph.DAG, q
 
 	loadOpts := []digraph.LoadOption{
 		digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig),
+		digraph.WithTemplatesDir(setup.cfg.Paths.TemplatesDir),
 	}
 	if status.Params != "" {
 		// backward compatibility
@@ -137,7 +140,7 @@ func executeDAG(ctx context.Context, cli client.Client, setup *setup,
 		cli,
 		dagStore,
 		setup.historyStore(),
-		agent.Options{Dry: false})
+		agent.Options{Secrets: setup.secretResolver()})
 
 	listenSignals(ctx, agt)
 	if err := agt.Run(ctx); err != nil {
@@ -158,7 +161,7 @@ func stopDAGIfRunning(ctx context.Context, cli client.Client, dag *digraph.DAG)
 		return fmt.Errorf("failed to get current status: %w", err)
 	}
 
-	if status.Status == scheduler.StatusRunning {
+	if status.Status.IsActive() {
 		logger.Infof(ctx, "Stopping: %s", dag.Name)
 		if err := stopRunningDAG(ctx, cli, dag); err != nil {
 			return fmt.Errorf("failed to stop running DAG: %w", err)
@@ -174,7 +177,7 @@ func stopRunningDAG(ctx context.Context, cli client.Client, dag *digraph.DAG) er
 			return fmt.Errorf("failed to get current status: %w", err)
 		}
 
-		if status.Status != scheduler.StatusRunning {
+		if !status.Status.IsActive() {
 			return nil
 		}
 
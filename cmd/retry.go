@@ -20,9 +20,9 @@ const (
 
 func retryCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "retry --req=<request-id> /path/to/spec.yaml",
+		Use:   "retry --req=<request-id> [--from-step=<step> | --only-step=<step>] /path/to/spec.yaml",
 		Short: "Retry the DAG execution",
-		Long:  `dagu retry --req=<request-id> /path/to/spec.yaml`,
+		Long:  `dagu retry --req=<request-id> [--from-step=<step> | --only-step=<step>] /path/to/spec.yaml`,
 		Args:  cobra.ExactArgs(1),
 		RunE:  wrapRunE(runRetry),
 	}
@@ -30,6 +30,9 @@ func retryCmd() *cobra.Command {
 	cmd.Flags().StringP("req", "r", "", "request-id")
 	_ = cmd.MarkFlagRequired("req")
 	cmd.Flags().BoolP("quiet", "q", false, "suppress output")
+	cmd.Flags().String("from-step", "", "retry from the step: run it and all of its descendants again, along with the failed steps")
+	cmd.Flags().String("only-step", "", "retry only the step and all of its descendants")
+	cmd.MarkFlagsMutuallyExclusive("from-step", "only-step")
 	return cmd
 }
 
@@ -52,6 +55,16 @@ func runRetry(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get request ID: %w", err)
 	}
 
+	fromStep, err := cmd.Flags().GetString("from-step")
+	if err != nil {
+		return fmt.Errorf("failed to get from-step: %w", err)
+	}
+
+	onlyStep, err := cmd.Flags().GetString("only-step")
+	if err != nil {
+		return fmt.Errorf("failed to get only-step: %w", err)
+	}
+
 	ctx := setup.loggerContext(cmd.Context(), quiet)
 
 	specFilePath := args[0]
@@ -70,6 +83,7 @@ func runRetry(cmd *cobra.Command, args []string) error {
 
 	loadOpts := []digraph.LoadOption{
 		digraph.WithBaseConfig(cfg.Paths.BaseConfig),
+		digraph.WithTemplatesDir(cfg.Paths.TemplatesDir),
 	}
 
 	if status.Status.Params != "" {
@@ -88,7 +102,11 @@ func runRetry(cmd *cobra.Command, args []string) error {
 	}
 
 	// Execute DAG retry
-	if err := executeRetry(ctx, dag, setup, status, quiet); err != nil {
+	opts := agent.Options{RetryStep: fromStep}
+	if onlyStep != "" {
+		opts = agent.Options{RetryStep: onlyStep, RetryOnlyStep: true}
+	}
+	if err := executeRetry(ctx, dag, setup, status, opts, quiet); err != nil {
 		logger.Error(ctx, "Failed to execute retry", "path", specFilePath, "err", err)
 		return fmt.Errorf("failed to execute retry: %w", err)
 	}
@@ -96,7 +114,7 @@ func runRetry(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func executeRetry(ctx context.Context, dag *digraph.DAG, setup *setup, originalStatus *model.StatusFile, quiet bool) error {
+func executeRetry(ctx context.Context, dag *digraph.DAG, setup *setup, originalStatus *model.StatusFile, opts agent.Options, quiet bool) error {
 	newRequestID, err := generateRequestID()
 	if err != nil {
 		return fmt.Errorf("failed to generate new request ID: %w", err)
@@ -108,7 +126,7 @@ func executeRetry(ctx context.Context, dag *digraph.DAG, setup *setup, originalS
 	}
 	defer logFile.Close()
 
-	logger.Info(ctx, "DAG retry initiated", "DAG", dag.Name, "originalRequestID", originalStatus.Status.RequestID, "newRequestID", newRequestID, "logFile", logFile.Name())
+	logger.Info(ctx, "DAG retry initiated", "DAG", dag.Name, "originalRequestID", originalStatus.Status.RequestID, "newRequestID", newRequestID, "step", opts.RetryStep, "logFile", logFile.Name())
 
 	ctx = setup.loggerContextWithFile(ctx, quiet, logFile)
 
@@ -132,7 +150,15 @@ func executeRetry(ctx context.Context, dag *digraph.DAG, setup *setup, originalS
 		cli,
 		dagStore,
 		setup.historyStore(),
-		agent.Options{RetryTarget: &originalStatus.Status},
+		agent.Options{
+			RetryTarget:   &originalStatus.Status,
+			RetryStep:     opts.RetryStep,
+			RetryOnlyStep: opts.RetryOnlyStep,
+			Secrets:       setup.secretResolver(),
+			TriggeredBy:   originalStatus.Status.TriggeredBy,
+			ScheduledTime: originalStatus.Status.ScheduledAt(),
+			Backfill:      originalStatus.Status.Backfill,
+		},
 	)
 
 	listenSignals(ctx, agt)
@@ -36,4 +36,33 @@ func TestRetryCommand(t *testing.T) {
 			expectedOut: []string{`params=[foo]`},
 		})
 	})
+	t.Run("RetryFromStep", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAGFile("retry_from_step.yaml")
+
+		// Run a DAG.
+		th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile.Path}})
+
+		cli := th.Client
+		ctx := context.Background()
+		status, err := cli.GetStatus(ctx, dagFile.Path)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusSuccess, status.Status.Status)
+		original := status.Status
+
+		// Retry from the second step.
+		args := []string{"retry", fmt.Sprintf("--req=%s", original.RequestID), "--from-step=2", dagFile.Path}
+		th.RunCommand(t, retryCmd(), cmdTest{args: args})
+
+		status, err = cli.GetStatus(ctx, dagFile.Path)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusSuccess, status.Status.Status)
+		require.NotEqual(t, original.RequestID, status.Status.RequestID)
+
+		// The first step keeps the result of the original run, and the
+		// second step is run again.
+		require.Equal(t, original.Nodes[0].Log, status.Status.Nodes[0].Log)
+		require.NotEqual(t, original.Nodes[1].Log, status.Status.Nodes[1].Log)
+	})
 }
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dagu-org/dagu/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func secretCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secret",
+		Short: "Manage the secrets in the local keystore",
+		Long:  `dagu secret [set|delete|list]`,
+	}
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "set <name>",
+			Short: "Store a secret read from stdin in the keystore",
+			Long:  `echo -n "value" | dagu secret set <name>`,
+			Args:  cobra.ExactArgs(1),
+			RunE:  wrapRunE(runSecretSet),
+		},
+		&cobra.Command{
+			Use:   "delete <name>",
+			Short: "Delete a secret from the keystore",
+			Long:  `dagu secret delete <name>`,
+			Args:  cobra.ExactArgs(1),
+			RunE:  wrapRunE(runSecretDelete),
+		},
+		&cobra.Command{
+			Use:   "list",
+			Short: "List the names of the secrets in the keystore",
+			Long:  `dagu secret list`,
+			Args:  cobra.NoArgs,
+			RunE:  wrapRunE(runSecretList),
+		},
+	)
+	return cmd
+}
+
+func runSecretSet(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// The value is read from stdin so that it's not left in the shell history.
+	data, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return fmt.Errorf("failed to read the secret value: %w", err)
+	}
+	value := strings.TrimRight(string(data), "\r\n")
+	if value == "" {
+		return fmt.Errorf("the secret value is empty")
+	}
+
+	if err := newSetup(cfg).keystore().Set(args[0], value); err != nil {
+		return fmt.Errorf("failed to store the secret: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Secret %s is stored\n", args[0])
+	return nil
+}
+
+func runSecretDelete(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := newSetup(cfg).keystore().Delete(args[0]); err != nil {
+		return fmt.Errorf("failed to delete the secret: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Secret %s is deleted\n", args[0])
+	return nil
+}
+
+func runSecretList(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	names, err := newSetup(cfg).keystore().List()
+	if err != nil {
+		return fmt.Errorf("failed to list the secrets: %w", err)
+	}
+	for _, name := range names {
+		fmt.Fprintln(cmd.OutOrStdout(), name)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/secrets"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretCommand(t *testing.T) {
+	th := testSetup(t)
+	keystore := secrets.NewKeystore(th.Config.Paths.KeystoreFile, th.Config.Paths.KeystoreKeyFile)
+
+	t.Run("Set", func(t *testing.T) {
+		cmd := secretCmd()
+		cmd.SetIn(strings.NewReader("s3cret\n"))
+		th.RunCommand(t, cmd, cmdTest{args: []string{"secret", "set", "API_TOKEN"}})
+
+		value, err := keystore.Get("API_TOKEN")
+		require.NoError(t, err)
+		require.Equal(t, "s3cret", value)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		th.RunCommand(t, secretCmd(), cmdTest{args: []string{"secret", "delete", "API_TOKEN"}})
+
+		names, err := keystore.List()
+		require.NoError(t, err)
+		require.Empty(t, names)
+	})
+}
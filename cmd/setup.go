@@ -24,6 +24,7 @@ import (
 	"github.com/dagu-org/dagu/internal/persistence/local/storage"
 	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/dagu-org/dagu/internal/scheduler"
+	"github.com/dagu-org/dagu/internal/secrets"
 	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
@@ -134,7 +135,7 @@ func (s *setup) server(ctx context.Context) (*server.Server, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize client: %w", err)
 	}
-	return frontend.New(s.cfg, cli), nil
+	return frontend.New(s.cfg, cli, s.schedulerStatusStore(), s.leaderLock()), nil
 }
 
 func (s *setup) scheduler() (*scheduler.Scheduler, error) {
@@ -142,7 +143,27 @@ func (s *setup) scheduler() (*scheduler.Scheduler, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize client: %w", err)
 	}
-	return scheduler.New(s.cfg, cli), nil
+	tickStore := local.NewTickStore(storage.NewStorage(
+		filepath.Join(s.cfg.Paths.DataDir, "scheduler"),
+	))
+	return scheduler.New(s.cfg, cli, tickStore, s.leaderLock(), s.schedulerStatusStore()), nil
+}
+
+// leaderLock returns the lock to elect the leader among the schedulers
+// sharing the data directory, or nil if the leader election is disabled.
+func (s *setup) leaderLock() persistence.LeaderLock {
+	if !s.cfg.Scheduler.LeaderElection {
+		return nil
+	}
+	return local.NewLeaderLock(storage.NewStorage(
+		filepath.Join(s.cfg.Paths.DataDir, "scheduler"),
+	))
+}
+
+func (s *setup) schedulerStatusStore() persistence.SchedulerStatusStore {
+	return local.NewSchedulerStatusStore(storage.NewStorage(
+		filepath.Join(s.cfg.Paths.DataDir, "scheduler", "instances"),
+	))
 }
 
 func (s *setup) dagStore() (persistence.DAGStore, error) {
@@ -154,11 +175,14 @@ func (s *setup) dagStore() (persistence.DAGStore, error) {
 		}
 	}
 
-	return local.NewDAGStore(s.cfg.Paths.DAGsDir), nil
+	return local.NewDAGStore(s.cfg.Paths.DAGsDir, local.WithTemplatesDir(s.cfg.Paths.TemplatesDir)), nil
 }
 
 func (s *setup) dagStoreWithCache(cache *filecache.Cache[*digraph.DAG]) persistence.DAGStore {
-	return local.NewDAGStore(s.cfg.Paths.DAGsDir, local.WithFileCache(cache))
+	return local.NewDAGStore(s.cfg.Paths.DAGsDir,
+		local.WithFileCache(cache),
+		local.WithTemplatesDir(s.cfg.Paths.TemplatesDir),
+	)
 }
 
 func (s *setup) historyStore() persistence.HistoryStore {
@@ -174,6 +198,14 @@ func (s *setup) historyStoreWithCache(cache *filecache.Cache[*model.Status]) per
 	)
 }
 
+func (s *setup) keystore() *secrets.Keystore {
+	return secrets.NewKeystore(s.cfg.Paths.KeystoreFile, s.cfg.Paths.KeystoreKeyFile)
+}
+
+func (s *setup) secretResolver() *secrets.Resolver {
+	return secrets.NewResolver(s.keystore())
+}
+
 func (s *setup) openLogFile(
 	ctx context.Context,
 	prefix string,
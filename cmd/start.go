@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/agent"
 	"github.com/dagu-org/dagu/internal/config"
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/spf13/cobra"
 )
 
@@ -32,6 +35,20 @@ func initStartFlags(cmd *cobra.Command) {
 	cmd.Flags().StringP("params", "p", "", "parameters")
 	cmd.Flags().StringP("requestID", "r", "", "specify request ID")
 	cmd.Flags().BoolP("quiet", "q", false, "suppress output")
+
+	// The parent run is passed by the agent that triggers the DAG.
+	cmd.Flags().String("parentDAG", "", "location of the DAG that triggered the run")
+	cmd.Flags().String("parentRequestID", "", "request ID of the run that triggered the run")
+	_ = cmd.Flags().MarkHidden("parentDAG")
+	_ = cmd.Flags().MarkHidden("parentRequestID")
+
+	// The logical time of the schedule tick is passed by the scheduler.
+	cmd.Flags().String("scheduledTime", "", "logical time of the schedule tick (RFC3339)")
+	_ = cmd.Flags().MarkHidden("scheduledTime")
+
+	// The backfill runs are started by the backfill command.
+	cmd.Flags().Bool("backfill", false, "mark the run as a backfill")
+	_ = cmd.Flags().MarkHidden("backfill")
 }
 
 func runStart(cmd *cobra.Command, args []string) error {
@@ -52,10 +69,31 @@ func runStart(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get request ID: %w", err)
 	}
 
+	parentDAG, err := cmd.Flags().GetString("parentDAG")
+	if err != nil {
+		return fmt.Errorf("failed to get parent DAG: %w", err)
+	}
+
+	parentRequestID, err := cmd.Flags().GetString("parentRequestID")
+	if err != nil {
+		return fmt.Errorf("failed to get parent request ID: %w", err)
+	}
+
+	scheduledTime, err := cmd.Flags().GetString("scheduledTime")
+	if err != nil {
+		return fmt.Errorf("failed to get scheduled time: %w", err)
+	}
+
+	backfill, err := cmd.Flags().GetBool("backfill")
+	if err != nil {
+		return fmt.Errorf("failed to get backfill flag: %w", err)
+	}
+
 	ctx := setup.loggerContext(cmd.Context(), quiet)
 
 	loadOpts := []digraph.LoadOption{
 		digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig),
+		digraph.WithTemplatesDir(setup.cfg.Paths.TemplatesDir),
 	}
 
 	var params string
@@ -71,10 +109,21 @@ func runStart(cmd *cobra.Command, args []string) error {
 		loadOpts = append(loadOpts, digraph.WithParams(removeQuotes(params)))
 	}
 
-	return executeDag(ctx, setup, args[0], loadOpts, quiet, requestID)
+	opts := agent.Options{Backfill: backfill}
+	if parentDAG != "" && parentRequestID != "" {
+		opts.TriggeredBy = parentRunRef(ctx, setup, parentDAG, parentRequestID)
+	}
+	if scheduledTime != "" {
+		opts.ScheduledTime, err = time.Parse(time.RFC3339, scheduledTime)
+		if err != nil {
+			return fmt.Errorf("failed to parse scheduled time %q: %w", scheduledTime, err)
+		}
+	}
+
+	return executeDag(ctx, setup, args[0], loadOpts, quiet, requestID, opts)
 }
 
-func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []digraph.LoadOption, quiet bool, requestID string) error {
+func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []digraph.LoadOption, quiet bool, requestID string, opts agent.Options) error {
 	dag, err := digraph.Load(ctx, specPath, loadOpts...)
 	if err != nil {
 		logger.Error(ctx, "Failed to load DAG", "path", specPath, "err", err)
@@ -113,6 +162,7 @@ func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []d
 		return fmt.Errorf("failed to initialize client: %w", err)
 	}
 
+	opts.Secrets = setup.secretResolver()
 	agt := agent.New(
 		requestID,
 		dag,
@@ -121,7 +171,7 @@ func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []d
 		cli,
 		dagStore,
 		setup.historyStore(),
-		agent.Options{},
+		opts,
 	)
 
 	listenSignals(ctx, agt)
@@ -144,6 +194,24 @@ func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []d
 	return nil
 }
 
+// parentRunRef returns the reference to the run of the DAG that triggered
+// the run.
+func parentRunRef(ctx context.Context, setup *setup, location, requestID string) *model.DAGRunRef {
+	ref := &model.DAGRunRef{
+		Name:      strings.TrimSuffix(filepath.Base(location), filepath.Ext(location)),
+		Location:  location,
+		RequestID: requestID,
+	}
+	dagStore, err := setup.dagStore()
+	if err != nil {
+		return ref
+	}
+	if dag, err := dagStore.GetMetadata(ctx, location); err == nil {
+		ref.Name = dag.Name
+	}
+	return ref
+}
+
 // removeQuotes removes the surrounding quotes from the string.
 func removeQuotes(s string) string {
 	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
@@ -13,10 +13,11 @@ func stopCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "stop /path/to/spec.yaml",
 		Short: "Stop the running DAG",
-		Long:  `dagu stop /path/to/spec.yaml`,
+		Long:  `dagu stop [--req=<request-id>] /path/to/spec.yaml`,
 		Args:  cobra.ExactArgs(1),
 		RunE:  wrapRunE(runStop),
 	}
+	cmd.Flags().StringP("req", "r", "", "request-id of the run to stop, e.g. a backfill run")
 	return cmd
 }
 
@@ -28,9 +29,17 @@ func runStop(cmd *cobra.Command, args []string) error {
 
 	setup := newSetup(cfg)
 
+	requestID, err := cmd.Flags().GetString("req")
+	if err != nil {
+		return fmt.Errorf("failed to get request ID: %w", err)
+	}
+
 	ctx := setup.loggerContext(cmd.Context(), false)
 
-	dag, err := digraph.Load(cmd.Context(), args[0], digraph.WithBaseConfig(cfg.Paths.BaseConfig))
+	dag, err := digraph.Load(cmd.Context(), args[0],
+		digraph.WithBaseConfig(cfg.Paths.BaseConfig),
+		digraph.WithTemplatesDir(cfg.Paths.TemplatesDir),
+	)
 	if err != nil {
 		logger.Error(ctx, "Failed to load DAG", "err", err)
 		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
@@ -44,7 +53,13 @@ func runStop(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize client: %w", err)
 	}
 
-	if err := cli.Stop(cmd.Context(), dag); err != nil {
+	if requestID != "" {
+		// The backfill runs are only reachable by the request ID.
+		err = cli.StopRun(cmd.Context(), dag, requestID)
+	} else {
+		err = cli.Stop(cmd.Context(), dag)
+	}
+	if err != nil {
 		logger.Error(ctx, "Failed to stop DAG", "dag", dag.Name, "err", err)
 		return fmt.Errorf("failed to stop DAG: %w", err)
 	}
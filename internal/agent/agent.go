@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"runtime/debug"
 	"strings"
@@ -22,7 +23,9 @@ import (
 	"github.com/dagu-org/dagu/internal/mailer"
 	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/secrets"
 	"github.com/dagu-org/dagu/internal/sock"
+	"github.com/dagu-org/dagu/internal/stringutil"
 )
 
 // Agent is responsible for running the DAG and handling communication
@@ -35,6 +38,9 @@ type Agent struct {
 	dag          *digraph.DAG
 	dry          bool
 	retryTarget  *model.Status
+	retryStep    string
+	retryOnly    bool
+	recover      bool
 	dagStore     persistence.DAGStore
 	client       client.Client
 	scheduler    *scheduler.Scheduler
@@ -44,6 +50,11 @@ type Agent struct {
 	socketServer *sock.Server
 	logDir       string
 	logFile      string
+	secrets      *secrets.Resolver
+	triggeredBy  *model.DAGRunRef
+	triggered    []model.DAGRunRef
+	scheduledAt  time.Time
+	backfill     bool
 
 	// requestID is request ID to identify DAG execution uniquely.
 	// The request ID can be used for history lookup, retry, etc.
@@ -63,6 +74,26 @@ type Options struct {
 	// If it's specified the agent will execute the DAG with the same
 	// configuration as the specified history.
 	RetryTarget *model.Status
+	// RetryStep is the step to retry from. The step and all of its
+	// descendants are run again even if they succeeded.
+	RetryStep string
+	// RetryOnlyStep retries only the RetryStep and its descendants, and
+	// keeps the states of the other steps even if they failed.
+	RetryOnlyStep bool
+	// Recover continues the run of the RetryTarget whose agent process died
+	// under the same request ID. The finished steps are not run again.
+	Recover bool
+	// Secrets is the resolver of the secrets of the DAG. Only the env and
+	// file providers are available if it's not specified.
+	Secrets *secrets.Resolver
+	// TriggeredBy is the run of the DAG that triggered this run.
+	TriggeredBy *model.DAGRunRef
+	// ScheduledTime is the logical time of the schedule tick of the run. It's
+	// passed to the steps as DAG_SCHEDULED_TIME.
+	ScheduledTime time.Time
+	// Backfill marks the run as a backfill of a past schedule tick. It runs
+	// alongside the other runs of the DAG with its own socket.
+	Backfill bool
 }
 
 // New creates a new Agent.
@@ -81,6 +112,13 @@ func New(
 		dag:          dag,
 		dry:          opts.Dry,
 		retryTarget:  opts.RetryTarget,
+		retryStep:    opts.RetryStep,
+		retryOnly:    opts.RetryOnlyStep,
+		recover:      opts.Recover,
+		secrets:      opts.Secrets,
+		triggeredBy:  opts.TriggeredBy,
+		scheduledAt:  opts.ScheduledTime,
+		backfill:     opts.Backfill,
 		logDir:       logDir,
 		logFile:      logFile,
 		client:       cli,
@@ -98,6 +136,10 @@ func (a *Agent) Run(ctx context.Context) error {
 	// Create a new context for the DAG execution
 	dbClient := newDBClient(a.historyStore, a.dagStore)
 	ctx = digraph.NewContext(ctx, a.dag, dbClient, a.requestID, a.logFile)
+	if !a.scheduledAt.IsZero() {
+		ctx = digraph.WithContext(ctx, digraph.GetContext(ctx).
+			WithEnv(digraph.EnvKeyScheduledTime, a.scheduledAt.Format(time.RFC3339)))
+	}
 
 	// It should not run the DAG if the condition is unmet.
 	if err := a.checkPreconditions(ctx); err != nil {
@@ -132,6 +174,16 @@ func (a *Agent) Run(ctx context.Context) error {
 		logger.Error(ctx, "Failed to write status", "err", err)
 	}
 
+	// Resolve the secrets to pass to the steps.
+	// The failure is recorded in the history so that the run is shown as
+	// failed instead of being lost.
+	ctx, err := a.setupSecrets(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to resolve secrets", "err", err)
+		a.failBeforeStart(ctx, err)
+		return err
+	}
+
 	// Start the unix socket server for receiving HTTP requests from
 	// the local client (e.g., the frontend server, scheduler, etc).
 	if err := a.setupSocketServer(ctx); err != nil {
@@ -190,6 +242,10 @@ func (a *Agent) Run(ctx context.Context) error {
 	logger.Info(ctx, "DAG execution started", "reqId", a.requestID, "name", a.dag.Name, "params", a.dag.Params)
 	lastErr := a.scheduler.Schedule(ctx, a.graph, done)
 
+	// Start the downstream DAGs before writing the finished status so that
+	// the triggered runs are recorded in it.
+	a.startTriggers(ctx, a.scheduler.Status(a.graph))
+
 	// Update the finished status to the history database.
 	finishedStatus := a.Status()
 	logger.Info(ctx, "DAG execution finished", "status", finishedStatus.Status)
@@ -210,6 +266,24 @@ func (a *Agent) Run(ctx context.Context) error {
 	return lastErr
 }
 
+// failBeforeStart records the run as failed by the error that occurred
+// before any step started.
+func (a *Agent) failBeforeStart(ctx context.Context, err error) {
+	a.lastErr = err
+	a.finished.Store(true)
+
+	now := time.Now()
+	status := a.Status()
+	status.Status = scheduler.StatusError
+	status.StatusText = scheduler.StatusError.String()
+	status.StartedAt = model.FormatTime(now)
+	status.FinishedAt = model.FormatTime(now)
+	status.Error = err.Error()
+	if err := a.historyStore.Write(ctx, status); err != nil {
+		logger.Error(ctx, "Status write failed", "err", err)
+	}
+}
+
 func (a *Agent) PrintSummary(ctx context.Context) {
 	status := a.Status()
 	summary := a.reporter.getSummary(ctx, status, a.lastErr)
@@ -238,10 +312,15 @@ func (a *Agent) Status() model.Status {
 			model.WithFinishedAt(a.graph.FinishAt()),
 			model.WithNodes(a.graph.NodeData()),
 			model.WithLogFilePath(a.logFile),
+			model.WithArtifactDir(a.artifactDir()),
 			model.WithOnExitNode(a.scheduler.HandlerNode(digraph.HandlerOnExit)),
 			model.WithOnSuccessNode(a.scheduler.HandlerNode(digraph.HandlerOnSuccess)),
 			model.WithOnFailureNode(a.scheduler.HandlerNode(digraph.HandlerOnFailure)),
 			model.WithOnCancelNode(a.scheduler.HandlerNode(digraph.HandlerOnCancel)),
+			model.WithTriggeredBy(a.triggeredBy),
+			model.WithTriggered(a.triggered),
+			model.WithScheduledTime(a.scheduledAt),
+			model.WithBackfill(a.backfill),
 		)
 }
 
@@ -255,8 +334,11 @@ const waitForRunning = time.Millisecond * 100
 
 // Simple regular expressions for request routing
 var (
-	statusRe = regexp.MustCompile(`^/status[/]?$`)
-	stopRe   = regexp.MustCompile(`^/stop[/]?$`)
+	statusRe  = regexp.MustCompile(`^/status[/]?$`)
+	stopRe    = regexp.MustCompile(`^/stop[/]?$`)
+	approveRe = regexp.MustCompile(`^/approve[/]?$`)
+	pauseRe   = regexp.MustCompile(`^/pause[/]?$`)
+	resumeRe  = regexp.MustCompile(`^/resume[/]?$`)
 )
 
 // HandleHTTP handles HTTP requests via unix socket.
@@ -267,7 +349,9 @@ func (a *Agent) HandleHTTP(ctx context.Context) sock.HTTPHandlerFunc {
 		case r.Method == http.MethodGet && statusRe.MatchString(r.URL.Path):
 			// Return the current status of the execution.
 			status := a.Status()
-			status.Status = scheduler.StatusRunning
+			if status.Status != scheduler.StatusPaused {
+				status.Status = scheduler.StatusRunning
+			}
 			statusJSON, err := json.Marshal(status)
 			if err != nil {
 				encodeError(w, err)
@@ -283,6 +367,34 @@ func (a *Agent) HandleHTTP(ctx context.Context) sock.HTTPHandlerFunc {
 				logger.Info(ctx, "Stop request received")
 				a.signal(ctx, syscall.SIGTERM, true)
 			}()
+		case r.Method == http.MethodPost && approveRe.MatchString(r.URL.Path):
+			// Handle Approve request for the step waiting for approval.
+			if err := a.approve(ctx, r); err != nil {
+				encodeError(w, &httpError{Code: http.StatusBadRequest, Message: err.Error()})
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("OK"))
+		case r.Method == http.MethodPost && pauseRe.MatchString(r.URL.Path):
+			// Handle Pause request to stop launching new steps.
+			logger.Info(ctx, "Pause request received")
+			if err := a.scheduler.Pause(); err != nil {
+				encodeError(w, &httpError{Code: http.StatusBadRequest, Message: err.Error()})
+				return
+			}
+			a.writeStatus(ctx)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("OK"))
+		case r.Method == http.MethodPost && resumeRe.MatchString(r.URL.Path):
+			// Handle Resume request to continue the paused run.
+			logger.Info(ctx, "Resume request received")
+			if err := a.scheduler.Resume(); err != nil {
+				encodeError(w, &httpError{Code: http.StatusBadRequest, Message: err.Error()})
+				return
+			}
+			a.writeStatus(ctx)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("OK"))
 		default:
 			// Unknown request
 			encodeError(
@@ -292,6 +404,43 @@ func (a *Agent) HandleHTTP(ctx context.Context) sock.HTTPHandlerFunc {
 	}
 }
 
+// writeStatus writes the current status to the history database, e.g. to
+// record the paused status without waiting for the next step to finish.
+func (a *Agent) writeStatus(ctx context.Context) {
+	if err := a.historyStore.Write(ctx, a.Status()); err != nil {
+		logger.Error(ctx, "Status write failed", "err", err)
+	}
+}
+
+// approve sends the decision on the step waiting for approval. The step
+// name, the user, the comment and the action ("approve" or "reject") are
+// passed as the query parameters.
+func (a *Agent) approve(ctx context.Context, r *http.Request) error {
+	query := r.URL.Query()
+	step := query.Get("step")
+	if step == "" {
+		return errors.New("step is required")
+	}
+
+	var approved bool
+	switch action := query.Get("action"); action {
+	case "", "approve":
+		approved = true
+	case "reject":
+		approved = false
+	default:
+		return fmt.Errorf("invalid action: %s", action)
+	}
+
+	decision := scheduler.ApprovalDecision{
+		Approved: approved,
+		User:     query.Get("user"),
+		Comment:  query.Get("comment"),
+	}
+	logger.Info(ctx, "Approval request received", "step", step, "approved", approved, "user", decision.User)
+	return a.scheduler.Approve(a.graph, step, decision)
+}
+
 // setup the agent instance for DAG execution.
 func (a *Agent) setup(ctx context.Context) error {
 	// Lock to prevent race condition.
@@ -314,13 +463,21 @@ func (a *Agent) setup(ctx context.Context) error {
 func (a *Agent) newScheduler() *scheduler.Scheduler {
 	cfg := &scheduler.Config{
 		LogDir:        a.logDir,
+		ArtifactDir:   a.artifactDir(),
 		MaxActiveRuns: a.dag.MaxActiveRuns,
 		Timeout:       a.dag.Timeout,
 		Delay:         a.dag.Delay,
 		Dry:           a.dry,
+		WaitFor:       a.dag.WaitFor,
 		ReqID:         a.requestID,
 	}
 
+	if a.recover && hasStartedSteps(a.retryTarget) {
+		// The runs of other DAGs were waited for before the steps started.
+		// Otherwise, the process died while waiting and it waits again.
+		cfg.WaitFor = nil
+	}
+
 	if a.dag.HandlerOn.Exit != nil {
 		cfg.OnExit = a.dag.HandlerOn.Exit
 	}
@@ -368,6 +525,24 @@ func (a *Agent) dryRun(ctx context.Context) error {
 	return lastErr
 }
 
+// setupSecrets resolves the values of the secrets and sets them to the DAG
+// context. The values are passed to the executors as environment variables.
+func (a *Agent) setupSecrets(ctx context.Context) (context.Context, error) {
+	if len(a.dag.Secrets) == 0 {
+		return ctx, nil
+	}
+	resolver := a.secrets
+	if resolver == nil {
+		resolver = secrets.NewResolver(nil)
+	}
+	values, err := a.dag.ResolveSecrets(ctx, resolver)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+	dagCtx := digraph.GetContext(ctx).WithSecrets(values)
+	return digraph.WithContext(ctx, dagCtx), nil
+}
+
 // signal propagates the received signal to the all running child processes.
 // allowOverride parameters is used to specify if a node can override
 // the signal to send to the process, in case the node is configured
@@ -407,6 +582,16 @@ func (a *Agent) signal(ctx context.Context, sig os.Signal, allowOverride bool) {
 	}
 }
 
+// artifactDir returns the directory to store the artifacts of the run.
+// A retry execution uses the directory of the original run so that the
+// retried steps see the original artifacts.
+func (a *Agent) artifactDir() string {
+	if a.retryTarget != nil && a.retryTarget.ArtifactDir != "" {
+		return a.retryTarget.ArtifactDir
+	}
+	return filepath.Join(a.logDir, "artifacts", a.requestID)
+}
+
 // setupGraph setups the DAG graph. If is retry execution, it loads nodes
 // from the retry node so that it runs the same DAG as the previous run.
 func (a *Agent) setupGraph(ctx context.Context) error {
@@ -422,12 +607,38 @@ func (a *Agent) setupGraph(ctx context.Context) error {
 	return nil
 }
 
+// hasStartedSteps returns true if any step of the run has started.
+func hasStartedSteps(status *model.Status) bool {
+	for _, node := range status.Nodes {
+		if node.Status != scheduler.NodeStatusNone {
+			return true
+		}
+	}
+	return false
+}
+
 // setupGraphForRetry setsup the graph for retry.
 func (a *Agent) setupGraphForRetry(ctx context.Context) error {
 	nodes := make([]*scheduler.Node, 0, len(a.retryTarget.Nodes))
 	for _, n := range a.retryTarget.Nodes {
 		nodes = append(nodes, n.ToNode())
 	}
+	if a.recover {
+		graph, err := scheduler.CreateRecoveryExecutionGraph(ctx, nodes...)
+		if err != nil {
+			return err
+		}
+		a.graph = graph
+		return nil
+	}
+	if a.retryStep != "" {
+		graph, err := scheduler.CreateStepRetryExecutionGraph(ctx, a.retryStep, a.retryOnly, nodes...)
+		if err != nil {
+			return err
+		}
+		a.graph = graph
+		return nil
+	}
 	graph, err := scheduler.CreateRetryExecutionGraph(ctx, nodes...)
 	if err != nil {
 		return err
@@ -443,12 +654,16 @@ func (a *Agent) setupDatabase(ctx context.Context) error {
 		logger.Error(ctx, "History data cleanup failed", "err", err)
 	}
 
+	if a.recover {
+		// Continue writing to the status file of the run.
+		return a.historyStore.Reopen(ctx, a.dag.Location, a.requestID)
+	}
 	return a.historyStore.Open(ctx, a.dag.Location, time.Now(), a.requestID)
 }
 
 // setupSocketServer create socket server instance.
 func (a *Agent) setupSocketServer(ctx context.Context) error {
-	socketServer, err := sock.NewServer(a.dag.SockAddr(), a.HandleHTTP(ctx))
+	socketServer, err := sock.NewServer(a.sockAddr(), a.HandleHTTP(ctx))
 	if err != nil {
 		return err
 	}
@@ -473,6 +688,10 @@ func (a *Agent) checkPreconditions(ctx context.Context) error {
 
 // checkIsAlreadyRunning returns error if the DAG is already running.
 func (a *Agent) checkIsAlreadyRunning(ctx context.Context) error {
+	if a.backfill {
+		// The backfill runs don't block the other runs.
+		return nil
+	}
 	status, err := a.client.GetCurrentStatus(ctx, a.dag)
 	if err != nil {
 		return err
@@ -483,6 +702,14 @@ func (a *Agent) checkIsAlreadyRunning(ctx context.Context) error {
 	return nil
 }
 
+// sockAddr returns the address of the unix socket of the run.
+func (a *Agent) sockAddr() string {
+	if a.backfill {
+		return a.dag.SockAddrForRequest(a.requestID)
+	}
+	return a.dag.SockAddr()
+}
+
 func execWithRecovery(ctx context.Context, fn func()) {
 	defer func() {
 		if panicObj := recover(); panicObj != nil {
@@ -540,9 +767,21 @@ func (o *dbClient) GetStatus(ctx context.Context, name string, requestID string)
 	if err != nil {
 		return nil, err
 	}
+	return toDigraphStatus(status.Status), nil
+}
+
+// GetRecentStatuses implements digraph.DBClient.
+func (o *dbClient) GetRecentStatuses(ctx context.Context, name string, n int) ([]*digraph.Status, error) {
+	var ret []*digraph.Status
+	for _, status := range o.historyStore.ReadStatusRecent(ctx, name, n) {
+		ret = append(ret, toDigraphStatus(status.Status))
+	}
+	return ret, nil
+}
 
+func toDigraphStatus(status model.Status) *digraph.Status {
 	outputVariables := map[string]string{}
-	for _, node := range status.Status.Nodes {
+	for _, node := range status.Nodes {
 		if node.Step.OutputVariables != nil {
 			node.Step.OutputVariables.Range(func(_, value any) bool {
 				// split the value by '=' to get the key and value
@@ -555,9 +794,16 @@ func (o *dbClient) GetStatus(ctx context.Context, name string, requestID string)
 		}
 	}
 
+	startedAt, _ := stringutil.ParseTime(status.StartedAt)
+	finishedAt, _ := stringutil.ParseTime(status.FinishedAt)
+
 	return &digraph.Status{
-		Outputs: outputVariables,
-		Name:    status.Status.Name,
-		Params:  status.Status.Params,
-	}, nil
+		Outputs:    outputVariables,
+		Name:       status.Name,
+		Params:     status.Params,
+		RequestID:  status.RequestID,
+		Succeeded:  status.Status == scheduler.StatusSuccess,
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+	}
 }
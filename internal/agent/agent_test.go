@@ -92,6 +92,21 @@ func TestAgent_Run(t *testing.T) {
 		// Check if the status is saved correctly
 		require.Equal(t, scheduler.StatusError, dagAgent.Status().Status)
 	})
+	t.Run("SecretNotFound", func(t *testing.T) {
+		th := test.Setup(t)
+		dag := th.LoadDAGFile(t, "secret_not_found.yaml")
+		dagAgent := dag.Agent()
+
+		err := dagAgent.Run(dagAgent.Context)
+		require.ErrorContains(t, err, "DAGU_TEST_SECRET_NOT_FOUND")
+
+		// Check if the failure is recorded in the history
+		status, err := dag.Client.GetLatestStatus(dag.Context, dag.DAG)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusError, status.Status)
+		require.Contains(t, status.Error, "DAGU_TEST_SECRET_NOT_FOUND")
+		require.Equal(t, scheduler.NodeStatusNone, status.Nodes[0].Status)
+	})
 	t.Run("FinishWithTimeout", func(t *testing.T) {
 		th := test.Setup(t)
 		timeoutDAG := th.LoadDAGFile(t, "timeout.yaml")
@@ -135,6 +150,20 @@ func TestAgent_Run(t *testing.T) {
 		// Check if the exit handler is executed
 		require.Equal(t, scheduler.NodeStatusSuccess.String(), status.OnExit.Status.String())
 	})
+	t.Run("Triggers", func(t *testing.T) {
+		th := test.Setup(t)
+		dag := th.LoadDAGFile(t, "triggers.yaml")
+		dagAgent := dag.Agent()
+		dagAgent.RunSuccess(t)
+
+		// Check if the DAG to trigger on success is recorded with the error
+		// since it does not exist
+		status := dagAgent.Status()
+		require.Len(t, status.Triggered, 1)
+		require.Equal(t, "missing_dag", status.Triggered[0].Name)
+		require.Equal(t, digraph.HandlerOnSuccess.String(), status.Triggered[0].On)
+		require.NotEmpty(t, status.Triggered[0].Error)
+	})
 }
 
 func TestAgent_DryRun(t *testing.T) {
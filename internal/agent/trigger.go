@@ -0,0 +1,115 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/cmdutil"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/google/uuid"
+)
+
+// triggerEvents returns the events to start the downstream DAGs on for the
+// status of the finished run.
+func triggerEvents(status scheduler.Status) []digraph.HandlerType {
+	switch status {
+	case scheduler.StatusSuccess:
+		return []digraph.HandlerType{digraph.HandlerOnSuccess, digraph.HandlerOnExit}
+	case scheduler.StatusError:
+		return []digraph.HandlerType{digraph.HandlerOnFailure, digraph.HandlerOnExit}
+	case scheduler.StatusCancel:
+		return []digraph.HandlerType{digraph.HandlerOnCancel, digraph.HandlerOnExit}
+	default:
+		return nil
+	}
+}
+
+// startTriggers starts the downstream DAGs of the finished run and records
+// the runs so that the lineage can be followed from both sides.
+func (a *Agent) startTriggers(ctx context.Context, status scheduler.Status) {
+	triggers := a.dag.TriggersOn(triggerEvents(status)...)
+	if len(triggers) == 0 {
+		return
+	}
+
+	outputs := outputVariables(a.graph.NodeData())
+	parent := &model.DAGRunRef{
+		Name:      a.dag.Name,
+		Location:  a.dag.Location,
+		RequestID: a.requestID,
+	}
+
+	var refs []model.DAGRunRef
+	for _, trigger := range triggers {
+		ref := model.DAGRunRef{Name: trigger.DAG, On: trigger.On.String()}
+		if err := a.startTrigger(ctx, trigger, outputs, parent, &ref); err != nil {
+			logger.Error(ctx, "Failed to trigger the DAG", "dag", trigger.DAG, "err", err)
+			ref.Error = err.Error()
+		} else {
+			logger.Info(ctx, "Triggered the DAG", "dag", trigger.DAG, "requestId", ref.RequestID)
+		}
+		refs = append(refs, ref)
+	}
+
+	a.lock.Lock()
+	a.triggered = refs
+	a.lock.Unlock()
+}
+
+func (a *Agent) startTrigger(
+	ctx context.Context,
+	trigger digraph.DAGTrigger,
+	outputs map[string]string,
+	parent *model.DAGRunRef,
+	ref *model.DAGRunRef,
+) error {
+	dag, err := a.dagStore.GetMetadata(ctx, trigger.DAG)
+	if err != nil {
+		return fmt.Errorf("failed to get the DAG: %w", err)
+	}
+	ref.Name = dag.Name
+	ref.Location = dag.Location
+
+	params, err := digraph.GetContext(ctx).EvalString(trigger.Params, cmdutil.WithVariables(outputs))
+	if err != nil {
+		return fmt.Errorf("failed to evaluate the params: %w", err)
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("failed to generate request ID: %w", err)
+	}
+	ref.RequestID = id.String()
+
+	return a.client.Start(ctx, dag, client.StartOptions{
+		Params:      params,
+		Quiet:       true,
+		RequestID:   ref.RequestID,
+		TriggeredBy: parent,
+		Detach:      true,
+	})
+}
+
+// outputVariables collects the output variables of the steps.
+func outputVariables(nodes []scheduler.NodeData) map[string]string {
+	ret := map[string]string{}
+	for _, node := range nodes {
+		if node.Step.OutputVariables == nil {
+			continue
+		}
+		node.Step.OutputVariables.Range(func(_, value any) bool {
+			// split the value by '=' to get the key and value
+			parts := strings.SplitN(value.(string), "=", 2)
+			if len(parts) == 2 {
+				ret[parts[0]] = parts[1]
+			}
+			return true
+		})
+	}
+	return ret
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
@@ -97,6 +99,54 @@ func (e *client) Stop(_ context.Context, dag *digraph.DAG) error {
 	return err
 }
 
+// StopRun stops the run of the request ID. Unlike Stop, it reaches the runs
+// that listen on their own socket, e.g. backfills.
+func (e *client) StopRun(ctx context.Context, dag *digraph.DAG, requestID string) error {
+	ret, err := e.historyStore.FindByRequestID(ctx, dag.Location, requestID)
+	if err != nil {
+		return err
+	}
+	client := sock.NewClient(runSockAddr(dag, &ret.Status))
+	_, err = client.Request("POST", "/stop")
+	return err
+}
+
+func (e *client) Pause(_ context.Context, dag *digraph.DAG) error {
+	return e.request(dag, "/pause", "pause")
+}
+
+func (e *client) Resume(_ context.Context, dag *digraph.DAG) error {
+	return e.request(dag, "/resume", "resume")
+}
+
+// request sends the request to the running DAG and returns an error unless
+// it responds "OK".
+func (*client) request(dag *digraph.DAG, path, action string) error {
+	client := sock.NewClient(dag.SockAddr())
+	ret, err := client.Request("POST", path)
+	if err != nil {
+		return fmt.Errorf("the DAG is not running: %w", err)
+	}
+	if ret != "OK" {
+		return fmt.Errorf("failed to %s: %s", action, strings.TrimSpace(ret))
+	}
+	return nil
+}
+
+func (e *client) Approve(_ context.Context, dag *digraph.DAG, step string, opts ApproveOptions) error {
+	action := "approve"
+	if opts.Reject {
+		action = "reject"
+	}
+	query := url.Values{}
+	query.Set("step", step)
+	query.Set("action", action)
+	query.Set("user", opts.User)
+	query.Set("comment", opts.Comment)
+
+	return e.request(dag, "/approve?"+query.Encode(), fmt.Sprintf("%s the step %s", action, step))
+}
+
 func (e *client) StartAsync(ctx context.Context, dag *digraph.DAG, opts StartOptions) {
 	go func() {
 		if err := e.Start(ctx, dag, opts); err != nil {
@@ -114,19 +164,39 @@ func (e *client) Start(_ context.Context, dag *digraph.DAG, opts StartOptions) e
 	if opts.Quiet {
 		args = append(args, "-q")
 	}
+	if opts.RequestID != "" {
+		args = append(args, fmt.Sprintf("--requestID=%s", opts.RequestID))
+	}
+	if opts.TriggeredBy != nil {
+		args = append(args,
+			fmt.Sprintf("--parentDAG=%s", opts.TriggeredBy.Location),
+			fmt.Sprintf("--parentRequestID=%s", opts.TriggeredBy.RequestID),
+		)
+	}
+	if !opts.ScheduledTime.IsZero() {
+		args = append(args, fmt.Sprintf("--scheduledTime=%s", opts.ScheduledTime.Format(time.RFC3339)))
+	}
+	if opts.Backfill {
+		args = append(args, "--backfill")
+	}
 	args = append(args, dag.Location)
 	// nolint:gosec
 	cmd := exec.Command(e.executable, args...)
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pgid: 0}
 	cmd.Dir = e.workDir
 	cmd.Env = os.Environ()
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	if !opts.Detach {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
 
 	err := cmd.Start()
 	if err != nil {
 		return err
 	}
+	if opts.Detach {
+		return cmd.Process.Release()
+	}
 	return cmd.Wait()
 }
 
@@ -148,9 +218,15 @@ func (e *client) Restart(_ context.Context, dag *digraph.DAG, opts RestartOption
 	return cmd.Wait()
 }
 
-func (e *client) Retry(_ context.Context, dag *digraph.DAG, requestID string) error {
+func (e *client) Retry(_ context.Context, dag *digraph.DAG, requestID string, opts RetryOptions) error {
 	args := []string{"retry"}
 	args = append(args, fmt.Sprintf("--req=%s", requestID))
+	if opts.FromStep != "" {
+		args = append(args, fmt.Sprintf("--from-step=%s", opts.FromStep))
+	}
+	if opts.OnlyStep != "" {
+		args = append(args, fmt.Sprintf("--only-step=%s", opts.OnlyStep))
+	}
 	args = append(args, dag.Location)
 	// nolint:gosec
 	cmd := exec.Command(e.executable, args...)
@@ -164,8 +240,71 @@ func (e *client) Retry(_ context.Context, dag *digraph.DAG, requestID string) er
 	return cmd.Wait()
 }
 
-func (*client) GetCurrentStatus(_ context.Context, dag *digraph.DAG) (*model.Status, error) {
-	client := sock.NewClient(dag.SockAddr())
+func (e *client) Recover(_ context.Context, dag *digraph.DAG, requestID string) error {
+	args := []string{"recover", fmt.Sprintf("--req=%s", requestID), dag.Location}
+	// nolint:gosec
+	cmd := exec.Command(e.executable, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pgid: 0}
+	cmd.Dir = e.workDir
+	cmd.Env = os.Environ()
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Process.Release()
+}
+
+// GetOrphanedRun returns the latest run of the DAG if its agent process died
+// while running it, or nil if there is no such run.
+func (e *client) GetOrphanedRun(ctx context.Context, dag *digraph.DAG) (*model.Status, error) {
+	recent := e.historyStore.ReadStatusRecent(ctx, dag.Location, 1)
+	if len(recent) == 0 {
+		return nil, nil
+	}
+	status := recent[0].Status
+	if !IsOrphaned(dag, &status) {
+		return nil, nil
+	}
+	return &status, nil
+}
+
+// IsOrphaned returns true if the status is of the run whose agent process
+// died, i.e. the run is still active in the history while the process is
+// gone and the socket of the run doesn't respond.
+func IsOrphaned(dag *digraph.DAG, status *model.Status) bool {
+	if !status.Status.IsActive() {
+		return false
+	}
+	if isProcessAlive(int(status.PID)) {
+		return false
+	}
+	_, err := sock.NewClient(runSockAddr(dag, status)).Request("GET", "/status")
+	return err != nil && !errors.Is(err, sock.ErrTimeout)
+}
+
+// runSockAddr returns the address of the socket the run of the status listens
+// on. The backfill runs listen on the socket of their request ID so that they
+// can run alongside the other runs.
+func runSockAddr(dag *digraph.DAG, status *model.Status) string {
+	if status.Backfill && status.RequestID != "" {
+		return dag.SockAddrForRequest(status.RequestID)
+	}
+	return dag.SockAddr()
+}
+
+func isProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+func (e *client) GetCurrentStatus(_ context.Context, dag *digraph.DAG) (*model.Status, error) {
+	return e.getCurrentStatus(dag, dag.SockAddr())
+}
+
+func (*client) getCurrentStatus(dag *digraph.DAG, addr string) (*model.Status, error) {
+	client := sock.NewClient(addr)
 	ret, err := client.Request("GET", "/status")
 	if err != nil {
 		if errors.Is(err, sock.ErrTimeout) {
@@ -185,7 +324,7 @@ func (e *client) GetStatusByRequestID(ctx context.Context, dag *digraph.DAG, req
 	if err != nil {
 		return nil, err
 	}
-	status, _ := e.GetCurrentStatus(ctx, dag)
+	status, _ := e.getCurrentStatus(dag, runSockAddr(dag, &ret.Status))
 	if status != nil && status.RequestID != requestID {
 		// if the request id is not matched then correct the status
 		ret.Status.CorrectRunningStatus()
@@ -228,7 +367,7 @@ func (e *client) GetRecentHistory(ctx context.Context, dag *digraph.DAG, n int)
 var errDAGIsRunning = errors.New("the DAG is running")
 
 func (e *client) UpdateStatus(ctx context.Context, dag *digraph.DAG, status model.Status) error {
-	client := sock.NewClient(dag.SockAddr())
+	client := sock.NewClient(runSockAddr(dag, &status))
 	res, err := client.Request("GET", "/status")
 	if err != nil {
 		if errors.Is(err, sock.ErrTimeout) {
@@ -237,7 +376,7 @@ func (e *client) UpdateStatus(ctx context.Context, dag *digraph.DAG, status mode
 	} else {
 		unmarshalled, _ := model.StatusFromJSON(res)
 		if unmarshalled != nil && unmarshalled.RequestID == status.RequestID &&
-			unmarshalled.Status == scheduler.StatusRunning {
+			unmarshalled.Status.IsActive() {
 			return errDAGIsRunning
 		}
 	}
@@ -102,6 +102,67 @@ func TestClient_GetStatus(t *testing.T) {
 		require.Equal(t, 1, len(status.Nodes))
 		require.Equal(t, newStatus, statusByRequestID.Nodes[0].Status)
 	})
+	t.Run("BackfillRun", func(t *testing.T) {
+		dag := th.LoadDAGFile(t, "valid.yaml")
+		ctx := th.Context
+		cli := th.Client
+
+		// Write the status of a backfill run whose process is not alive.
+		requestID := "test-backfill-run"
+		status := model.NewStatusFactory(dag.DAG).Create(
+			requestID, scheduler.StatusRunning, 0, time.Now(), model.WithBackfill(true),
+		)
+		err := th.HistoryStore.Open(ctx, dag.Location, time.Now(), requestID)
+		require.NoError(t, err)
+		require.NoError(t, th.HistoryStore.Write(ctx, status))
+		_ = th.HistoryStore.Close(ctx)
+
+		// The backfill run listens on the socket of its request ID.
+		stopped := make(chan struct{}, 1)
+		socketServer, _ := sock.NewServer(
+			dag.SockAddrForRequest(requestID),
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost && r.URL.Path == "/stop" {
+					stopped <- struct{}{}
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte("OK"))
+					return
+				}
+				jsonData, err := json.Marshal(status)
+				if err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(jsonData)
+			},
+		)
+		go func() {
+			_ = socketServer.Serve(ctx, nil)
+			_ = socketServer.Shutdown(ctx)
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		// The run is not corrected nor treated as orphaned.
+		statusByRequestID, err := cli.GetStatusByRequestID(ctx, dag.DAG, requestID)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusRunning, statusByRequestID.Status)
+		require.False(t, client.IsOrphaned(dag.DAG, &status))
+
+		// The status can't be updated while the run is active.
+		require.Error(t, cli.UpdateStatus(ctx, dag.DAG, status))
+
+		require.NoError(t, cli.StopRun(ctx, dag.DAG, requestID))
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			t.Fatal("the backfill run is not stopped")
+		}
+
+		_ = socketServer.Shutdown(ctx)
+
+		require.True(t, client.IsOrphaned(dag.DAG, &status))
+	})
 	t.Run("InvalidUpdateStatusWithInvalidReqID", func(t *testing.T) {
 		wrongReqID := "invalid-request-id"
 		dag := th.LoadDAGFile(t, "invalid_reqid.yaml")
@@ -173,7 +234,7 @@ func TestClient_RunDAG(t *testing.T) {
 		previousRequestID := status.RequestID
 		previousParams := status.Params
 
-		err = cli.Retry(ctx, dag.DAG, previousRequestID)
+		err = cli.Retry(ctx, dag.DAG, previousRequestID, client.RetryOptions{})
 		require.NoError(t, err)
 
 		// Wait for the DAG to finish
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"path/filepath"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/dags"
@@ -16,10 +17,16 @@ type Client interface {
 	Grep(ctx context.Context, pattern string) ([]*persistence.GrepResult, []string, error)
 	Rename(ctx context.Context, oldID, newID string) error
 	Stop(ctx context.Context, dag *digraph.DAG) error
+	StopRun(ctx context.Context, dag *digraph.DAG, requestID string) error
+	Pause(ctx context.Context, dag *digraph.DAG) error
+	Resume(ctx context.Context, dag *digraph.DAG) error
 	StartAsync(ctx context.Context, dag *digraph.DAG, opts StartOptions)
 	Start(ctx context.Context, dag *digraph.DAG, opts StartOptions) error
 	Restart(ctx context.Context, dag *digraph.DAG, opts RestartOptions) error
-	Retry(ctx context.Context, dag *digraph.DAG, requestID string) error
+	Retry(ctx context.Context, dag *digraph.DAG, requestID string, opts RetryOptions) error
+	Approve(ctx context.Context, dag *digraph.DAG, step string, opts ApproveOptions) error
+	Recover(ctx context.Context, dag *digraph.DAG, requestID string) error
+	GetOrphanedRun(ctx context.Context, dag *digraph.DAG) (*model.Status, error)
 	GetCurrentStatus(ctx context.Context, dag *digraph.DAG) (*model.Status, error)
 	GetStatusByRequestID(ctx context.Context, dag *digraph.DAG, requestID string) (*model.Status, error)
 	GetLatestStatus(ctx context.Context, dag *digraph.DAG) (model.Status, error)
@@ -38,12 +45,40 @@ type Client interface {
 type StartOptions struct {
 	Params string
 	Quiet  bool
+	// RequestID is the request ID of the run. It's generated if empty.
+	RequestID string
+	// TriggeredBy is the run of the DAG that triggered the run.
+	TriggeredBy *model.DAGRunRef
+	// Detach returns as soon as the process is started without waiting for
+	// the run to finish.
+	Detach bool
+	// ScheduledTime is the logical time of the schedule tick of the run.
+	ScheduledTime time.Time
+	// Backfill marks the run as a backfill of the scheduled time.
+	Backfill bool
 }
 
 type RestartOptions struct {
 	Quiet bool
 }
 
+type RetryOptions struct {
+	// FromStep is the step to retry from. The step and all of its
+	// descendants are run again along with the failed steps.
+	FromStep string
+	// OnlyStep is the step to retry only with its descendants.
+	OnlyStep string
+}
+
+type ApproveOptions struct {
+	// Reject rejects the step instead of approving it.
+	Reject bool
+	// User is the user who approves or rejects the step.
+	User string
+	// Comment is the comment on the decision.
+	Comment string
+}
+
 type DAGStatus struct {
 	File      string
 	Dir       string
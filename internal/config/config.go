@@ -70,6 +70,9 @@ type Config struct {
 
 	UI UI `mapstructure:"ui"`
 
+	// Scheduler configuration
+	Scheduler SchedulerConfig `mapstructure:"scheduler"`
+
 	// Remote nodes configuration
 	RemoteNodes []RemoteNode `mapstructure:"remoteNodes"`
 
@@ -105,6 +108,9 @@ type PathsConfig struct {
 	SuspendFlagsDir string `mapstructure:"suspendFlagsDir"`
 	AdminLogsDir    string `mapstructure:"adminLogsDir"`
 	BaseConfig      string `mapstructure:"baseConfig"`
+	TemplatesDir    string `mapstructure:"templatesDir"`
+	KeystoreFile    string `mapstructure:"keystoreFile"`
+	KeystoreKeyFile string `mapstructure:"keystoreKeyFile"`
 }
 
 type UI struct {
@@ -114,6 +120,21 @@ type UI struct {
 	MaxDashboardPageLimit int    `mapstructure:"maxDashboardPageLimit"`
 }
 
+// SchedulerConfig represents the scheduler configuration
+type SchedulerConfig struct {
+	// AutoRecover recovers the runs whose agent process died when the
+	// scheduler starts.
+	AutoRecover bool `mapstructure:"autoRecover"`
+
+	// LeaderElection elects the leader among the schedulers sharing the data
+	// directory so that only the leader runs the DAGs.
+	LeaderElection bool `mapstructure:"leaderElection"`
+
+	// LeaseDuration is the duration of the lease of the leader. A standby
+	// scheduler takes over when the leader doesn't renew the lease in time.
+	LeaseDuration time.Duration `mapstructure:"leaseDuration"`
+}
+
 // RemoteNode represents a remote node configuration
 type RemoteNode struct {
 	Name              string `mapstructure:"name"`
@@ -116,6 +116,9 @@ func (l *ConfigLoader) setDefaultValues(resolver PathResolver) {
 	viper.SetDefault("paths.logDir", resolver.LogsDir)
 	viper.SetDefault("paths.adminLogsDir", resolver.AdminLogsDir)
 	viper.SetDefault("paths.baseConfig", resolver.BaseConfigFile)
+	viper.SetDefault("paths.templatesDir", resolver.TemplatesDir)
+	viper.SetDefault("paths.keystoreFile", resolver.KeystoreFile)
+	viper.SetDefault("paths.keystoreKeyFile", resolver.KeystoreKeyFile)
 
 	// Server settings
 	viper.SetDefault("host", "127.0.0.1")
@@ -132,6 +135,11 @@ func (l *ConfigLoader) setDefaultValues(resolver PathResolver) {
 
 	// Logging settings
 	viper.SetDefault("logFormat", "text")
+
+	// Scheduler settings
+	viper.SetDefault("scheduler.autoRecover", false)
+	viper.SetDefault("scheduler.leaderElection", false)
+	viper.SetDefault("scheduler.leaseDuration", "30s")
 }
 
 func (l *ConfigLoader) bindEnvironmentVariables() {
@@ -184,9 +192,17 @@ func (l *ConfigLoader) bindEnvironmentVariables() {
 	l.bindEnv("suspendFlagsDir", "SUSPEND_FLAGS_DIR")
 	l.bindEnv("adminLogsDir", "ADMIN_LOG_DIR")
 	l.bindEnv("executable", "EXECUTABLE")
+	l.bindEnv("paths.templatesDir", "TEMPLATES_DIR")
+	l.bindEnv("paths.keystoreFile", "KEYSTORE_FILE")
+	l.bindEnv("paths.keystoreKeyFile", "KEYSTORE_KEY_FILE")
 
 	// UI customization
 	l.bindEnv("latestStatusToday", "LATEST_STATUS_TODAY")
+
+	// Scheduler settings
+	l.bindEnv("scheduler.autoRecover", "SCHEDULER_AUTO_RECOVER")
+	l.bindEnv("scheduler.leaderElection", "SCHEDULER_LEADER_ELECTION")
+	l.bindEnv("scheduler.leaseDuration", "SCHEDULER_LEASE_DURATION")
 }
 
 func (l *ConfigLoader) bindEnv(key, env string) {
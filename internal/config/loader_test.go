@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -189,6 +190,9 @@ func TestConfigLoader_BindEnvironmentVariables(t *testing.T) {
 		"DAGU_AUTH_BASIC_USERNAME": "env-user",
 		"DAGU_AUTH_BASIC_PASSWORD": "env-pass",
 		"DAGU_UI_NAVBAR_TITLE":     "Env Title",
+
+		"DAGU_SCHEDULER_LEADER_ELECTION": "true",
+		"DAGU_SCHEDULER_LEASE_DURATION":  "15s",
 	}
 
 	// Set environment variables
@@ -233,6 +237,12 @@ func TestConfigLoader_BindEnvironmentVariables(t *testing.T) {
 	if cfg.UI.NavbarTitle != "Env Title" {
 		t.Errorf("UI.NavbarTitle = %v, want Env Title", cfg.UI.NavbarTitle)
 	}
+	if !cfg.Scheduler.LeaderElection {
+		t.Error("Scheduler.LeaderElection = false, want true")
+	}
+	if cfg.Scheduler.LeaseDuration != 15*time.Second {
+		t.Errorf("Scheduler.LeaseDuration = %v, want 15s", cfg.Scheduler.LeaseDuration)
+	}
 }
 
 func TestConfigLoader_DefaultValues(t *testing.T) {
@@ -260,6 +270,9 @@ func TestConfigLoader_DefaultValues(t *testing.T) {
 	if cfg.UI.LogEncodingCharset != "utf-8" {
 		t.Errorf("UI.LogEncodingCharset = %v, want utf-8", cfg.UI.LogEncodingCharset)
 	}
+	if cfg.Scheduler.LeaseDuration != 30*time.Second {
+		t.Errorf("Scheduler.LeaseDuration = %v, want 30s", cfg.Scheduler.LeaseDuration)
+	}
 }
 
 func TestConfigLoader_ConfigFileOverride(t *testing.T) {
@@ -21,6 +21,9 @@ type Paths struct {
 	LogsDir         string
 	AdminLogsDir    string
 	BaseConfigFile  string
+	TemplatesDir    string
+	KeystoreFile    string
+	KeystoreKeyFile string
 }
 
 type XDGConfig struct {
@@ -56,6 +59,9 @@ func (r *PathResolver) setXDGPaths() {
 	r.AdminLogsDir = filepath.Join(r.DataHome, build.Slug, "logs", "admin")
 	r.SuspendFlagsDir = filepath.Join(r.DataHome, build.Slug, "suspend")
 	r.DAGsDir = filepath.Join(r.ConfigHome, build.Slug, "dags")
+	r.TemplatesDir = filepath.Join(r.ConfigHome, build.Slug, "templates")
+	r.KeystoreFile = filepath.Join(r.DataHome, build.Slug, "keystore.json")
+	r.KeystoreKeyFile = filepath.Join(r.ConfigHome, build.Slug, "keystore.key")
 }
 
 func (r *PathResolver) setLegacyPaths() {
@@ -65,4 +71,7 @@ func (r *PathResolver) setLegacyPaths() {
 	r.AdminLogsDir = filepath.Join(r.ConfigDir, "logs", "admin")
 	r.SuspendFlagsDir = filepath.Join(r.ConfigDir, "suspend")
 	r.DAGsDir = filepath.Join(r.ConfigDir, "dags")
+	r.TemplatesDir = filepath.Join(r.ConfigDir, "templates")
+	r.KeystoreFile = filepath.Join(r.ConfigDir, "data", "keystore.json")
+	r.KeystoreKeyFile = filepath.Join(r.ConfigDir, "keystore.key")
 }
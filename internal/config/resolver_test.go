@@ -30,6 +30,9 @@ func TestResolver(t *testing.T) {
 				LogsDir:         filepath.Join(tmpDir, build.Slug, "logs"),
 				AdminLogsDir:    filepath.Join(tmpDir, build.Slug, "logs/admin"),
 				BaseConfigFile:  filepath.Join(tmpDir, build.Slug, "base.yaml"),
+				TemplatesDir:    filepath.Join(tmpDir, build.Slug, "templates"),
+				KeystoreFile:    filepath.Join(tmpDir, build.Slug, "data", "keystore.json"),
+				KeystoreKeyFile: filepath.Join(tmpDir, build.Slug, "keystore.key"),
 			},
 		})
 	})
@@ -53,6 +56,9 @@ func TestResolver(t *testing.T) {
 				LogsDir:         filepath.Join(tmpDir, hiddenDir, "logs"),
 				AdminLogsDir:    filepath.Join(tmpDir, hiddenDir, "logs", "admin"),
 				BaseConfigFile:  filepath.Join(tmpDir, hiddenDir, "base.yaml"),
+				TemplatesDir:    filepath.Join(tmpDir, hiddenDir, "templates"),
+				KeystoreFile:    filepath.Join(tmpDir, hiddenDir, "data", "keystore.json"),
+				KeystoreKeyFile: filepath.Join(tmpDir, hiddenDir, "keystore.key"),
 			},
 		})
 	})
@@ -70,6 +76,9 @@ func TestResolver(t *testing.T) {
 				LogsDir:         path.Join("/home/user/.local/share", build.Slug, "logs"),
 				AdminLogsDir:    path.Join("/home/user/.local/share", build.Slug, "logs", "admin"),
 				BaseConfigFile:  path.Join("/home/user/.config", build.Slug, "base.yaml"),
+				TemplatesDir:    path.Join("/home/user/.config", build.Slug, "templates"),
+				KeystoreFile:    path.Join("/home/user/.local/share", build.Slug, "keystore.json"),
+				KeystoreKeyFile: path.Join("/home/user/.config", build.Slug, "keystore.key"),
 			},
 			XDGConfig: XDGConfig{
 				DataHome:   "/home/user/.local/share",
@@ -0,0 +1,59 @@
+package digraph
+
+import "time"
+
+const (
+	// ApprovalOnTimeoutReject rejects the step on timeout.
+	ApprovalOnTimeoutReject = "reject"
+	// ApprovalOnTimeoutApprove approves the step on timeout.
+	ApprovalOnTimeoutApprove = "approve"
+)
+
+// Approval is a gate that pauses the run until someone approves or rejects
+// the step, e.g. before deploying to production.
+type Approval struct {
+	// Message is the message to show to the approvers.
+	Message string `json:"Message,omitempty"`
+	// Timeout is the time to wait for the decision. Zero means no timeout.
+	Timeout time.Duration `json:"Timeout,omitempty"`
+	// OnTimeout is the action on timeout: "reject" (default) or "approve".
+	OnTimeout string `json:"OnTimeout,omitempty"`
+}
+
+// buildApproval builds the approval gate of the step.
+//
+// Example:
+//
+//	steps:
+//	  - name: approve deploy
+//	    approval:
+//	      message: "Deploy to production?"
+//	      timeoutSec: 3600
+//	      onTimeout: reject
+func buildApproval(_ BuildContext, def stepDef, step *Step) error {
+	if def.Approval == nil {
+		return nil
+	}
+	if def.Command != nil || def.Script != "" || def.Run != "" || def.Executor != nil || def.Call != nil {
+		return wrapError("approval", def.Name, errApprovalWithCommand)
+	}
+	switch def.Approval.OnTimeout {
+	case "", ApprovalOnTimeoutReject, ApprovalOnTimeoutApprove:
+	default:
+		return wrapError("approval.onTimeout", def.Approval.OnTimeout, errInvalidApprovalOnTimeout)
+	}
+	if def.Approval.TimeoutSec < 0 {
+		return wrapError("approval.timeoutSec", def.Approval.TimeoutSec, errInvalidApprovalTimeout)
+	}
+
+	onTimeout := def.Approval.OnTimeout
+	if onTimeout == "" {
+		onTimeout = ApprovalOnTimeoutReject
+	}
+	step.Approval = &Approval{
+		Message:   def.Approval.Message,
+		Timeout:   time.Duration(def.Approval.TimeoutSec) * time.Second,
+		OnTimeout: onTimeout,
+	}
+	return nil
+}
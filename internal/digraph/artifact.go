@@ -0,0 +1,55 @@
+package digraph
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ArtifactRef is a reference to the artifacts produced by another step.
+type ArtifactRef struct {
+	// From is the name of the step that produced the artifacts.
+	From string `json:"From"`
+	// Path is the path of the file or the directory in the artifacts of the
+	// step. All the artifacts of the step are staged if it is empty.
+	Path string `json:"Path,omitempty"`
+}
+
+// buildArtifacts builds the artifacts that the step produces and consumes.
+// The steps that produce the consumed artifacts are added to the
+// dependencies of the step.
+func buildArtifacts(_ BuildContext, def stepDef, step *Step) error {
+	produces, err := parseStringOrArray(def.Produces)
+	if err != nil {
+		return wrapError("produces", def.Produces, errProducesMustBeStringOrArray)
+	}
+	for _, pattern := range produces {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return wrapError("produces", pattern, fmt.Errorf("%w: %s", errInvalidProducesPattern, err))
+		}
+	}
+	step.Produces = produces
+
+	for _, artifact := range def.Artifacts {
+		if artifact.From == "" {
+			return wrapError("artifacts", artifact, errArtifactFromRequired)
+		}
+		path := artifact.Path
+		if path != "" {
+			path = filepath.Clean(path)
+			if filepath.IsAbs(path) || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+				return wrapError("artifacts.path", artifact.Path, errInvalidArtifactPath)
+			}
+			if path == "." {
+				path = ""
+			}
+		}
+		step.Artifacts = append(step.Artifacts, ArtifactRef{From: artifact.From, Path: path})
+		if !slices.Contains(step.Depends, artifact.From) {
+			step.Depends = append(step.Depends, artifact.From)
+		}
+	}
+
+	return nil
+}
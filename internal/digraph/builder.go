@@ -2,6 +2,7 @@ package digraph
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -49,18 +50,25 @@ type buildOpts struct {
 	parametersList []string
 	// noEval specifies whether to evaluate dynamic fields.
 	noEval bool
+	// templatesDir is the directory to look up the step templates.
+	templatesDir string
 }
 
 var builderRegistry = []builderEntry{
 	{metadata: true, name: "env", fn: buildEnvs},
+	{metadata: true, name: "timezone", fn: buildTimezone},
 	{metadata: true, name: "schedule", fn: buildSchedule},
 	{metadata: true, name: "skipIfSuccessful", fn: skipIfSuccessful},
+	{metadata: true, name: "catchup", fn: buildCatchup},
 	{metadata: true, name: "params", fn: buildParams},
 	{name: "dotenv", fn: buildDotenv},
+	{name: "secrets", fn: buildSecrets},
+	{name: "waitFor", fn: buildWaitFor},
 	{name: "mailOn", fn: buildMailOn},
 	{name: "steps", fn: buildSteps},
 	{name: "logDir", fn: buildLogDir},
 	{name: "handlers", fn: buildHandlers},
+	{name: "triggers", fn: buildTriggers},
 	{name: "smtpConfig", fn: buildSMTPConfig},
 	{name: "errMailConfig", fn: buildErrMailConfig},
 	{name: "infoMailConfig", fn: buildInfoMailConfig},
@@ -84,7 +92,16 @@ var stepBuilderRegistry = []stepBuilderEntry{
 	{name: "retryPolicy", fn: buildRetryPolicy},
 	{name: "repeatPolicy", fn: buildRepeatPolicy},
 	{name: "signalOnStop", fn: buildSignalOnStop},
+	{name: "timeout", fn: buildStepTimeout},
 	{name: "precondition", fn: buildStepPrecondition},
+	{name: "if", fn: buildIf},
+	{name: "trigger", fn: buildTrigger},
+	{name: "foreach", fn: buildForeach},
+	{name: "template", fn: buildStepTemplate},
+	{name: "outputs", fn: buildStepOutputs},
+	{name: "artifacts", fn: buildArtifacts},
+	{name: "waitFor", fn: buildStepWaitFor},
+	{name: "approval", fn: buildApproval},
 }
 
 type stepBuilderEntry struct {
@@ -223,18 +240,31 @@ func buildSchedule(_ BuildContext, spec *definition, dag *DAG) error {
 
 	// Parse each schedule as a cron expression.
 	var err error
-	dag.Schedule, err = buildScheduler(starts)
+	dag.Schedule, err = buildScheduler(starts, dag.Timezone)
 	if err != nil {
 		return err
 	}
-	dag.StopSchedule, err = buildScheduler(stops)
+	dag.StopSchedule, err = buildScheduler(stops, dag.Timezone)
 	if err != nil {
 		return err
 	}
-	dag.RestartSchedule, err = buildScheduler(restarts)
+	dag.RestartSchedule, err = buildScheduler(restarts, dag.Timezone)
 	return err
 }
 
+// buildTimezone validates the time zone of the schedules.
+func buildTimezone(_ BuildContext, spec *definition, dag *DAG) error {
+	timezone := strings.TrimSpace(spec.Timezone)
+	if timezone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return wrapError("timezone", spec.Timezone, fmt.Errorf("%w: %s", errInvalidTimezone, err))
+	}
+	dag.Timezone = timezone
+	return nil
+}
+
 func buildDotenv(ctx BuildContext, spec *definition, dag *DAG) error {
 	switch v := spec.Dotenv.(type) {
 	case nil:
@@ -452,9 +482,49 @@ func skipIfSuccessful(_ BuildContext, spec *definition, dag *DAG) error {
 	return nil
 }
 
+// buildCatchup builds the catchup policy. The value is none, latest, all or
+// the maximum number of the latest missed ticks to run.
+func buildCatchup(_ BuildContext, spec *definition, dag *DAG) error {
+	var limit int
+	switch v := spec.Catchup.(type) {
+	case nil:
+		return nil
+
+	case string:
+		switch policy := CatchupPolicy(strings.TrimSpace(v)); policy {
+		case CatchupNone, CatchupLatest, CatchupAll:
+			dag.Catchup = Catchup{Policy: policy}
+			return nil
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return wrapError("catchup", v, errInvalidCatchup)
+		}
+		limit = n
+
+	case int:
+		limit = v
+
+	default:
+		return wrapError("catchup", v, errInvalidCatchup)
+
+	}
+
+	if limit <= 0 {
+		return wrapError("catchup", spec.Catchup, errInvalidCatchup)
+	}
+	dag.Catchup = Catchup{Policy: CatchupAll, Limit: limit}
+	return nil
+}
+
 // buildSteps builds the steps for the DAG.
 func buildSteps(ctx BuildContext, spec *definition, dag *DAG) error {
-	switch v := spec.Steps.(type) {
+	steps, err := resolveStepTemplates(ctx, spec.Steps)
+	if err != nil {
+		return err
+	}
+
+	switch v := steps.(type) {
 	case nil:
 		return nil
 
@@ -468,12 +538,11 @@ func buildSteps(ctx BuildContext, spec *definition, dag *DAG) error {
 			return wrapError("steps", v, err)
 		}
 		for _, stepDef := range stepDefs {
-			step, err := buildStep(ctx, stepDef, spec.Functions)
-			if err != nil {
+			if err := buildStepsFromDef(ctx, spec, stepDef, dag); err != nil {
 				return err
 			}
-			dag.Steps = append(dag.Steps, *step)
 		}
+		resolveMatrixDepends(dag.Steps)
 
 		return nil
 
@@ -488,12 +557,11 @@ func buildSteps(ctx BuildContext, spec *definition, dag *DAG) error {
 		}
 		for name, stepDef := range stepDefs {
 			stepDef.Name = name
-			step, err := buildStep(ctx, stepDef, spec.Functions)
-			if err != nil {
+			if err := buildStepsFromDef(ctx, spec, stepDef, dag); err != nil {
 				return err
 			}
-			dag.Steps = append(dag.Steps, *step)
 		}
+		resolveMatrixDepends(dag.Steps)
 
 		return nil
 
@@ -503,6 +571,28 @@ func buildSteps(ctx BuildContext, spec *definition, dag *DAG) error {
 	}
 }
 
+// buildStepsFromDef builds the steps from the step definition and adds them
+// to the DAG. A matrix step is expanded into a step for each combination.
+func buildStepsFromDef(ctx BuildContext, spec *definition, def stepDef, dag *DAG) error {
+	if def.Matrix != nil {
+		steps, err := buildMatrixSteps(ctx, def, spec.Functions)
+		if err != nil {
+			return err
+		}
+		for _, step := range steps {
+			dag.Steps = append(dag.Steps, *step)
+		}
+		return nil
+	}
+
+	step, err := buildStep(ctx, def, spec.Functions)
+	if err != nil {
+		return err
+	}
+	dag.Steps = append(dag.Steps, *step)
+	return nil
+}
+
 // buildSMTPConfig builds the SMTP configuration for the DAG.
 func buildSMTPConfig(_ BuildContext, spec *definition, dag *DAG) (err error) {
 	dag.SMTP = &SMTPConfig{
@@ -578,6 +668,7 @@ func buildContinueOn(_ BuildContext, def stepDef, step *Step) error {
 	}
 	step.ContinueOn.Skipped = def.ContinueOn.Skipped
 	step.ContinueOn.Failure = def.ContinueOn.Failure
+	step.ContinueOn.Timeout = def.ContinueOn.Timeout
 	step.ContinueOn.MarkSuccess = def.ContinueOn.MarkSuccess
 
 	exitCodes, err := parseIntOrArray(def.ContinueOn.ExitCode)
@@ -615,15 +706,107 @@ func buildRetryPolicy(_ BuildContext, def stepDef, step *Step) error {
 		default:
 			return wrapError("retryPolicy.IntervalSec", v, fmt.Errorf("invalid type: %T", v))
 		}
+
+		if def.RetryPolicy.Backoff != nil {
+			backoff, err := parseFloat(def.RetryPolicy.Backoff)
+			if err != nil || backoff < 1 {
+				return wrapError("retryPolicy.backoff", def.RetryPolicy.Backoff, errRetryPolicyBackoff)
+			}
+			step.RetryPolicy.Backoff = backoff
+		}
+
+		if def.RetryPolicy.MaxIntervalSec < 0 {
+			return wrapError("retryPolicy.maxIntervalSec", def.RetryPolicy.MaxIntervalSec, errRetryPolicyMaxIntervalSec)
+		}
+		step.RetryPolicy.MaxInterval = time.Second * time.Duration(def.RetryPolicy.MaxIntervalSec)
+
+		if def.RetryPolicy.Jitter != nil {
+			jitter, err := parseFloat(def.RetryPolicy.Jitter)
+			if err != nil || jitter < 0 || jitter > 1 {
+				return wrapError("retryPolicy.jitter", def.RetryPolicy.Jitter, errRetryPolicyJitter)
+			}
+			step.RetryPolicy.Jitter = jitter
+		}
+
+		exitCodes, err := parseIntOrArray(def.RetryPolicy.ExitCode)
+		if err != nil {
+			return wrapError("retryPolicy.exitCode", def.RetryPolicy.ExitCode, errRetryPolicyExitCodeMustBeIntOrArray)
+		}
+		step.RetryPolicy.ExitCode = exitCodes
+
+		output, err := parseStringOrArray(def.RetryPolicy.Output)
+		if err != nil {
+			return wrapError("retryPolicy.output", def.RetryPolicy.Output, errRetryPolicyOutputMustBeStringOrArray)
+		}
+		step.RetryPolicy.Output = output
+
+		step.RetryPolicy.OnTimeout = def.RetryPolicy.OnTimeout
 	}
 	return nil
 }
 
 func buildRepeatPolicy(_ BuildContext, def stepDef, step *Step) error {
-	if def.RepeatPolicy != nil {
-		step.RepeatPolicy.Repeat = def.RepeatPolicy.Repeat
-		step.RepeatPolicy.Interval = time.Second * time.Duration(def.RepeatPolicy.IntervalSec)
+	if def.RepeatPolicy == nil {
+		return nil
 	}
+
+	step.RepeatPolicy.Repeat = def.RepeatPolicy.Repeat
+	step.RepeatPolicy.Interval = time.Second * time.Duration(def.RepeatPolicy.IntervalSec)
+	step.RepeatPolicy.FailOnLimit = def.RepeatPolicy.FailOnLimit
+
+	if def.RepeatPolicy.Limit < 0 {
+		return wrapError("repeatPolicy.limit", def.RepeatPolicy.Limit, errRepeatPolicyLimit)
+	}
+	step.RepeatPolicy.Limit = def.RepeatPolicy.Limit
+
+	if def.RepeatPolicy.Backoff != nil {
+		backoff, err := parseFloat(def.RepeatPolicy.Backoff)
+		if err != nil || backoff < 1 {
+			return wrapError("repeatPolicy.backoff", def.RepeatPolicy.Backoff, errRepeatPolicyBackoff)
+		}
+		step.RepeatPolicy.Backoff = backoff
+	}
+
+	if def.RepeatPolicy.MaxIntervalSec < 0 {
+		return wrapError("repeatPolicy.maxIntervalSec", def.RepeatPolicy.MaxIntervalSec, errRepeatPolicyMaxIntervalSec)
+	}
+	step.RepeatPolicy.MaxInterval = time.Second * time.Duration(def.RepeatPolicy.MaxIntervalSec)
+
+	if until := def.RepeatPolicy.Until; until != nil {
+		step.RepeatPolicy.Until = &RepeatUntil{}
+
+		output, err := parseStringOrArray(until.Output)
+		if err != nil {
+			return wrapError("repeatPolicy.until.output", until.Output, errRepeatUntilOutputMustBeStringOrArray)
+		}
+		step.RepeatPolicy.Until.Output = output
+
+		exitCodes, err := parseIntOrArray(until.ExitCode)
+		if err != nil {
+			return wrapError("repeatPolicy.until.exitCode", until.ExitCode, errRepeatUntilExitCodeMustBeIntOrArray)
+		}
+		step.RepeatPolicy.Until.ExitCode = exitCodes
+
+		if until.Condition != "" || until.Command != "" {
+			cond := &Condition{
+				Condition: until.Condition,
+				Expected:  until.Expected,
+				Command:   until.Command,
+			}
+			if err := cond.Validate(); err != nil {
+				return wrapError("repeatPolicy.until", until.Condition, err)
+			}
+			step.RepeatPolicy.Until.Condition = cond
+		}
+
+		if len(output) == 0 && len(exitCodes) == 0 && step.RepeatPolicy.Until.Condition == nil {
+			return wrapError("repeatPolicy.until", until, errRepeatUntilIsEmpty)
+		}
+
+		// until implies repeat
+		step.RepeatPolicy.Repeat = true
+	}
+
 	return nil
 }
 
@@ -642,6 +825,31 @@ func buildStepPrecondition(ctx BuildContext, def stepDef, step *Step) error {
 	return nil
 }
 
+// buildIf validates the syntax of the if expression of the step.
+func buildIf(_ BuildContext, def stepDef, step *Step) error {
+	if def.If == "" {
+		return nil
+	}
+	if _, err := ParseExpression(def.If); err != nil {
+		return wrapError("if", def.If, err)
+	}
+	step.If = def.If
+	return nil
+}
+
+func buildTrigger(_ BuildContext, def stepDef, step *Step) error {
+	switch rule := TriggerRule(def.Trigger); rule {
+	case "":
+		// use the default rule
+	case TriggerAllSuccess, TriggerAllFailed, TriggerAllDone,
+		TriggerOneSuccess, TriggerOneFailed, TriggerNoneFailed:
+		step.Trigger = rule
+	default:
+		return wrapError("trigger", def.Trigger, errInvalidTrigger)
+	}
+	return nil
+}
+
 func buildSignalOnStop(_ BuildContext, def stepDef, step *Step) error {
 	if def.SignalOnStop != nil {
 		sigDef := *def.SignalOnStop
@@ -654,6 +862,46 @@ func buildSignalOnStop(_ BuildContext, def stepDef, step *Step) error {
 	return nil
 }
 
+// buildForeach parses the foreach field of the step.
+// The value can be a list of items or a string that evaluates to a JSON array
+// at run time, e.g. a parameter or the output of a previous step.
+//
+// ```yaml
+// foreach: [1, 2, 3]
+// foreach: "${ITEMS}"
+// ```
+func buildForeach(_ BuildContext, def stepDef, step *Step) error {
+	switch v := def.Foreach.(type) {
+	case nil:
+		return nil
+
+	case string:
+		step.Foreach = strings.TrimSpace(v)
+
+	case []any:
+		items, err := json.Marshal(v)
+		if err != nil {
+			return wrapError("foreach", v, errForeachMustBeStringOrArray)
+		}
+		step.Foreach = string(items)
+
+	default:
+		return wrapError("foreach", v, errForeachMustBeStringOrArray)
+
+	}
+
+	return nil
+}
+
+// buildStepTimeout sets the timeout of the step.
+func buildStepTimeout(_ BuildContext, def stepDef, step *Step) error {
+	if def.TimeoutSec < 0 {
+		return wrapError("timeoutSec", def.TimeoutSec, errTimeoutSecMustBePositive)
+	}
+	step.Timeout = time.Second * time.Duration(def.TimeoutSec)
+	return nil
+}
+
 // commandRun is not a actual command.
 // subworkflow does not use this command field so it is used
 // just for display purposes.
@@ -873,6 +1121,24 @@ func parseIntOrArray(v any) ([]int, error) {
 	}
 }
 
+// parseFloat parses an int, float or numeric string as a float64.
+func parseFloat(v any) (float64, error) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), nil
+
+	case float64:
+		return v, nil
+
+	case string:
+		return strconv.ParseFloat(v, 64)
+
+	default:
+		return 0, fmt.Errorf("number expected, got %T", v)
+
+	}
+}
+
 func parseStringOrArray(v any) ([]string, error) {
 	switch v := v.(type) {
 	case nil:
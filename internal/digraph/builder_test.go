@@ -41,6 +41,12 @@ func (th *testHelper) AssertParam(t *testing.T, params ...string) {
 
 type testOption func(*testHelper)
 
+func withTemplatesDir() testOption {
+	return func(th *testHelper) {
+		th.buildOpts.templatesDir = filepath.Join(testdataDir, "templates")
+	}
+}
+
 func withBuildOpts(opts buildOpts) testOption {
 	return func(th *testHelper) {
 		th.buildOpts = opts
@@ -107,6 +113,12 @@ func TestBuildDAGError(t *testing.T) {
 	t.Run("InvalidSchedule", func(t *testing.T) {
 		loadTestYAMLError(t, "invalid_schedule.yaml", errInvalidSchedule)
 	})
+	t.Run("InvalidCatchup", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_catchup.yaml", errInvalidCatchup)
+	})
+	t.Run("InvalidTimezone", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_timezone.yaml", errInvalidTimezone)
+	})
 }
 
 func TestBuildStepError(t *testing.T) {
@@ -114,6 +126,12 @@ func TestBuildStepError(t *testing.T) {
 	t.Run("NoCommand", func(t *testing.T) {
 		loadTestYAMLError(t, "invalid_no_command.yaml", errStepCommandIsRequired)
 	})
+	t.Run("InvalidMatrix", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_matrix.yaml", errMatrixValueMustBeArray)
+	})
+	t.Run("ApprovalWithCommand", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_approval.yaml", errApprovalWithCommand)
+	})
 }
 
 func TestBuildDAG(t *testing.T) {
@@ -181,6 +199,20 @@ func TestBuildDAG(t *testing.T) {
 		th := loadTestYAML(t, "skip_if_successful.yaml")
 		assert.True(t, th.SkipIfSuccessful)
 	})
+	t.Run("Catchup", func(t *testing.T) {
+		th := loadTestYAML(t, "catchup.yaml")
+		assert.Equal(t, Catchup{Policy: CatchupAll, Limit: 3}, th.Catchup)
+	})
+	t.Run("Timezone", func(t *testing.T) {
+		th := loadTestYAML(t, "timezone.yaml")
+		assert.Equal(t, "Asia/Tokyo", th.Timezone)
+		assert.Equal(t, "0 9 * * *", th.Schedule[0].Expression)
+
+		// The CRON_TZ prefix takes precedence over the timezone.
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), th.Schedule[0].Parsed.Next(now.Add(-time.Second)).UTC())
+		assert.Equal(t, time.Date(2025, 1, 1, 14, 0, 0, 0, time.UTC), th.Schedule[1].Parsed.Next(now).UTC())
+	})
 	t.Run("ParamsWithSubstitution", func(t *testing.T) {
 		th := loadTestYAML(t, "params_with_substitution.yaml")
 		th.AssertParam(t, "x", "x")
@@ -209,6 +241,92 @@ func TestBuildDAG(t *testing.T) {
 			"BAZ=Y",
 		)
 	})
+	t.Run("ParamsSchema", func(t *testing.T) {
+		th := loadTestYAML(t, "params_schema.yaml")
+		th.AssertParam(t,
+			"ENV=dev",
+			"COUNT=3",
+			"VERSION=v1.0",
+			"DRY_RUN=false",
+			"NOTE=hello",
+		)
+
+		require.Len(t, th.ParamsSchema, 4)
+		minimum, maximum := 1.0, 10.0
+		assert.Equal(t, ParamSchema{
+			Name:        "ENV",
+			Type:        ParamTypeString,
+			Description: "Target environment",
+			Default:     "dev",
+			Enum:        []string{"dev", "staging", "prod"},
+		}, th.ParamsSchema[0])
+		assert.Equal(t, ParamSchema{
+			Name:    "COUNT",
+			Type:    ParamTypeInteger,
+			Default: "3",
+			Minimum: &minimum,
+			Maximum: &maximum,
+		}, th.ParamsSchema[1])
+		assert.True(t, th.ParamsSchema[2].Required)
+		assert.Equal(t, ParamTypeBoolean, th.ParamsSchema[3].Type)
+	})
+	t.Run("ParamsSchemaOverride", func(t *testing.T) {
+		th := loadTestYAML(t, "params_schema.yaml", withBuildOpts(
+			buildOpts{
+				parameters: "ENV=prod COUNT=10",
+			},
+		))
+		th.AssertParam(t,
+			"ENV=prod",
+			"COUNT=10",
+			"VERSION=v1.0",
+			"DRY_RUN=false",
+			"NOTE=hello",
+		)
+	})
+	t.Run("ParamsSchemaValidationError", func(t *testing.T) {
+		_, err := loadYAML(context.Background(), readTestFile(t, "params_schema.yaml"), buildOpts{
+			parameters: `ENV=test COUNT=11 VERSION="" DRY_RUN=yes`,
+		})
+		require.Error(t, err)
+
+		errs, ok := AsParamErrors(err)
+		require.True(t, ok)
+		require.Len(t, errs, 4)
+		assert.Equal(t, "ENV", errs[0].Name)
+		assert.ErrorIs(t, errs[0], errParamNotInEnum)
+		assert.Equal(t, "COUNT", errs[1].Name)
+		assert.ErrorIs(t, errs[1], errParamOutOfRange)
+		assert.Equal(t, "VERSION", errs[2].Name)
+		assert.ErrorIs(t, errs[2], errParamRequired)
+		assert.Equal(t, "DRY_RUN", errs[3].Name)
+		assert.ErrorIs(t, errs[3], errParamType)
+	})
+	t.Run("ParamsSchemaUnknownParam", func(t *testing.T) {
+		_, err := loadYAML(context.Background(), readTestFile(t, "params_schema.yaml"), buildOpts{
+			parameters: "ENVV=prod NOTE=bye",
+		})
+		require.Error(t, err)
+
+		errs, ok := AsParamErrors(err)
+		require.True(t, ok)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "ENVV", errs[0].Name)
+		assert.ErrorIs(t, errs[0], errParamUnknown)
+	})
+	t.Run("ParamsSchemaWithoutEval", func(t *testing.T) {
+		// The values are not validated without evaluation, e.g. in the UI.
+		th := loadTestYAML(t, "params_schema.yaml", withBuildOpts(
+			buildOpts{
+				parameters: "COUNT=100",
+				noEval:     true,
+			},
+		))
+		require.Len(t, th.ParamsSchema, 4)
+	})
+	t.Run("InvalidParamsSchema", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_params_schema.yaml", errInvalidParamSchema)
+	})
 	t.Run("ParamsWithComplexValues", func(t *testing.T) {
 		th := loadTestYAML(t, "params_with_complex_values.yaml")
 		th.AssertParam(t,
@@ -274,6 +392,44 @@ func TestBuildDAG(t *testing.T) {
 		th := loadTestYAML(t, "max_active_runs.yaml")
 		assert.Equal(t, 3, th.MaxActiveRuns)
 	})
+	t.Run("Secrets", func(t *testing.T) {
+		th := loadTestYAML(t, "secrets.yaml")
+		assert.Equal(t, []Secret{
+			{Name: "API_TOKEN", Provider: "env", Key: "PROD_API_TOKEN"},
+			{Name: "DB_PASSWORD", Provider: "keystore", Key: "DB_PASSWORD"},
+			{Name: "TLS_KEY", Provider: "file", Key: "/etc/ssl/private/tls.key"},
+		}, th.Secrets)
+	})
+	t.Run("InvalidSecrets", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_secrets.yaml", errInvalidSecretProvider)
+	})
+	t.Run("WaitFor", func(t *testing.T) {
+		th := loadTestYAML(t, "wait_for.yaml")
+		assert.Equal(t, []WaitFor{
+			{DAG: "ingest", Since: "today", Timeout: time.Hour, Interval: 30 * time.Second, OnTimeout: "fail"},
+		}, th.WaitFor)
+		assert.Equal(t, []WaitFor{
+			{DAG: "export", Since: "6h", Interval: time.Minute, OnTimeout: "skip"},
+			{DAG: "upstream", RequestID: "${UPSTREAM_REQUEST_ID}", Interval: time.Minute, OnTimeout: "fail"},
+		}, th.Steps[0].WaitFor)
+	})
+	t.Run("InvalidWaitFor", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_wait_for.yaml", errInvalidWaitForOnTimeout)
+	})
+	t.Run("Triggers", func(t *testing.T) {
+		th := loadTestYAML(t, "triggers.yaml")
+		assert.Equal(t, []DAGTrigger{
+			{On: HandlerOnSuccess, DAG: "reporting"},
+			{On: HandlerOnSuccess, DAG: "cleanup", Params: "DATE=${DATE}"},
+			{On: HandlerOnFailure, DAG: "alert"},
+		}, th.Triggers)
+		assert.Equal(t, []DAGTrigger{
+			{On: HandlerOnFailure, DAG: "alert"},
+		}, th.TriggersOn(HandlerOnFailure, HandlerOnExit))
+	})
+	t.Run("InvalidTriggers", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_triggers.yaml", errDAGTriggerNameRequired)
+	})
 }
 
 func TestBuildStep(t *testing.T) {
@@ -357,12 +513,186 @@ func TestBuildStep(t *testing.T) {
 		assert.Len(t, th.Steps, 1)
 		assert.Equal(t, "SIGINT", th.Steps[0].SignalOnStop)
 	})
+	t.Run("RetryPolicyBackoff", func(t *testing.T) {
+		th := loadTestYAML(t, "retry_policy_backoff.yaml")
+		assert.Len(t, th.Steps, 1)
+
+		policy := th.Steps[0].RetryPolicy
+		assert.Equal(t, 5, policy.Limit)
+		assert.Equal(t, 2*time.Second, policy.Interval)
+		assert.Equal(t, 1.5, policy.Backoff)
+		assert.Equal(t, 30*time.Second, policy.MaxInterval)
+		assert.Equal(t, 0.1, policy.Jitter)
+		assert.Equal(t, []int{7, 28}, policy.ExitCode)
+		assert.Equal(t, []string{"Connection refused", "re:^HTTP/1.1 5[0-9]{2}"}, policy.Output)
+	})
+	t.Run("InvalidRetryPolicyBackoff", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_retry_policy_backoff.yaml", errRetryPolicyBackoff)
+	})
+	t.Run("RepeatUntil", func(t *testing.T) {
+		th := loadTestYAML(t, "repeat_until.yaml")
+		assert.Len(t, th.Steps, 1)
+
+		policy := th.Steps[0].RepeatPolicy
+		assert.True(t, policy.Repeat)
+		assert.Equal(t, 5*time.Second, policy.Interval)
+		assert.Equal(t, 10, policy.Limit)
+		assert.Equal(t, 2.0, policy.Backoff)
+		assert.Equal(t, 60*time.Second, policy.MaxInterval)
+		assert.True(t, policy.FailOnLimit)
+		require.NotNil(t, policy.Until)
+		assert.Equal(t, []string{"re:^DONE"}, policy.Until.Output)
+		assert.Equal(t, []int{0, 3}, policy.Until.ExitCode)
+		assert.Equal(t, &Condition{Condition: "${JOB_STATUS}", Expected: "DONE"}, policy.Until.Condition)
+	})
+	t.Run("InvalidRepeatUntil", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_repeat_until.yaml", errRepeatUntilIsEmpty)
+	})
+	t.Run("Outputs", func(t *testing.T) {
+		th := loadTestYAML(t, "step_outputs.yaml")
+		require.Len(t, th.Steps, 1)
+		assert.Equal(t, []StepOutput{
+			{Name: "commit", Regex: "commit: ([a-f0-9]+)"},
+			{Name: "image", Path: ".images[0].name"},
+			{Name: "report", File: "${DAG_STEP_LOG_PATH}.report"},
+			{Name: "version", Path: "$.version"},
+		}, th.Steps[0].Outputs)
+	})
+	t.Run("InvalidOutputs", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_step_outputs.yaml", errStepOutputSource)
+	})
+	t.Run("Artifacts", func(t *testing.T) {
+		th := loadTestYAML(t, "artifacts.yaml")
+		require.Len(t, th.Steps, 3)
+		assert.Equal(t, []string{"dist", "reports/*.xml"}, th.Steps[0].Produces)
+		assert.Equal(t, []string{"package.tar.gz"}, th.Steps[1].Produces)
+
+		deploy := th.Steps[2]
+		assert.Equal(t, []ArtifactRef{
+			{From: "build", Path: "dist"},
+			{From: "package"},
+		}, deploy.Artifacts)
+		assert.Equal(t, []string{"package", "build"}, deploy.Depends)
+	})
+	t.Run("InvalidArtifacts", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_artifacts.yaml", errInvalidArtifactPath)
+	})
+	t.Run("Uses", func(t *testing.T) {
+		th := loadTestYAML(t, "step_uses.yaml", withTemplatesDir())
+		require.Len(t, th.Steps, 3)
+
+		notify := th.Steps[0]
+		assert.Equal(t, "notify", notify.Name)
+		assert.Equal(t, "Send a notification to the channel", notify.Description)
+		assert.Equal(t, "http", notify.ExecutorConfig.Type)
+		assert.Equal(t, true, notify.ExecutorConfig.Config["silent"])
+		assert.Equal(t, "POST", notify.Command)
+		assert.Equal(t, []string{"https://hooks.example.com/ops"}, notify.Args)
+		assert.Equal(t, `{"text": "done"}`, notify.Script)
+		assert.Equal(t, 30*time.Second, notify.Timeout)
+		assert.Equal(t, &StepTemplate{
+			Uses:   "notify",
+			Inputs: map[string]string{"channel": "ops", "message": "done", "timeout": "30"},
+		}, notify.Template)
+
+		// A template built from another template.
+		retry := th.Steps[1]
+		assert.Equal(t, []string{"https://hooks.example.com/dev"}, retry.Args)
+		assert.Equal(t, `{"text": "retrying"}`, retry.Script)
+		assert.Equal(t, 3, retry.RetryPolicy.Limit)
+		assert.Equal(t, []string{"notify"}, retry.Depends)
+		assert.Equal(t, &StepTemplate{
+			Uses:   "notify_with_retry",
+			Inputs: map[string]string{"channel": "dev"},
+		}, retry.Template)
+
+		// The fields of the step take precedence over the template.
+		override := th.Steps[2]
+		assert.Equal(t, []string{"https://example.com/override"}, override.Args)
+		assert.Equal(t, `{"text": "hello"}`, override.Script)
+	})
+	t.Run("UsesMissingInput", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_step_uses_missing_input.yaml", errStepTemplateInputRequired, withTemplatesDir())
+	})
+	t.Run("UsesUnknownInput", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_step_uses_unknown_input.yaml", errStepTemplateUnknownInput, withTemplatesDir())
+	})
+	t.Run("UsesCycle", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_step_uses_cycle.yaml", errStepTemplateCycle, withTemplatesDir())
+	})
+	t.Run("UsesNotFound", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_step_uses_not_found.yaml", errStepTemplateNotFound, withTemplatesDir())
+	})
 	t.Run("Preconditions", func(t *testing.T) {
 		th := loadTestYAML(t, "step_preconditions.yaml")
 		assert.Len(t, th.Steps, 1)
 		assert.Len(t, th.Steps[0].Preconditions, 1)
 		assert.Equal(t, Condition{Condition: "test -f file.txt", Expected: "true"}, th.Steps[0].Preconditions[0])
 	})
+	t.Run("If", func(t *testing.T) {
+		th := loadTestYAML(t, "step_if.yaml")
+		assert.Len(t, th.Steps, 3)
+		assert.Equal(t, `steps.build.status == "failed" || ${COUNT} > 10`, th.Steps[1].If)
+		assert.Equal(t, TriggerOneSuccess, th.Steps[2].Trigger)
+	})
+	t.Run("InvalidIf", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_step_if.yaml", ErrExpressionSyntax)
+	})
+	t.Run("Trigger", func(t *testing.T) {
+		th := loadTestYAML(t, "step_trigger.yaml")
+		assert.Len(t, th.Steps, 4)
+		assert.Empty(t, th.Steps[1].Trigger)
+		assert.Equal(t, TriggerOneFailed, th.Steps[2].Trigger)
+		assert.Equal(t, TriggerAllDone, th.Steps[3].Trigger)
+	})
+	t.Run("InvalidTrigger", func(t *testing.T) {
+		loadTestYAMLError(t, "invalid_step_trigger.yaml", errInvalidTrigger)
+	})
+	t.Run("Timeout", func(t *testing.T) {
+		th := loadTestYAML(t, "step_timeout.yaml")
+		assert.Len(t, th.Steps, 1)
+		assert.Equal(t, 30*time.Second, th.Steps[0].Timeout)
+		assert.True(t, th.Steps[0].ContinueOn.Timeout)
+		assert.True(t, th.Steps[0].RetryPolicy.OnTimeout)
+	})
+	t.Run("Foreach", func(t *testing.T) {
+		th := loadTestYAML(t, "foreach.yaml")
+		assert.Len(t, th.Steps, 2)
+		assert.Equal(t, "[1,2,3]", th.Steps[0].Foreach)
+		assert.Equal(t, "${ITEMS}", th.Steps[1].Foreach)
+	})
+	t.Run("Matrix", func(t *testing.T) {
+		th := loadTestYAML(t, "matrix.yaml")
+		require.Len(t, th.Steps, 5)
+
+		names := []string{
+			"test[os=linux,version=1]",
+			"test[os=linux,version=2]",
+			"test[os=darwin,version=2]",
+			"test[os=windows,version=2]",
+		}
+		for i, name := range names {
+			assert.Equal(t, name, th.Steps[i].Name)
+			require.NotNil(t, th.Steps[i].Matrix)
+			assert.Equal(t, "test", th.Steps[i].Matrix.Step)
+			assert.True(t, th.Steps[i].Matrix.FailFast)
+		}
+		assert.Equal(t, map[string]string{"os": "darwin", "version": "2"}, th.Steps[2].Matrix.Values)
+
+		// the downstream step depends on all of the instances
+		assert.Equal(t, "report", th.Steps[4].Name)
+		assert.Equal(t, names, th.Steps[4].Depends)
+	})
+	t.Run("Approval", func(t *testing.T) {
+		th := loadTestYAML(t, "approval.yaml")
+		require.Len(t, th.Steps, 2)
+		assert.Equal(t, &Approval{
+			Message:   "Deploy to production?",
+			Timeout:   time.Hour,
+			OnTimeout: ApprovalOnTimeoutReject,
+		}, th.Steps[0].Approval)
+		assert.Nil(t, th.Steps[1].Approval)
+	})
 }
 
 func TestOverrideBaseConfig(t *testing.T) {
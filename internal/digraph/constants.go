@@ -13,4 +13,6 @@ const (
 	EnvKeyDAGName          = "DAG_NAME"
 	EnvKeyDAGStepName      = "DAG_STEP_NAME"
 	EnvKeyDAGStepLogPath   = "DAG_STEP_LOG_PATH"
+	EnvKeyForeachItem      = "ITEM"
+	EnvKeyScheduledTime    = "DAG_SCHEDULED_TIME"
 )
@@ -13,9 +13,20 @@ type Context struct {
 	dag    *DAG
 	client DBClient
 	envs   map[string]string
+	// secrets is the values of the secrets by name. They are passed to the
+	// executors as environment variables but are not used to evaluate the
+	// fields so that they are not saved in the status.
+	secrets map[string]string
 }
 
+// GetDAGByName returns the DAG by name. The local DAGs defined in the same
+// file take precedence over the DAGs in the DAGs directory.
 func (c Context) GetDAGByName(name string) (*DAG, error) {
+	if c.dag != nil {
+		if dag, ok := c.dag.LocalDAGs[name]; ok {
+			return dag, nil
+		}
+	}
 	return c.client.GetDAG(c.ctx, name)
 }
 
@@ -23,15 +34,38 @@ func (c Context) GetResult(name, requestID string) (*Status, error) {
 	return c.client.GetStatus(c.ctx, name, requestID)
 }
 
+// GetRecentResults returns the results of the recent runs of the DAG.
+func (c Context) GetRecentResults(name string, n int) ([]*Status, error) {
+	return c.client.GetRecentStatuses(c.ctx, name, n)
+}
+
 func (c Context) AllEnvs() []string {
 	envs := os.Environ()
 	envs = append(envs, c.dag.Env...)
 	for k, v := range c.envs {
 		envs = append(envs, k+"="+v)
 	}
+	for k, v := range c.secrets {
+		envs = append(envs, k+"="+v)
+	}
 	return envs
 }
 
+// WithSecrets returns a copy of the context with the values of the secrets.
+func (c Context) WithSecrets(secrets map[string]string) Context {
+	c.secrets = secrets
+	return c
+}
+
+// SecretValues returns the values of the secrets to mask in the outputs.
+func (c Context) SecretValues() []string {
+	values := make([]string, 0, len(c.secrets))
+	for _, v := range c.secrets {
+		values = append(values, v)
+	}
+	return values
+}
+
 func (c Context) ApplyEnvs() {
 	for k, v := range c.envs {
 		if err := os.Setenv(k, v); err != nil {
@@ -12,6 +12,7 @@ import (
 type StepContext struct {
 	Context
 	outputVariables *SyncMap
+	stepOutputs     map[string]string
 	step            Step
 	envs            map[string]string
 }
@@ -21,6 +22,7 @@ func NewStepContext(ctx context.Context, step Step) StepContext {
 		Context: GetContext(ctx),
 
 		outputVariables: &SyncMap{},
+		stepOutputs:     map[string]string{},
 		step:            step,
 		envs: map[string]string{
 			EnvKeyDAGStepName: step.Name,
@@ -51,6 +53,19 @@ func (c StepContext) LoadOutputVariables(vars *SyncMap) {
 	})
 }
 
+// LoadStepOutputs makes the extracted outputs of the step available as
+// ${steps.<name>.outputs.<output>}.
+func (c StepContext) LoadStepOutputs(step string, outputs map[string]string) {
+	for k, v := range outputs {
+		c.stepOutputs[StepOutputKey(step, k)] = v
+	}
+}
+
+// StepOutputKey returns the key to reference the output of the step.
+func StepOutputKey(step, output string) string {
+	return "steps." + step + ".outputs." + output
+}
+
 func (c StepContext) MailerConfig() (mailer.Config, error) {
 	return EvalStringFields(c, mailer.Config{
 		Host:     c.dag.SMTP.Host,
@@ -63,6 +78,7 @@ func (c StepContext) MailerConfig() (mailer.Config, error) {
 func (c StepContext) EvalString(s string, opts ...cmdutil.EvalOption) (string, error) {
 	opts = append(opts, cmdutil.WithVariables(c.envs))
 	opts = append(opts, cmdutil.WithVariables(c.outputVariables.Variables()))
+	opts = append(opts, cmdutil.WithVariables(c.stepOutputs))
 	return cmdutil.EvalString(c.ctx, s, opts...)
 }
 
@@ -107,5 +123,6 @@ type stepCtxKey struct{}
 
 func EvalStringFields[T any](stepContext StepContext, obj T) (T, error) {
 	return cmdutil.EvalStringFields(stepContext.ctx, obj,
-		cmdutil.WithVariables(stepContext.outputVariables.Variables()))
+		cmdutil.WithVariables(stepContext.outputVariables.Variables()),
+		cmdutil.WithVariables(stepContext.stepOutputs))
 }
@@ -36,9 +36,15 @@ type DAG struct {
 	Schedule        []Schedule `json:"Schedule"`
 	StopSchedule    []Schedule `json:"StopSchedule"`
 	RestartSchedule []Schedule `json:"RestartSchedule"`
+	// Timezone is the time zone of the schedules. The time zone of the
+	// server is used if it's empty.
+	Timezone string `json:"Timezone,omitempty"`
 	// SkipIfSuccessful indicates whether to skip the DAG if it was successful previously.
 	// E.g., when the DAG has already been executed manually before the scheduled time.
 	SkipIfSuccessful bool `json:"SkipIfSuccessful"`
+	// Catchup is the policy to run the schedule ticks missed while the
+	// scheduler was down.
+	Catchup Catchup `json:"Catchup,omitempty"`
 	// Env contains a list of environment variables to be set before running the DAG.
 	Env []string `json:"Env"`
 	// LogDir is the directory where the logs are stored.
@@ -47,6 +53,15 @@ type DAG struct {
 	DefaultParams string `json:"DefaultParams"`
 	// Params contains the list of parameters to be passed to the DAG.
 	Params []string `json:"Params"`
+	// ParamsSchema contains the schema of the named parameters.
+	ParamsSchema []ParamSchema `json:"ParamsSchema,omitempty"`
+	// Secrets contains the references to the secrets passed to the steps.
+	Secrets []Secret `json:"Secrets,omitempty"`
+	// WaitFor contains the runs of other DAGs to wait for before running
+	// the steps.
+	WaitFor []WaitFor `json:"WaitFor,omitempty"`
+	// Triggers contains the DAGs to start when the run finishes.
+	Triggers []DAGTrigger `json:"Triggers,omitempty"`
 	// Steps contains the list of steps in the DAG.
 	Steps []Step `json:"Steps"`
 	// HandlerOn contains the steps to be executed on different events.
@@ -73,6 +88,9 @@ type DAG struct {
 	MaxCleanUpTime time.Duration `json:"MaxCleanUpTime"`
 	// HistRetentionDays is the number of days to keep the history.
 	HistRetentionDays int `json:"HistRetentionDays"`
+	// LocalDAGs contains the DAGs defined in the same file. They are private
+	// to this DAG and can be run by sub workflow steps by name.
+	LocalDAGs map[string]*DAG `json:"LocalDAGs,omitempty"`
 }
 
 // Schedule contains the cron expression and the parsed cron schedule.
@@ -152,6 +170,17 @@ func (d *DAG) HasTag(tag string) bool {
 // SockAddr returns the unix socket address for the DAG.
 // The address is used to communicate with the agent process.
 func (d *DAG) SockAddr() string {
+	return d.sockAddr("")
+}
+
+// SockAddrForRequest returns the unix socket address for the run of the
+// request ID. It's used by the runs that run alongside the other runs of the
+// DAG, e.g. backfills.
+func (d *DAG) SockAddrForRequest(requestID string) string {
+	return d.sockAddr(requestID)
+}
+
+func (d *DAG) sockAddr(requestID string) string {
 	// Normalize the location path
 	normalizedPath := strings.ReplaceAll(d.Location, " ", "_")
 	name := strings.TrimSuffix(filepath.Base(normalizedPath), filepath.Ext(filepath.Base(normalizedPath)))
@@ -159,6 +188,7 @@ func (d *DAG) SockAddr() string {
 	// Generate hash for uniqueness
 	hash := md5.New() // nolint // gosec
 	hash.Write([]byte(normalizedPath))
+	hash.Write([]byte(requestID))
 	hashSum := hash.Sum(nil)
 
 	// Truncate name if necessary
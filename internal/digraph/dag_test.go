@@ -4,6 +4,7 @@ import (
 	"context"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/fileutil"
 	"github.com/stretchr/testify/require"
@@ -41,4 +42,204 @@ func TestDAG_SockAddr(t *testing.T) {
 			dag.SockAddr(),
 		)
 	})
+	t.Run("ForRequest", func(t *testing.T) {
+		dag := &DAG{Location: "testdata/testDag.yml"}
+		addr := dag.SockAddrForRequest("request-1")
+		require.Regexp(t, `^/tmp/@dagu-testDag-[0-9a-f]+\.sock$`, addr)
+		require.NotEqual(t, dag.SockAddr(), addr)
+		require.NotEqual(t, dag.SockAddrForRequest("request-2"), addr)
+	})
+}
+
+func TestDAG_ValidateParams(t *testing.T) {
+	minimum := 1.0
+	dag := &DAG{
+		ParamsSchema: []ParamSchema{
+			{Name: "ENV", Type: ParamTypeString, Enum: []string{"dev", "prod"}, Default: "dev"},
+			{Name: "COUNT", Type: ParamTypeInteger, Minimum: &minimum, Required: true},
+		},
+		DefaultParams: "ENV=dev COUNT= NOTE=hello",
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, dag.ValidateParams("ENV=prod COUNT=2"))
+	})
+	t.Run("DefaultValue", func(t *testing.T) {
+		require.NoError(t, dag.ValidateParams("COUNT=1"))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		err := dag.ValidateParams("ENV=test")
+		errs, ok := AsParamErrors(err)
+		require.True(t, ok)
+		require.Len(t, errs, 2)
+		require.Equal(t, "ENV", errs[0].Name)
+		require.ErrorIs(t, errs[0], errParamNotInEnum)
+		require.Equal(t, "COUNT", errs[1].Name)
+		require.ErrorIs(t, errs[1], errParamRequired)
+	})
+	t.Run("UnknownName", func(t *testing.T) {
+		err := dag.ValidateParams("ENVV=prod COUNT=1")
+		errs, ok := AsParamErrors(err)
+		require.True(t, ok)
+		require.Len(t, errs, 1)
+		require.Equal(t, "ENVV", errs[0].Name)
+		require.ErrorIs(t, errs[0], errParamUnknown)
+	})
+	t.Run("DeclaredWithoutSchema", func(t *testing.T) {
+		require.NoError(t, dag.ValidateParams("NOTE=bye COUNT=1"))
+	})
+	t.Run("Positional", func(t *testing.T) {
+		require.NoError(t, dag.ValidateParams("prod COUNT=1"))
+	})
+}
+
+func TestDAG_ScheduleTicks(t *testing.T) {
+	schedules, err := buildScheduler([]string{"0 */6 * * *", "0 12 * * *"}, "")
+	require.NoError(t, err)
+	dag := &DAG{Schedule: schedules}
+
+	after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, []time.Time{
+		time.Date(2025, 1, 1, 6, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 18, 0, 0, 0, time.UTC),
+	}, dag.ScheduleTicks(after, end))
+
+	require.Empty(t, dag.ScheduleTicks(end, end))
+}
+
+func TestSchedule_Seconds(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 20, 0, time.UTC)
+
+	t.Run("SecondsField", func(t *testing.T) {
+		schedules, err := buildScheduler([]string{"*/15 * * * * *"}, "")
+		require.NoError(t, err)
+		next := schedules[0].Parsed.Next(now)
+		require.Equal(t, time.Date(2025, 1, 1, 10, 0, 30, 0, time.UTC), next)
+		require.Equal(t, time.Date(2025, 1, 1, 10, 0, 45, 0, time.UTC), schedules[0].Parsed.Next(next))
+	})
+	t.Run("Every", func(t *testing.T) {
+		schedules, err := buildScheduler([]string{"@every 15s"}, "")
+		require.NoError(t, err)
+		// The ticks are aligned to the interval regardless of the given time.
+		next := schedules[0].Parsed.Next(now)
+		require.Equal(t, time.Date(2025, 1, 1, 10, 0, 30, 0, time.UTC), next)
+		require.Equal(t, next, schedules[0].Parsed.Next(now.Add(9*time.Second)))
+		require.Equal(t, time.Date(2025, 1, 1, 10, 0, 45, 0, time.UTC), schedules[0].Parsed.Next(next))
+	})
+	t.Run("EveryInTimezone", func(t *testing.T) {
+		schedules, err := buildScheduler([]string{"@every 1m"}, "Asia/Tokyo")
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2025, 1, 1, 10, 1, 0, 0, time.UTC).Unix(), schedules[0].Parsed.Next(now).Unix())
+	})
+	t.Run("FiveFields", func(t *testing.T) {
+		schedules, err := buildScheduler([]string{"0 * * * *"}, "")
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC), schedules[0].Parsed.Next(now))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := buildScheduler([]string{"@every 15x"}, "")
+		require.ErrorIs(t, err, errInvalidSchedule)
+		_, err = buildScheduler([]string{"60 * * * * *"}, "")
+		require.ErrorIs(t, err, errInvalidSchedule)
+	})
+}
+
+func TestDAG_NextRun(t *testing.T) {
+	schedules, err := buildScheduler([]string{"0 9 * * *", "CRON_TZ=UTC 0 12 * * *"}, "Asia/Tokyo")
+	require.NoError(t, err)
+	dag := &DAG{Schedule: schedules, Timezone: "Asia/Tokyo"}
+
+	now := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+	next := dag.NextRun(now)
+	require.Equal(t, "2025-01-01T12:00:00Z", next.Format(time.RFC3339))
+
+	next = dag.NextRun(next)
+	require.Equal(t, "2025-01-02T09:00:00+09:00", next.Format(time.RFC3339))
+
+	require.Equal(t, "Asia/Tokyo", dag.ScheduleLocation(time.UTC).String())
+	require.True(t, (&DAG{}).NextRun(now).IsZero())
+}
+
+func TestSchedule_DST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// ticks returns the next n ticks of the expression in New York.
+	ticks := func(t *testing.T, expr string, from time.Time, n int) []string {
+		t.Helper()
+		schedules, err := buildScheduler([]string{expr}, "America/New_York")
+		require.NoError(t, err)
+		var ret []string
+		for next := from; len(ret) < n; {
+			next = schedules[0].Parsed.Next(next)
+			ret = append(ret, next.Format(time.RFC3339))
+		}
+		return ret
+	}
+
+	// The clock jumps from 02:00 to 03:00 on 2025-03-09.
+	springForward := time.Date(2025, 3, 8, 12, 0, 0, 0, ny)
+	// The clock goes back from 02:00 to 01:00 on 2025-11-02.
+	fallBack := time.Date(2025, 11, 1, 12, 0, 0, 0, ny)
+
+	t.Run("GapRunsAtEndOfGap", func(t *testing.T) {
+		require.Equal(t, []string{
+			"2025-03-09T03:00:00-04:00",
+			"2025-03-10T02:30:00-04:00",
+		}, ticks(t, "30 2 * * *", springForward, 2))
+	})
+	t.Run("GapHourlyRunsOnce", func(t *testing.T) {
+		require.Equal(t, []string{
+			"2025-03-09T01:00:00-05:00",
+			"2025-03-09T03:00:00-04:00",
+			"2025-03-09T04:00:00-04:00",
+		}, ticks(t, "0 * * * *", time.Date(2025, 3, 9, 0, 30, 0, 0, ny), 3))
+	})
+	t.Run("OverlapRunsOnce", func(t *testing.T) {
+		require.Equal(t, []string{
+			"2025-11-02T01:30:00-04:00",
+			"2025-11-03T01:30:00-05:00",
+		}, ticks(t, "30 1 * * *", fallBack, 2))
+	})
+	t.Run("OverlapHourlyRunsOnce", func(t *testing.T) {
+		require.Equal(t, []string{
+			"2025-11-02T01:00:00-04:00",
+			"2025-11-02T02:00:00-05:00",
+		}, ticks(t, "0 * * * *", time.Date(2025, 11, 2, 0, 30, 0, 0, ny), 2))
+	})
+	t.Run("OutsideTransition", func(t *testing.T) {
+		require.Equal(t, []string{
+			"2025-03-09T09:00:00-04:00",
+			"2025-03-10T09:00:00-04:00",
+		}, ticks(t, "0 9 * * *", springForward, 2))
+	})
+	t.Run("ServerTimezone", func(t *testing.T) {
+		// The schedule without the time zone follows the given time.
+		schedules, err := buildScheduler([]string{"30 2 * * *"}, "")
+		require.NoError(t, err)
+		require.Equal(t, "2025-03-09T03:00:00-04:00", schedules[0].Parsed.Next(springForward).Format(time.RFC3339))
+	})
+}
+
+func TestCatchup_Select(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	missed := []time.Time{base, base.Add(time.Hour), base.Add(2 * time.Hour)}
+
+	t.Run("None", func(t *testing.T) {
+		require.Empty(t, Catchup{}.Select(missed))
+		require.Empty(t, Catchup{Policy: CatchupNone}.Select(missed))
+	})
+	t.Run("Latest", func(t *testing.T) {
+		require.Equal(t, missed[2:], Catchup{Policy: CatchupLatest}.Select(missed))
+		require.Empty(t, Catchup{Policy: CatchupLatest}.Select(nil))
+	})
+	t.Run("All", func(t *testing.T) {
+		require.Equal(t, missed, Catchup{Policy: CatchupAll}.Select(missed))
+	})
+	t.Run("Limit", func(t *testing.T) {
+		require.Equal(t, missed[1:], Catchup{Policy: CatchupAll, Limit: 2}.Select(missed))
+		require.Equal(t, missed, Catchup{Policy: CatchupAll, Limit: 5}.Select(missed))
+	})
 }
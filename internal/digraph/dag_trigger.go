@@ -0,0 +1,111 @@
+package digraph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DAGTrigger is a DAG to start when the run of the DAG finishes.
+type DAGTrigger struct {
+	// On is the event to start the DAG on.
+	On HandlerType `json:"On"`
+	// DAG is the name of the DAG to start.
+	DAG string `json:"DAG"`
+	// Params is the parameters to pass to the DAG. It can reference the
+	// output variables of the steps.
+	Params string `json:"Params,omitempty"`
+}
+
+// TriggersOn returns the DAGs to start on the events.
+func (d *DAG) TriggersOn(events ...HandlerType) []DAGTrigger {
+	var ret []DAGTrigger
+	for _, t := range d.Triggers {
+		for _, e := range events {
+			if t.On == e {
+				ret = append(ret, t)
+			}
+		}
+	}
+	return ret
+}
+
+// buildTriggers builds the DAGs to start when the run finishes.
+//
+// Example:
+//
+//	triggers:
+//	  onSuccess:
+//	    - reporting
+//	    - dag: cleanup
+//	      params: "DATE=${DATE}"
+func buildTriggers(_ BuildContext, spec *definition, dag *DAG) error {
+	if spec.Triggers == nil {
+		return nil
+	}
+	for _, event := range []struct {
+		on    HandlerType
+		value any
+	}{
+		{HandlerOnSuccess, spec.Triggers.OnSuccess},
+		{HandlerOnFailure, spec.Triggers.OnFailure},
+		{HandlerOnCancel, spec.Triggers.OnCancel},
+		{HandlerOnExit, spec.Triggers.OnExit},
+	} {
+		triggers, err := parseDAGTriggers(event.on, event.value)
+		if err != nil {
+			return err
+		}
+		dag.Triggers = append(dag.Triggers, triggers...)
+	}
+	return nil
+}
+
+// parseDAGTriggers parses a DAG name, or a list of DAG names or maps with
+// the dag and params keys.
+func parseDAGTriggers(on HandlerType, value any) ([]DAGTrigger, error) {
+	field := "triggers." + on.String()
+
+	var items []any
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		items = []any{v}
+	case []any:
+		items = v
+	default:
+		return nil, wrapError(field, value, errInvalidDAGTrigger)
+	}
+
+	var ret []DAGTrigger
+	for _, item := range items {
+		trigger := DAGTrigger{On: on}
+		switch v := item.(type) {
+		case string:
+			trigger.DAG = v
+		case map[any]any:
+			for k, vv := range v {
+				key, _ := k.(string)
+				s, ok := vv.(string)
+				if !ok {
+					return nil, wrapError(field, vv, errInvalidDAGTrigger)
+				}
+				switch strings.ToLower(key) {
+				case "dag":
+					trigger.DAG = s
+				case "params":
+					trigger.Params = s
+				default:
+					return nil, wrapError(field, k, fmt.Errorf("%w: unknown key %v", errInvalidDAGTrigger, k))
+				}
+			}
+		default:
+			return nil, wrapError(field, item, errInvalidDAGTrigger)
+		}
+		if trigger.DAG == "" {
+			return nil, wrapError(field, item, errDAGTriggerNameRequired)
+		}
+		ret = append(ret, trigger)
+	}
+	return ret, nil
+}
@@ -35,38 +35,98 @@ func wrapError(field string, value any, err error) error {
 
 // errors on building a DAG.
 var (
-	errInvalidSchedule                     = errors.New("invalid schedule")
-	errScheduleMustBeStringOrArray         = errors.New("schedule must be a string or an array of strings")
-	errInvalidScheduleType                 = errors.New("invalid schedule type")
-	errInvalidKeyType                      = errors.New("invalid key type")
-	errExecutorConfigMustBeString          = errors.New("executor config key must be string")
-	errDuplicateFunction                   = errors.New("duplicate function")
-	errFuncParamsMismatch                  = errors.New("func params and args given to func command do not match")
-	errStepNameRequired                    = errors.New("step name must be specified")
-	errStepCommandIsRequired               = errors.New("step command is required")
-	errStepCommandIsEmpty                  = errors.New("step command is empty")
-	errStepCommandMustBeArrayOrString      = errors.New("step command must be an array of strings or a string")
-	errInvalidParamValue                   = errors.New("invalid parameter value")
-	errCallFunctionNotFound                = errors.New("call must specify a functions that exists")
-	errNumberOfParamsMismatch              = errors.New("the number of parameters defined in the function does not match the number of parameters given")
-	errRequiredParameterNotFound           = errors.New("required parameter not found")
-	errScheduleKeyMustBeString             = errors.New("schedule key must be a string")
-	errInvalidSignal                       = errors.New("invalid signal")
-	errInvalidEnvValue                     = errors.New("invalid value for env")
-	errArgsMustBeConvertibleToIntOrString  = errors.New("args must be convertible to either int or string")
-	errExecutorTypeMustBeString            = errors.New("executor.type value must be string")
-	errExecutorConfigValueMustBeMap        = errors.New("executor.config value must be a map")
-	errExecutorHasInvalidKey               = errors.New("executor has invalid key")
-	errExecutorConfigMustBeStringOrMap     = errors.New("executor config must be string or map")
-	errDotenvMustBeStringOrArray           = errors.New("dotenv must be a string or an array of strings")
-	errPreconditionMustBeArrayOrString     = errors.New("precondition must be a string or an array of strings")
-	errPreconditionKeyMustBeString         = errors.New("precondition key must be a string")
-	errPreconditionValueMustBeString       = errors.New("precondition value must be a string")
-	errPreconditionHasInvalidKey           = errors.New("precondition has invalid key")
-	errContinueOnOutputMustBeStringOrArray = errors.New("continueOn.Output must be a string or an array of strings")
-	errContinueOnExitCodeMustBeIntOrArray  = errors.New("continueOn.ExitCode must be an int or an array of ints")
-	errDependsMustBeStringOrArray          = errors.New("depends must be a string or an array of strings")
-	errStepsMustBeArrayOrMap               = errors.New("steps must be an array or a map")
+	errInvalidSchedule                      = errors.New("invalid schedule")
+	errScheduleMustBeStringOrArray          = errors.New("schedule must be a string or an array of strings")
+	errInvalidScheduleType                  = errors.New("invalid schedule type")
+	errInvalidTimezone                      = errors.New("invalid timezone")
+	errInvalidKeyType                       = errors.New("invalid key type")
+	errExecutorConfigMustBeString           = errors.New("executor config key must be string")
+	errDuplicateFunction                    = errors.New("duplicate function")
+	errFuncParamsMismatch                   = errors.New("func params and args given to func command do not match")
+	errStepNameRequired                     = errors.New("step name must be specified")
+	errStepCommandIsRequired                = errors.New("step command is required")
+	errStepCommandIsEmpty                   = errors.New("step command is empty")
+	errStepCommandMustBeArrayOrString       = errors.New("step command must be an array of strings or a string")
+	errInvalidParamValue                    = errors.New("invalid parameter value")
+	errCallFunctionNotFound                 = errors.New("call must specify a functions that exists")
+	errNumberOfParamsMismatch               = errors.New("the number of parameters defined in the function does not match the number of parameters given")
+	errRequiredParameterNotFound            = errors.New("required parameter not found")
+	errScheduleKeyMustBeString              = errors.New("schedule key must be a string")
+	errInvalidSignal                        = errors.New("invalid signal")
+	errInvalidEnvValue                      = errors.New("invalid value for env")
+	errArgsMustBeConvertibleToIntOrString   = errors.New("args must be convertible to either int or string")
+	errExecutorTypeMustBeString             = errors.New("executor.type value must be string")
+	errExecutorConfigValueMustBeMap         = errors.New("executor.config value must be a map")
+	errExecutorHasInvalidKey                = errors.New("executor has invalid key")
+	errExecutorConfigMustBeStringOrMap      = errors.New("executor config must be string or map")
+	errDotenvMustBeStringOrArray            = errors.New("dotenv must be a string or an array of strings")
+	errPreconditionMustBeArrayOrString      = errors.New("precondition must be a string or an array of strings")
+	errPreconditionKeyMustBeString          = errors.New("precondition key must be a string")
+	errPreconditionValueMustBeString        = errors.New("precondition value must be a string")
+	errPreconditionHasInvalidKey            = errors.New("precondition has invalid key")
+	errContinueOnOutputMustBeStringOrArray  = errors.New("continueOn.Output must be a string or an array of strings")
+	errContinueOnExitCodeMustBeIntOrArray   = errors.New("continueOn.ExitCode must be an int or an array of ints")
+	errDependsMustBeStringOrArray           = errors.New("depends must be a string or an array of strings")
+	errStepsMustBeArrayOrMap                = errors.New("steps must be an array or a map")
+	errForeachMustBeStringOrArray           = errors.New("foreach must be a string or an array")
+	errMatrixValueMustBeArray               = errors.New("matrix values must be an array")
+	errMatrixCombinationMustBeArrayOfMaps   = errors.New("matrix include and exclude must be an array of maps")
+	errMatrixFailFastMustBeBool             = errors.New("matrix failFast must be a boolean")
+	errMatrixIsEmpty                        = errors.New("matrix has no combinations")
+	errRepeatPolicyLimit                    = errors.New("repeatPolicy.limit must not be negative")
+	errRepeatPolicyBackoff                  = errors.New("repeatPolicy.backoff must be a number greater than or equal to 1")
+	errRepeatPolicyMaxIntervalSec           = errors.New("repeatPolicy.maxIntervalSec must not be negative")
+	errRepeatUntilOutputMustBeStringOrArray = errors.New("repeatPolicy.until.output must be a string or an array of strings")
+	errRepeatUntilExitCodeMustBeIntOrArray  = errors.New("repeatPolicy.until.exitCode must be an int or an array of ints")
+	errRepeatUntilIsEmpty                   = errors.New("repeatPolicy.until must have output, exitCode, condition or command")
+	errInvalidTrigger                       = errors.New("invalid trigger rule")
+	errTimeoutSecMustBePositive             = errors.New("timeoutSec must be a positive integer")
+	errRetryPolicyBackoff                   = errors.New("retryPolicy.backoff must be a number greater than or equal to 1")
+	errRetryPolicyMaxIntervalSec            = errors.New("retryPolicy.maxIntervalSec must not be negative")
+	errRetryPolicyJitter                    = errors.New("retryPolicy.jitter must be a number between 0 and 1")
+	errRetryPolicyExitCodeMustBeIntOrArray  = errors.New("retryPolicy.ExitCode must be an int or an array of ints")
+	errRetryPolicyOutputMustBeStringOrArray = errors.New("retryPolicy.Output must be a string or an array of strings")
+	errLocalDAGNameRequired                 = errors.New("name is required for a DAG defined in the same file")
+	errLocalDAGMustBeMap                    = errors.New("dags must be a map of DAG definitions")
+	errDuplicateLocalDAG                    = errors.New("duplicate DAG name")
+	errLocalDAGNotFound                     = errors.New("DAG is not defined in the file")
+	errStepTemplateNotFound                 = errors.New("step template not found")
+	errStepTemplateCycle                    = errors.New("cycle detected in step templates")
+	errStepTemplateUsesMustBeString         = errors.New("uses must be a string")
+	errStepTemplateWithMustBeMap            = errors.New("with must be a map")
+	errStepTemplateInputRequired            = errors.New("required input of the step template is missing")
+	errStepTemplateUnknownInput             = errors.New("unknown input of the step template")
+	errInvalidParamType                     = errors.New("invalid parameter type")
+	errInvalidParamPattern                  = errors.New("invalid parameter pattern")
+	errInvalidParamSchema                   = errors.New("invalid parameter schema")
+	errParamRequired                        = errors.New("value is required")
+	errParamType                            = errors.New("invalid type")
+	errParamOutOfRange                      = errors.New("out of range")
+	errParamNotInEnum                       = errors.New("not allowed")
+	errParamPatternMismatch                 = errors.New("does not match the pattern")
+	errParamUnknown                         = errors.New("unknown parameter")
+	errStepOutputSource                     = errors.New("output must have exactly one of path, regex or file")
+	errInvalidStepOutputPath                = errors.New("invalid output path")
+	errInvalidStepOutputRegex               = errors.New("invalid output regex")
+	errStepOutputMustBeStringOrMap          = errors.New("output must be a string or a map")
+	errProducesMustBeStringOrArray          = errors.New("produces must be a string or an array of strings")
+	errInvalidProducesPattern               = errors.New("invalid produces pattern")
+	errArtifactFromRequired                 = errors.New("artifact must have from")
+	errInvalidArtifactPath                  = errors.New("artifact path must be a relative path in the artifacts")
+	errInvalidSecretName                    = errors.New("secret name must be a valid environment variable name")
+	errInvalidSecretProvider                = errors.New("invalid secret provider")
+	errSecretKeyRequired                    = errors.New("secret key is required")
+	errDuplicateSecret                      = errors.New("duplicate secret name")
+	errWaitForDAGRequired                   = errors.New("dag is required to wait for")
+	errInvalidWaitForSince                  = errors.New("since must be today, schedule, or a duration")
+	errInvalidWaitForOnTimeout              = errors.New("onTimeout must be fail or skip")
+	errInvalidWaitForTimeout                = errors.New("timeoutSec and intervalSec must not be negative")
+	errInvalidDAGTrigger                    = errors.New("trigger must be a DAG name or a map with dag and params")
+	errDAGTriggerNameRequired               = errors.New("dag is required to trigger")
+	errInvalidApprovalOnTimeout             = errors.New("onTimeout must be approve or reject")
+	errInvalidApprovalTimeout               = errors.New("timeoutSec must not be negative")
+	errApprovalWithCommand                  = errors.New("approval step cannot run a command")
+	errInvalidCatchup                       = errors.New("catchup must be none, latest, all or a positive number")
 )
 
 // errorList is just a list of errors.
@@ -80,6 +140,11 @@ func (e *errorList) Add(err error) {
 	}
 }
 
+// Unwrap returns the errors in the list.
+func (e *errorList) Unwrap() []error {
+	return *e
+}
+
 // Error implements the error interface.
 // It returns a string with all the errors separated by a semicolon.
 func (e *errorList) Error() string {
@@ -0,0 +1,519 @@
+package digraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dagu-org/dagu/internal/cmdutil"
+)
+
+// Expression is a parsed `if` expression of a step.
+//
+// The expression language supports:
+//   - literals: "string", 'string', numbers, true and false
+//   - variables: ${NAME} or $NAME (params, env and outputs of upstream steps)
+//   - step references: steps.<name>.status, steps.<name>.exitCode,
+//     steps.<name>.output and steps.<name>.outputs.<output>
+//   - comparisons: ==, !=, <, <=, >, >=, =~ (regexp match) and !~
+//   - logical operators: &&, || and ! with parentheses
+//
+// Values are compared as numbers when both sides are numeric, otherwise as
+// strings. A value without a comparison is true unless it is empty, "false"
+// or "0".
+type Expression struct {
+	src  string
+	root exprNode
+}
+
+// StepLookup returns the value of the field of the step referenced in an
+// expression, e.g. steps.build.status.
+type StepLookup func(step, field string) (string, error)
+
+var (
+	ErrExpressionSyntax = errors.New("invalid expression")
+	errExpressionEval   = errors.New("failed to evaluate expression")
+)
+
+// ParseExpression parses the expression.
+func ParseExpression(s string) (*Expression, error) {
+	p := &exprParser{lexer: &exprLexer{src: s}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, fmt.Errorf("%w: unexpected %q at %d", ErrExpressionSyntax, p.tok.text, p.tok.pos)
+	}
+	return &Expression{src: s, root: root}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.src
+}
+
+// Eval evaluates the expression. Variables are resolved with the step
+// context or DAG context in ctx, and step references with the lookup function.
+func (e *Expression) Eval(ctx context.Context, lookup StepLookup) (bool, error) {
+	v, err := e.root.eval(ctx, lookup)
+	if err != nil {
+		return false, err
+	}
+	return truthy(v), nil
+}
+
+func truthy(v string) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "", "false", "0":
+		return false
+	default:
+		return true
+	}
+}
+
+func formatBool(b bool) string {
+	return strconv.FormatBool(b)
+}
+
+// exprNode is a node of the expression tree. All nodes evaluate to a string.
+type exprNode interface {
+	eval(ctx context.Context, lookup StepLookup) (string, error)
+}
+
+type literalNode struct{ value string }
+
+func (n literalNode) eval(_ context.Context, _ StepLookup) (string, error) {
+	return n.value, nil
+}
+
+type variableNode struct{ ref string }
+
+func (n variableNode) eval(ctx context.Context, _ StepLookup) (string, error) {
+	var (
+		v   string
+		err error
+	)
+	switch {
+	case IsStepContext(ctx):
+		v, err = GetStepContext(ctx).EvalString(n.ref, cmdutil.WithoutSubstitute())
+	case IsContext(ctx):
+		v, err = GetContext(ctx).EvalString(n.ref, cmdutil.WithoutSubstitute())
+	default:
+		v, err = cmdutil.EvalString(ctx, n.ref, cmdutil.WithoutSubstitute())
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %w", errExpressionEval, n.ref, err)
+	}
+	return v, nil
+}
+
+type stepRefNode struct {
+	step  string
+	field string
+}
+
+func (n stepRefNode) eval(_ context.Context, lookup StepLookup) (string, error) {
+	if lookup == nil {
+		return "", fmt.Errorf("%w: steps.%s.%s is not available", errExpressionEval, n.step, n.field)
+	}
+	v, err := lookup(n.step, n.field)
+	if err != nil {
+		return "", fmt.Errorf("%w: steps.%s.%s: %w", errExpressionEval, n.step, n.field, err)
+	}
+	return v, nil
+}
+
+type notNode struct{ operand exprNode }
+
+func (n notNode) eval(ctx context.Context, lookup StepLookup) (string, error) {
+	v, err := n.operand.eval(ctx, lookup)
+	if err != nil {
+		return "", err
+	}
+	return formatBool(!truthy(v)), nil
+}
+
+type logicalNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n logicalNode) eval(ctx context.Context, lookup StepLookup) (string, error) {
+	l, err := n.left.eval(ctx, lookup)
+	if err != nil {
+		return "", err
+	}
+	// short-circuit evaluation
+	if n.op == "&&" && !truthy(l) {
+		return formatBool(false), nil
+	}
+	if n.op == "||" && truthy(l) {
+		return formatBool(true), nil
+	}
+	r, err := n.right.eval(ctx, lookup)
+	if err != nil {
+		return "", err
+	}
+	return formatBool(truthy(r)), nil
+}
+
+type compareNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n compareNode) eval(ctx context.Context, lookup StepLookup) (string, error) {
+	l, err := n.left.eval(ctx, lookup)
+	if err != nil {
+		return "", err
+	}
+	r, err := n.right.eval(ctx, lookup)
+	if err != nil {
+		return "", err
+	}
+
+	switch n.op {
+	case "=~", "!~":
+		re, err := regexp.Compile(r)
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid regexp %q: %w", errExpressionEval, r, err)
+		}
+		return formatBool(re.MatchString(l) == (n.op == "=~")), nil
+	}
+
+	lf, lerr := strconv.ParseFloat(strings.TrimSpace(l), 64)
+	rf, rerr := strconv.ParseFloat(strings.TrimSpace(r), 64)
+	numeric := lerr == nil && rerr == nil
+
+	switch n.op {
+	case "==":
+		if numeric {
+			return formatBool(lf == rf), nil
+		}
+		return formatBool(l == r), nil
+	case "!=":
+		if numeric {
+			return formatBool(lf != rf), nil
+		}
+		return formatBool(l != r), nil
+	}
+
+	if !numeric {
+		return "", fmt.Errorf("%w: %q %s %q: operands must be numbers", errExpressionEval, l, n.op, r)
+	}
+	switch n.op {
+	case "<":
+		return formatBool(lf < rf), nil
+	case "<=":
+		return formatBool(lf <= rf), nil
+	case ">":
+		return formatBool(lf > rf), nil
+	case ">=":
+		return formatBool(lf >= rf), nil
+	default:
+		return "", fmt.Errorf("%w: unknown operator %q", errExpressionEval, n.op)
+	}
+}
+
+// exprParser is a recursive descent parser for expressions.
+//
+//	or      = and { "||" and }
+//	and     = unary { "&&" unary }
+//	unary   = "!" unary | compare
+//	compare = operand [ op operand ]
+//	operand = "(" or ")" | string | number | ident | variable
+type exprParser struct {
+	lexer *exprLexer
+	tok   exprToken
+}
+
+func (p *exprParser) next() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOp && p.tok.text == "||" {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOp && p.tok.text == "&&" {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.tok.kind == tokOp && p.tok.text == "!" {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *exprParser) parseCompare() (exprNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokOp || !isCompareOp(p.tok.text) {
+		return left, nil
+	}
+	op := p.tok.text
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return compareNode{op: op, left: left, right: right}, nil
+}
+
+func (p *exprParser) parseOperand() (exprNode, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokLParen:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, fmt.Errorf("%w: missing ')' at %d", ErrExpressionSyntax, p.tok.pos)
+		}
+		return node, p.next()
+
+	case tokString, tokNumber:
+		return literalNode{value: tok.text}, p.next()
+
+	case tokVariable:
+		return variableNode{ref: tok.text}, p.next()
+
+	case tokIdent:
+		node, err := parseIdent(tok)
+		if err != nil {
+			return nil, err
+		}
+		return node, p.next()
+
+	case tokEOF:
+		return nil, fmt.Errorf("%w: unexpected end of expression", ErrExpressionSyntax)
+
+	default:
+		return nil, fmt.Errorf("%w: unexpected %q at %d", ErrExpressionSyntax, tok.text, tok.pos)
+
+	}
+}
+
+// parseIdent parses an identifier, which is either a boolean literal or a
+// step reference in the form of steps.<name>.<field> or
+// steps.<name>.outputs.<output>.
+func parseIdent(tok exprToken) (exprNode, error) {
+	switch tok.text {
+	case "true", "false":
+		return literalNode{value: tok.text}, nil
+	}
+
+	rest, ok := strings.CutPrefix(tok.text, "steps.")
+	if ok {
+		if idx := strings.LastIndex(rest, ".outputs."); idx > 0 && idx+len(".outputs.") < len(rest) {
+			return stepRefNode{step: rest[:idx], field: rest[idx+1:]}, nil
+		}
+		idx := strings.LastIndex(rest, ".")
+		if idx > 0 && idx < len(rest)-1 {
+			return stepRefNode{step: rest[:idx], field: rest[idx+1:]}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unknown identifier %q at %d", ErrExpressionSyntax, tok.text, tok.pos)
+}
+
+func isCompareOp(op string) bool {
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=", "=~", "!~":
+		return true
+	default:
+		return false
+	}
+}
+
+type exprTokenKind int
+
+const (
+	tokEOF exprTokenKind = iota
+	tokString
+	tokNumber
+	tokIdent
+	tokVariable
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+	pos  int
+}
+
+type exprLexer struct {
+	src string
+	pos int
+}
+
+// operators sorted so that the longer ones are matched first.
+var exprOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "=~", "!~", "<", ">", "!"}
+
+func (l *exprLexer) next() (exprToken, error) {
+	for l.pos < len(l.src) && unicode.IsSpace(rune(l.src[l.pos])) {
+		l.pos++
+	}
+	if l.pos >= len(l.src) {
+		return exprToken{kind: tokEOF, pos: l.pos}, nil
+	}
+
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case c == '(':
+		l.pos++
+		return exprToken{kind: tokLParen, text: "(", pos: start}, nil
+
+	case c == ')':
+		l.pos++
+		return exprToken{kind: tokRParen, text: ")", pos: start}, nil
+
+	case c == '"' || c == '\'':
+		return l.readString(c)
+
+	case c == '$':
+		return l.readVariable()
+
+	case c >= '0' && c <= '9' || c == '-' || c == '.':
+		return l.readNumber()
+
+	case isIdentChar(c):
+		for l.pos < len(l.src) && isIdentChar(l.src[l.pos]) {
+			l.pos++
+		}
+		return exprToken{kind: tokIdent, text: l.src[start:l.pos], pos: start}, nil
+
+	}
+
+	for _, op := range exprOperators {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.pos += len(op)
+			return exprToken{kind: tokOp, text: op, pos: start}, nil
+		}
+	}
+
+	return exprToken{}, fmt.Errorf("%w: unexpected character %q at %d", ErrExpressionSyntax, c, start)
+}
+
+func (l *exprLexer) readString(quote byte) (exprToken, error) {
+	start := l.pos
+	l.pos++ // skip the opening quote
+
+	var sb strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\\' && l.pos+1 < len(l.src) && (l.src[l.pos+1] == quote || l.src[l.pos+1] == '\\'):
+			// only the quote and backslash are escaped so that regexps can
+			// be written without double escaping, e.g. "^v\d+"
+			sb.WriteByte(l.src[l.pos+1])
+			l.pos += 2
+		case c == quote:
+			l.pos++
+			return exprToken{kind: tokString, text: sb.String(), pos: start}, nil
+		default:
+			sb.WriteByte(c)
+			l.pos++
+		}
+	}
+	return exprToken{}, fmt.Errorf("%w: unterminated string at %d", ErrExpressionSyntax, start)
+}
+
+func (l *exprLexer) readVariable() (exprToken, error) {
+	start := l.pos
+	l.pos++ // skip '$'
+
+	if l.pos < len(l.src) && l.src[l.pos] == '{' {
+		end := strings.IndexByte(l.src[l.pos:], '}')
+		if end < 0 {
+			return exprToken{}, fmt.Errorf("%w: unterminated variable at %d", ErrExpressionSyntax, start)
+		}
+		l.pos += end + 1
+		return exprToken{kind: tokVariable, text: l.src[start:l.pos], pos: start}, nil
+	}
+
+	for l.pos < len(l.src) && (isIdentChar(l.src[l.pos]) && l.src[l.pos] != '.' && l.src[l.pos] != '-') {
+		l.pos++
+	}
+	if l.pos == start+1 {
+		return exprToken{}, fmt.Errorf("%w: invalid variable at %d", ErrExpressionSyntax, start)
+	}
+	return exprToken{kind: tokVariable, text: l.src[start:l.pos], pos: start}, nil
+}
+
+func (l *exprLexer) readNumber() (exprToken, error) {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.src) && (l.src[l.pos] >= '0' && l.src[l.pos] <= '9' || l.src[l.pos] == '.') {
+		l.pos++
+	}
+	text := l.src[start:l.pos]
+	if _, err := strconv.ParseFloat(text, 64); err != nil {
+		return exprToken{}, fmt.Errorf("%w: invalid number %q at %d", ErrExpressionSyntax, text, start)
+	}
+	return exprToken{kind: tokNumber, text: text, pos: start}, nil
+}
+
+func isIdentChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-'
+}
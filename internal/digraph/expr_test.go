@@ -0,0 +1,90 @@
+package digraph
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpression_Eval(t *testing.T) {
+	steps := map[string]map[string]string{
+		"build": {"status": "failed", "exitCode": "2", "output": "v1.2.3", "outputs.version": "1.2.3"},
+		"test":  {"status": "finished", "exitCode": "0", "output": ""},
+	}
+	lookup := func(step, field string) (string, error) {
+		if s, ok := steps[step]; ok {
+			return s[field], nil
+		}
+		return "", fmt.Errorf("step not found: %s", step)
+	}
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    bool
+		wantErr bool
+	}{
+		{name: "StepStatus", expr: `steps.build.status == "failed"`, want: true},
+		{name: "StepStatusNotEqual", expr: `steps.test.status != 'finished'`, want: false},
+		{name: "StepExitCode", expr: `steps.build.exitCode >= 2`, want: true},
+		{name: "StepOutputRegexp", expr: `steps.build.output =~ "^v1\.\d+"`, want: true},
+		{name: "StepOutputNotRegexp", expr: `steps.build.output !~ "^v2"`, want: true},
+		{name: "StepOutputs", expr: `steps.build.outputs.version == "1.2.3"`, want: true},
+		{name: "EnvVar", expr: `${TEST_EXPR_COUNT} > 10`, want: true},
+		{name: "EnvVarShort", expr: `$TEST_EXPR_COUNT == 12.0`, want: true},
+		{name: "Or", expr: `steps.build.status == "failed" || ${TEST_EXPR_COUNT} > 100`, want: true},
+		{name: "And", expr: `steps.build.status == "failed" && ${TEST_EXPR_COUNT} > 100`, want: false},
+		{name: "Not", expr: `!(steps.test.status == "failed")`, want: true},
+		{name: "Precedence", expr: `false && true || true`, want: true},
+		{name: "Parentheses", expr: `false && (true || true)`, want: false},
+		{name: "Truthy", expr: `steps.build.output`, want: true},
+		{name: "FalsyEmpty", expr: `steps.test.output`, want: false},
+		{name: "FalsyZero", expr: `steps.test.exitCode`, want: false},
+		{name: "StringCompare", expr: `"abc" == "abc"`, want: true},
+		{name: "NonNumericCompare", expr: `"abc" > 1`, wantErr: true},
+		{name: "UnknownStep", expr: `steps.deploy.status == "failed"`, wantErr: true},
+		{name: "InvalidRegexp", expr: `"a" =~ "("`, wantErr: true},
+	}
+
+	_ = os.Setenv("TEST_EXPR_COUNT", "12")
+	t.Cleanup(func() {
+		_ = os.Unsetenv("TEST_EXPR_COUNT")
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseExpression(tt.expr)
+			require.NoError(t, err)
+
+			got, err := expr.Eval(context.Background(), lookup)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseExpression_Error(t *testing.T) {
+	tests := []string{
+		``,
+		`steps.build.status ==`,
+		`(true`,
+		`"unterminated`,
+		`${UNTERMINATED`,
+		`unknown == 1`,
+		`true true`,
+		`1 # 2`,
+	}
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseExpression(expr)
+			require.ErrorIs(t, err, ErrExpressionSyntax)
+		})
+	}
+}
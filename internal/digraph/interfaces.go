@@ -3,12 +3,18 @@
 
 package digraph
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // DBClient gets a result of a DAG execution.
 type DBClient interface {
 	GetDAG(ctx context.Context, name string) (*DAG, error)
 	GetStatus(ctx context.Context, name string, requestID string) (*Status, error)
+	// GetRecentStatuses returns the statuses of the recent runs of the DAG
+	// ordered from the newest.
+	GetRecentStatuses(ctx context.Context, name string, n int) ([]*Status, error)
 }
 
 // Status is the result of a DAG execution.
@@ -19,4 +25,12 @@ type Status struct {
 	Params string `json:"params,omitempty"`
 	// Outputs is the outputs of the DAG execution.
 	Outputs map[string]string `json:"outputs,omitempty"`
+	// RequestID is the request ID of the DAG execution.
+	RequestID string `json:"-"`
+	// Succeeded is true if the DAG execution finished successfully.
+	Succeeded bool `json:"-"`
+	// StartedAt is the time when the DAG execution started.
+	StartedAt time.Time `json:"-"`
+	// FinishedAt is the time when the DAG execution finished.
+	FinishedAt time.Time `json:"-"`
 }
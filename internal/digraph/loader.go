@@ -25,6 +25,7 @@ type LoadOptions struct {
 	paramsList   []string // List of parameters to override default parameters in the DAG.
 	noEval       bool     // Flag to disable evaluation of dynamic fields.
 	onlyMetadata bool     // Flag to load only metadata without full DAG details.
+	templatesDir string   // Directory to look up the step templates.
 }
 
 // LoadOption is a function type for setting LoadOptions.
@@ -37,6 +38,13 @@ func WithBaseConfig(baseDAG string) LoadOption {
 	}
 }
 
+// WithTemplatesDir sets the directory to look up the step templates.
+func WithTemplatesDir(dir string) LoadOption {
+	return func(o *LoadOptions) {
+		o.templatesDir = dir
+	}
+}
+
 // WithParams sets the parameters for the DAG.
 func WithParams(params any) LoadOption {
 	return func(o *LoadOptions) {
@@ -79,6 +87,7 @@ func Load(ctx context.Context, dag string, opts ...LoadOption) (*DAG, error) {
 			parametersList: options.paramsList,
 			onlyMetadata:   options.onlyMetadata,
 			noEval:         options.noEval,
+			templatesDir:   options.templatesDir,
 		},
 	}
 	return loadDAG(buildContext, dag)
@@ -96,22 +105,38 @@ func LoadYAML(ctx context.Context, data []byte, opts ...LoadOption) (*DAG, error
 		parametersList: options.paramsList,
 		onlyMetadata:   options.onlyMetadata,
 		noEval:         options.noEval,
+		templatesDir:   options.templatesDir,
 	})
 }
 
 // loadYAML loads the DAG configuration from YAML data.
 func loadYAML(ctx context.Context, data []byte, opts buildOpts) (*DAG, error) {
-	raw, err := unmarshalData(data)
+	docs, err := unmarshalDocuments(data)
 	if err != nil {
 		return nil, err
 	}
 
-	def, err := decode(raw)
+	def, err := decode(docs[0])
 	if err != nil {
 		return nil, err
 	}
 
-	return build(BuildContext{ctx: ctx, opts: opts}, def)
+	buildContext := BuildContext{ctx: ctx, opts: opts}
+	dag, err := build(buildContext, def)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.onlyMetadata {
+		buildContext = buildContext.WithOpts(buildOpts{noEval: opts.noEval, templatesDir: opts.templatesDir})
+		locals, err := loadLocalDAGs(buildContext, docs)
+		if err != nil {
+			return nil, err
+		}
+		dag.LocalDAGs = locals
+	}
+
+	return dag, nil
 }
 
 // loadBaseConfig loads the global configuration from the given file.
@@ -134,25 +159,66 @@ func loadBaseConfig(ctx BuildContext, file string) (*DAG, error) {
 		return nil, err
 	}
 
-	ctx = ctx.WithOpts(buildOpts{noEval: ctx.opts.noEval}).WithFile(file)
+	ctx = ctx.WithOpts(buildOpts{noEval: ctx.opts.noEval, templatesDir: ctx.opts.templatesDir}).WithFile(file)
 	return build(ctx, def)
 }
 
 // loadDAG loads the DAG from the given file.
+// The file can define local DAGs in the following YAML documents or in the
+// `dags` field. A local DAG is loaded by the location "<file>#<name>".
 func loadDAG(ctx BuildContext, dag string) (*DAG, error) {
-	filePath, err := resolveYamlFilePath(dag)
+	file, localName := splitLocation(dag)
+	filePath, err := resolveYamlFilePath(file)
 	if err != nil {
 		return nil, err
 	}
 
 	ctx = ctx.WithFile(filePath)
 
-	dest, err := loadBaseConfigIfRequired(ctx, ctx.opts.base)
+	docs, err := readFileDocuments(filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	raw, err := readFile(filePath)
+	if localName != "" {
+		locals, err := localDAGDocuments(docs)
+		if err != nil {
+			return nil, err
+		}
+		raw, ok := locals[localName]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errLocalDAGNotFound, localName)
+		}
+		return loadDocument(ctx, raw, localName)
+	}
+
+	dest, err := loadDocument(ctx, docs[0], "")
+	if err != nil {
+		return nil, err
+	}
+
+	if !ctx.opts.onlyMetadata {
+		// The parameters are for the main DAG only.
+		ctx = ctx.WithOpts(buildOpts{
+			base:         ctx.opts.base,
+			noEval:       ctx.opts.noEval,
+			templatesDir: ctx.opts.templatesDir,
+		})
+		locals, err := loadLocalDAGs(ctx, docs)
+		if err != nil {
+			return nil, err
+		}
+		dest.LocalDAGs = locals
+	}
+
+	return dest, nil
+}
+
+// loadDocument builds the DAG from the YAML document and merges it into the
+// base config. If localName is not empty, the document is loaded as the
+// local DAG of the file.
+func loadDocument(ctx BuildContext, raw map[string]any, localName string) (*DAG, error) {
+	dest, err := loadBaseConfigIfRequired(ctx, ctx.opts.base)
 	if err != nil {
 		return nil, err
 	}
@@ -173,9 +239,14 @@ func loadDAG(ctx BuildContext, dag string) (*DAG, error) {
 		return nil, err
 	}
 
+	if localName != "" {
+		dest.Name = localName
+		dest.Location = LocalDAGLocation(ctx.file, localName)
+	}
+
 	// Set the name if not set.
 	if dest.Name == "" {
-		dest.Name = defaultName(filePath)
+		dest.Name = defaultName(ctx.file)
 	}
 
 	// Set defaults
@@ -184,6 +255,92 @@ func loadDAG(ctx BuildContext, dag string) (*DAG, error) {
 	return dest, nil
 }
 
+// loadLocalDAGs loads the local DAGs defined in the documents.
+func loadLocalDAGs(ctx BuildContext, docs []map[string]any) (map[string]*DAG, error) {
+	locals, err := localDAGDocuments(docs)
+	if err != nil {
+		return nil, err
+	}
+	if len(locals) == 0 {
+		return nil, nil
+	}
+
+	ret := make(map[string]*DAG, len(locals))
+	for name, raw := range locals {
+		dag, err := loadDocument(ctx, raw, name)
+		if err != nil {
+			return nil, wrapError("dags."+name, nil, err)
+		}
+		ret[name] = dag
+	}
+	return ret, nil
+}
+
+// localDAGDocuments returns the definitions of the local DAGs by name.
+// The local DAGs are defined in the `dags` field of the first document or
+// as the following documents with the `name` field.
+func localDAGDocuments(docs []map[string]any) (map[string]map[string]any, error) {
+	ret := make(map[string]map[string]any)
+
+	if value, ok := docs[0]["dags"]; ok && value != nil {
+		dags, ok := value.(map[any]any)
+		if !ok {
+			return nil, wrapError("dags", value, errLocalDAGMustBeMap)
+		}
+		for k, v := range dags {
+			name, err := parseKey(k)
+			if err != nil {
+				return nil, wrapError("dags", k, err)
+			}
+			def, ok := v.(map[any]any)
+			if !ok {
+				return nil, wrapError("dags."+name, v, errLocalDAGMustBeMap)
+			}
+			raw := make(map[string]any, len(def))
+			for k, v := range def {
+				key, err := parseKey(k)
+				if err != nil {
+					return nil, wrapError("dags."+name, k, err)
+				}
+				raw[key] = v
+			}
+			ret[name] = raw
+		}
+	}
+
+	for _, doc := range docs[1:] {
+		name, _ := doc["name"].(string)
+		if name == "" {
+			return nil, wrapError("name", doc["name"], errLocalDAGNameRequired)
+		}
+		if _, ok := ret[name]; ok {
+			return nil, wrapError("name", name, errDuplicateLocalDAG)
+		}
+		ret[name] = doc
+	}
+
+	return ret, nil
+}
+
+// LocalDAGLocation returns the location of the local DAG defined in the file.
+func LocalDAGLocation(file, name string) string {
+	return file + localDAGSeparator + name
+}
+
+// splitLocation splits the location into the file and the name of the local
+// DAG. The name is empty if the location is not for a local DAG.
+func splitLocation(location string) (string, string) {
+	i := strings.LastIndex(location, localDAGSeparator)
+	if i > 0 && !strings.ContainsRune(location[i+1:], filepath.Separator) {
+		return location[:i], location[i+1:]
+	}
+	return location, ""
+}
+
+// localDAGSeparator separates the file and the name of a local DAG in the
+// location.
+const localDAGSeparator = "#"
+
 // defaultName returns the default name for the given file.
 // The default name is the filename without the extension.
 func defaultName(file string) string {
@@ -256,6 +413,45 @@ func readFile(file string) (cfg map[string]any, err error) {
 	return unmarshalData(data)
 }
 
+// readFileDocuments reads all of the YAML documents in the file.
+func readFileDocuments(file string) ([]map[string]any, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %v", file, err)
+	}
+
+	return unmarshalDocuments(data)
+}
+
+// unmarshalDocuments unmarshals the multi-document YAML data into maps.
+// It returns at least one (possibly nil) document.
+func unmarshalDocuments(data []byte) ([]map[string]any, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	var docs []map[string]any
+	for {
+		var cm map[string]any
+		err := decoder.Decode(&cm)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Skip empty documents except the first one.
+		if cm == nil && len(docs) > 0 {
+			continue
+		}
+		docs = append(docs, cm)
+	}
+
+	if len(docs) == 0 {
+		docs = append(docs, nil)
+	}
+
+	return docs, nil
+}
+
 // unmarshalData unmarshals the data into a map.
 func unmarshalData(data []byte) (map[string]any, error) {
 	var cm map[string]any
@@ -99,6 +99,56 @@ func Test_LoadDefaultConfig(t *testing.T) {
 	})
 }
 
+func Test_LoadLocalDAGs(t *testing.T) {
+	filePath := filepath.Join(testdataDir, "local_dags.yaml")
+
+	t.Run("LoadParent", func(t *testing.T) {
+		dag, err := Load(context.Background(), filePath, WithParams("NAME=foo"))
+		require.NoError(t, err)
+
+		require.Equal(t, "local_dags", dag.Name)
+		require.Equal(t, filePath, dag.Location)
+		require.Equal(t, []string{"NAME=foo"}, dag.Params)
+		require.Len(t, dag.Steps, 2)
+		require.Len(t, dag.LocalDAGs, 2)
+
+		child := dag.LocalDAGs["child"]
+		require.NotNil(t, child)
+		assert.Equal(t, "child", child.Name)
+		assert.Equal(t, filePath+"#child", child.Location)
+		assert.Equal(t, []string{"MESSAGE=default"}, child.Params)
+		assert.Equal(t, filepath.Dir(filePath), child.Steps[0].Dir)
+
+		inline := dag.LocalDAGs["inline"]
+		require.NotNil(t, inline)
+		assert.Equal(t, "inline", inline.Name)
+		assert.Equal(t, filePath+"#inline", inline.Location)
+		require.Len(t, inline.Steps, 1)
+	})
+	t.Run("LoadLocalDAG", func(t *testing.T) {
+		dag, err := Load(context.Background(), LocalDAGLocation(filePath, "child"), WithParams("MESSAGE=hello"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "child", dag.Name)
+		assert.Equal(t, filePath+"#child", dag.Location)
+		assert.Equal(t, []string{"MESSAGE=hello"}, dag.Params)
+		assert.Empty(t, dag.LocalDAGs)
+	})
+	t.Run("OnlyMetadata", func(t *testing.T) {
+		dag, err := Load(context.Background(), filePath, OnlyMetadata(), WithoutEval())
+		require.NoError(t, err)
+		assert.Empty(t, dag.LocalDAGs)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Load(context.Background(), LocalDAGLocation(filePath, "missing"))
+		require.ErrorIs(t, err, errLocalDAGNotFound)
+	})
+	t.Run("NameRequired", func(t *testing.T) {
+		_, err := Load(context.Background(), filepath.Join(testdataDir, "invalid_local_dags.yaml"))
+		require.ErrorIs(t, err, errLocalDAGNameRequired)
+	})
+}
+
 const (
 	testDAG = `
 name: test DAG
@@ -118,6 +168,12 @@ func Test_LoadYAML(t *testing.T) {
 		require.Equal(t, step.Name, "1")
 		require.Equal(t, step.Command, "true")
 	})
+	t.Run("MultipleDocuments", func(t *testing.T) {
+		ret, err := loadYAML(context.Background(), []byte(testDAG+"---\nname: child\nsteps:\n  - name: a\n    command: \"true\"\n"), buildOpts{})
+		require.NoError(t, err)
+		require.Equal(t, "test DAG", ret.Name)
+		require.Contains(t, ret.LocalDAGs, "child")
+	})
 	t.Run("InvalidYAMLData", func(t *testing.T) {
 		_, err := loadYAML(context.Background(), []byte(`invalidyaml`), buildOpts{})
 		require.Error(t, err)
@@ -0,0 +1,246 @@
+package digraph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Reserved keys in the matrix definition.
+const (
+	matrixKeyInclude  = "include"
+	matrixKeyExclude  = "exclude"
+	matrixKeyFailFast = "failFast"
+)
+
+// matrix is the parsed matrix definition of a step.
+type matrix struct {
+	// keys is the sorted list of dimension names.
+	keys []string
+	// values is the list of values for each dimension.
+	values map[string][]string
+	// include is the list of additional combinations.
+	include []map[string]string
+	// exclude is the list of (partial) combinations to exclude.
+	exclude []map[string]string
+	// failFast cancels the sibling instances when one of them fails.
+	failFast bool
+}
+
+// parseMatrix parses the matrix definition of a step.
+// Keys other than include, exclude and failFast are the dimensions of the
+// matrix and their values must be arrays.
+func parseMatrix(def map[string]any) (*matrix, error) {
+	m := &matrix{values: make(map[string][]string)}
+
+	for key, value := range def {
+		switch key {
+		case matrixKeyInclude:
+			combinations, err := parseMatrixCombinations(value)
+			if err != nil {
+				return nil, wrapError("matrix.include", value, err)
+			}
+			m.include = combinations
+
+		case matrixKeyExclude:
+			combinations, err := parseMatrixCombinations(value)
+			if err != nil {
+				return nil, wrapError("matrix.exclude", value, err)
+			}
+			m.exclude = combinations
+
+		case matrixKeyFailFast:
+			failFast, ok := value.(bool)
+			if !ok {
+				return nil, wrapError("matrix.failFast", value, errMatrixFailFastMustBeBool)
+			}
+			m.failFast = failFast
+
+		default:
+			items, ok := value.([]any)
+			if !ok {
+				return nil, wrapError("matrix."+key, value, errMatrixValueMustBeArray)
+			}
+			for _, item := range items {
+				m.values[key] = append(m.values[key], fmt.Sprintf("%v", item))
+			}
+			m.keys = append(m.keys, key)
+
+		}
+	}
+
+	sort.Strings(m.keys)
+	return m, nil
+}
+
+func parseMatrixCombinations(value any) ([]map[string]string, error) {
+	items, ok := value.([]any)
+	if !ok {
+		return nil, errMatrixCombinationMustBeArrayOfMaps
+	}
+
+	var ret []map[string]string
+	for _, item := range items {
+		combination, ok := item.(map[any]any)
+		if !ok {
+			return nil, errMatrixCombinationMustBeArrayOfMaps
+		}
+		c := make(map[string]string)
+		for k, v := range combination {
+			key, err := parseKey(k)
+			if err != nil {
+				return nil, err
+			}
+			c[key] = fmt.Sprintf("%v", v)
+		}
+		ret = append(ret, c)
+	}
+	return ret, nil
+}
+
+// combinations returns the combinations of the matrix in a deterministic
+// order: the cartesian product of the dimensions (sorted by name) without the
+// excluded combinations, followed by the included ones.
+func (m *matrix) combinations() []map[string]string {
+	var ret []map[string]string
+	if len(m.keys) > 0 {
+		ret = []map[string]string{{}}
+		for _, key := range m.keys {
+			var next []map[string]string
+			for _, c := range ret {
+				for _, value := range m.values[key] {
+					combination := make(map[string]string, len(c)+1)
+					for k, v := range c {
+						combination[k] = v
+					}
+					combination[key] = value
+					next = append(next, combination)
+				}
+			}
+			ret = next
+		}
+	}
+
+	var filtered []map[string]string
+	for _, c := range ret {
+		if !m.isExcluded(c) {
+			filtered = append(filtered, c)
+		}
+	}
+
+	for _, c := range m.include {
+		if !containsCombination(filtered, c) {
+			filtered = append(filtered, c)
+		}
+	}
+
+	return filtered
+}
+
+// isExcluded returns true if the combination matches any of the exclude rules.
+// An exclude rule matches when all of its keys have the same values.
+func (m *matrix) isExcluded(combination map[string]string) bool {
+	for _, rule := range m.exclude {
+		matched := true
+		for k, v := range rule {
+			if combination[k] != v {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func containsCombination(list []map[string]string, combination map[string]string) bool {
+	for _, c := range list {
+		if len(c) != len(combination) {
+			continue
+		}
+		equal := true
+		for k, v := range combination {
+			if vv, ok := c[k]; !ok || vv != v {
+				equal = false
+				break
+			}
+		}
+		if equal {
+			return true
+		}
+	}
+	return false
+}
+
+// matrixStepName returns the name of the step instance for the combination,
+// e.g. test[os=linux,version=1].
+func matrixStepName(name string, combination map[string]string) string {
+	keys := make([]string, 0, len(combination))
+	for k := range combination {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+combination[k])
+	}
+	return fmt.Sprintf("%s[%s]", name, strings.Join(parts, ","))
+}
+
+// buildMatrixSteps builds a step for each combination of the matrix.
+func buildMatrixSteps(ctx BuildContext, def stepDef, fns []*funcDef) ([]*Step, error) {
+	m, err := parseMatrix(def.Matrix)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []*Step
+	for _, combination := range m.combinations() {
+		step, err := buildStep(ctx, def, fns)
+		if err != nil {
+			return nil, err
+		}
+		step.Name = matrixStepName(def.Name, combination)
+		step.Matrix = &MatrixInstance{
+			Step:     def.Name,
+			Values:   combination,
+			FailFast: m.failFast,
+		}
+		steps = append(steps, step)
+	}
+
+	if len(steps) == 0 {
+		return nil, wrapError("matrix", def.Matrix, errMatrixIsEmpty)
+	}
+
+	return steps, nil
+}
+
+// resolveMatrixDepends replaces the dependencies on a matrix step with the
+// dependencies on all of its instances.
+func resolveMatrixDepends(steps []Step) {
+	instances := make(map[string][]string)
+	for _, step := range steps {
+		if step.Matrix != nil {
+			instances[step.Matrix.Step] = append(instances[step.Matrix.Step], step.Name)
+		}
+	}
+	if len(instances) == 0 {
+		return
+	}
+
+	for i, step := range steps {
+		var depends []string
+		for _, dep := range step.Depends {
+			if names, ok := instances[dep]; ok {
+				depends = append(depends, names...)
+				continue
+			}
+			depends = append(depends, dep)
+		}
+		steps[i].Depends = depends
+	}
+}
@@ -0,0 +1,344 @@
+package digraph
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// ParamType is the type of a parameter value.
+type ParamType string
+
+const (
+	ParamTypeString  ParamType = "string"
+	ParamTypeInteger ParamType = "integer"
+	ParamTypeNumber  ParamType = "number"
+	ParamTypeBoolean ParamType = "boolean"
+)
+
+// ParamSchema is the schema of a named parameter.
+// Parameters are passed as strings; the schema defines how to validate them.
+type ParamSchema struct {
+	// Name is the name of the parameter.
+	Name string `json:"Name"`
+	// Type is the type of the value. It is ParamTypeString if empty.
+	Type ParamType `json:"Type,omitempty"`
+	// Description is the description of the parameter.
+	Description string `json:"Description,omitempty"`
+	// Default is the default value of the parameter.
+	Default string `json:"Default,omitempty"`
+	// Required indicates that the value must not be empty.
+	Required bool `json:"Required,omitempty"`
+	// Enum is the list of allowed values.
+	Enum []string `json:"Enum,omitempty"`
+	// Pattern is the regular expression that the value must match.
+	Pattern string `json:"Pattern,omitempty"`
+	// Minimum is the minimum value of a number or an integer.
+	Minimum *float64 `json:"Minimum,omitempty"`
+	// Maximum is the maximum value of a number or an integer.
+	Maximum *float64 `json:"Maximum,omitempty"`
+	// MinLength is the minimum length of a string.
+	MinLength *int `json:"MinLength,omitempty"`
+	// MaxLength is the maximum length of a string.
+	MaxLength *int `json:"MaxLength,omitempty"`
+}
+
+// paramSchemaDef defines the schema of a parameter.
+type paramSchemaDef struct {
+	Type        string   // Type of the value
+	Description string   // Description of the parameter
+	Default     any      // Default value
+	Required    bool     // The value must not be empty
+	Enum        []any    // Allowed values
+	Pattern     string   // Regular expression to match
+	Minimum     *float64 // Minimum value of a number
+	Maximum     *float64 // Maximum value of a number
+	MinLength   *int     // Minimum length of a string
+	MaxLength   *int     // Maximum length of a string
+}
+
+// Validate validates the value of the parameter against the schema.
+func (s ParamSchema) Validate(value string) error {
+	if value == "" {
+		if s.Required {
+			return errParamRequired
+		}
+		return nil
+	}
+
+	switch s.Type {
+	case ParamTypeInteger, ParamTypeNumber:
+		var n float64
+		if s.Type == ParamTypeInteger {
+			i, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%w: must be an integer", errParamType)
+			}
+			n = float64(i)
+		} else {
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("%w: must be a number", errParamType)
+			}
+			n = f
+		}
+		if s.Minimum != nil && n < *s.Minimum {
+			return fmt.Errorf("%w: must be greater than or equal to %v", errParamOutOfRange, *s.Minimum)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			return fmt.Errorf("%w: must be less than or equal to %v", errParamOutOfRange, *s.Maximum)
+		}
+
+	case ParamTypeBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%w: must be a boolean", errParamType)
+		}
+
+	default:
+		length := utf8.RuneCountInString(value)
+		if s.MinLength != nil && length < *s.MinLength {
+			return fmt.Errorf("%w: length must be greater than or equal to %d", errParamOutOfRange, *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			return fmt.Errorf("%w: length must be less than or equal to %d", errParamOutOfRange, *s.MaxLength)
+		}
+
+	}
+
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, value) {
+		return fmt.Errorf("%w: must be one of [%s]", errParamNotInEnum, strings.Join(s.Enum, ", "))
+	}
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("%w: %s", errInvalidParamPattern, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("%w: %s", errParamPatternMismatch, s.Pattern)
+		}
+	}
+
+	return nil
+}
+
+// ParamError is a validation error of a parameter.
+type ParamError struct {
+	Name  string
+	Value string
+	Err   error
+}
+
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("param '%s': %v (value: %q)", e.Name, e.Err, e.Value)
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// ParamErrors is a list of validation errors of parameters.
+type ParamErrors []*ParamError
+
+func (e ParamErrors) Error() string {
+	errStrings := make([]string, len(e))
+	for i, err := range e {
+		errStrings[i] = err.Error()
+	}
+	return strings.Join(errStrings, "; ")
+}
+
+func (e ParamErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// AsParamErrors returns the validation errors of parameters in the error.
+func AsParamErrors(err error) (ParamErrors, bool) {
+	var errs ParamErrors
+	if errors.As(err, &errs) {
+		return errs, true
+	}
+	return nil, false
+}
+
+// ValidateParams validates the parameters against the schema of the DAG.
+// The parameters are in the same form as the command line, e.g.
+// "ENV=prod COUNT=3". Parameters that are not given take the default values.
+// It returns ParamErrors if any of the parameters is invalid or is a named
+// parameter that the DAG doesn't declare.
+func (d *DAG) ValidateParams(params string) error {
+	if len(d.ParamsSchema) == 0 {
+		return nil
+	}
+
+	buildCtx := BuildContext{opts: buildOpts{noEval: true}}
+	pairs, err := parseStringParams(buildCtx, params)
+	if err != nil {
+		return err
+	}
+	defaults, err := parseStringParams(buildCtx, d.DefaultParams)
+	if err != nil {
+		return err
+	}
+
+	declared := make(map[string]bool, len(defaults))
+	for _, pair := range defaults {
+		declared[pair.Name] = true
+	}
+	values := make(map[string]string, len(d.ParamsSchema))
+	for _, s := range d.ParamsSchema {
+		declared[s.Name] = true
+		values[s.Name] = s.Default
+	}
+	for _, pair := range pairs {
+		if pair.Name != "" {
+			values[pair.Name] = pair.Value
+		}
+	}
+
+	return validateParams(d.ParamsSchema, declared, values)
+}
+
+// validateParams validates the values of the parameters by name. The values
+// of the names that are not declared are errors as well since a typo in the
+// name would leave the declared parameter at its default value.
+func validateParams(schema []ParamSchema, declared map[string]bool, values map[string]string) error {
+	var errs ParamErrors
+	for _, s := range schema {
+		value := values[s.Name]
+		if err := s.Validate(value); err != nil {
+			errs = append(errs, &ParamError{Name: s.Name, Value: value, Err: err})
+		}
+	}
+	var unknown []string
+	for name := range values {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	slices.Sort(unknown)
+	for _, name := range unknown {
+		errs = append(errs, &ParamError{Name: name, Value: values[name], Err: errParamUnknown})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// parseParamsSchema parses the schema of the parameters defined in the map
+// form, e.g.
+//
+//	params:
+//	  - ENV:
+//	      type: string
+//	      enum: [dev, prod]
+//	      default: dev
+func parseParamsSchema(value any) ([]ParamSchema, error) {
+	items, ok := value.([]any)
+	if !ok {
+		return nil, nil
+	}
+
+	var ret []ParamSchema
+	for _, item := range items {
+		m, ok := item.(map[any]any)
+		if !ok {
+			continue
+		}
+		for name, value := range m {
+			def, ok := value.(map[any]any)
+			if !ok {
+				continue
+			}
+			nameStr, err := parseKey(name)
+			if err != nil {
+				return nil, wrapError("params", name, err)
+			}
+			schema, err := buildParamSchema(nameStr, def)
+			if err != nil {
+				return nil, wrapError("params."+nameStr, value, err)
+			}
+			ret = append(ret, *schema)
+		}
+	}
+
+	return ret, nil
+}
+
+func buildParamSchema(name string, value map[any]any) (*ParamSchema, error) {
+	var def paramSchemaDef
+	md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		Result:      &def,
+	})
+	if err := md.Decode(value); err != nil {
+		return nil, err
+	}
+
+	schema := &ParamSchema{
+		Name:        name,
+		Type:        ParamType(def.Type),
+		Description: def.Description,
+		Required:    def.Required,
+		Pattern:     def.Pattern,
+		Minimum:     def.Minimum,
+		Maximum:     def.Maximum,
+		MinLength:   def.MinLength,
+		MaxLength:   def.MaxLength,
+	}
+
+	switch schema.Type {
+	case "":
+		schema.Type = ParamTypeString
+	case ParamTypeString, ParamTypeInteger, ParamTypeNumber, ParamTypeBoolean:
+	default:
+		return nil, fmt.Errorf("%w: %s", errInvalidParamType, def.Type)
+	}
+
+	if def.Default != nil {
+		schema.Default = fmt.Sprintf("%v", def.Default)
+	}
+	for _, v := range def.Enum {
+		schema.Enum = append(schema.Enum, fmt.Sprintf("%v", v))
+	}
+
+	if schema.Pattern != "" {
+		if _, err := regexp.Compile(schema.Pattern); err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidParamPattern, err)
+		}
+	}
+	if schema.Minimum != nil && schema.Maximum != nil && *schema.Minimum > *schema.Maximum {
+		return nil, fmt.Errorf("%w: minimum is greater than maximum", errInvalidParamSchema)
+	}
+	if schema.MinLength != nil && schema.MaxLength != nil && *schema.MinLength > *schema.MaxLength {
+		return nil, fmt.Errorf("%w: minLength is greater than maxLength", errInvalidParamSchema)
+	}
+
+	// The allowed values and the default value must be valid.
+	for _, v := range schema.Enum {
+		check := *schema
+		check.Enum = nil
+		if err := check.Validate(v); err != nil {
+			return nil, fmt.Errorf("%w: enum value %q: %s", errInvalidParamSchema, v, err)
+		}
+	}
+	// The default value is validated only if it is a literal.
+	if schema.Default != "" && !strings.ContainsAny(schema.Default, "$`") {
+		if err := schema.Validate(schema.Default); err != nil {
+			return nil, fmt.Errorf("%w: default value %q: %s", errInvalidParamSchema, schema.Default, err)
+		}
+	}
+
+	return schema, nil
+}
@@ -18,6 +18,12 @@ func buildParams(ctx BuildContext, spec *definition, dag *DAG) error {
 		envs       []string
 	)
 
+	schema, err := parseParamsSchema(spec.Params)
+	if err != nil {
+		return err
+	}
+	dag.ParamsSchema = schema
+
 	if err := parseParams(ctx, spec.Params, &paramPairs, &envs); err != nil {
 		return err
 	}
@@ -29,6 +35,13 @@ func buildParams(ctx BuildContext, spec *definition, dag *DAG) error {
 	}
 	dag.DefaultParams = strings.Join(paramsToJoin, " ")
 
+	declared := make(map[string]bool, len(paramPairs))
+	for _, paramPair := range paramPairs {
+		if paramPair.Name != "" {
+			declared[paramPair.Name] = true
+		}
+	}
+
 	if ctx.opts.parameters != "" {
 		// Parse the parameters from the command line and override the default parameters
 		var (
@@ -56,6 +69,18 @@ func buildParams(ctx BuildContext, spec *definition, dag *DAG) error {
 		overrideEnvirons(&envs, overrideEnvs)
 	}
 
+	if len(schema) > 0 && !ctx.opts.noEval {
+		values := make(map[string]string, len(paramPairs))
+		for _, paramPair := range paramPairs {
+			if paramPair.Name != "" {
+				values[paramPair.Name] = paramPair.Value
+			}
+		}
+		if err := validateParams(schema, declared, values); err != nil {
+			return err
+		}
+	}
+
 	for _, paramPair := range paramPairs {
 		dag.Params = append(dag.Params, paramPair.String())
 	}
@@ -197,6 +222,12 @@ func parseMapParams(ctx BuildContext, input []any) ([]paramPair, error) {
 				case string:
 					valueStr = v
 
+				case map[any]any:
+					// The parameter with the schema. See parseParamsSchema.
+					if def, ok := v["default"]; ok && def != nil {
+						valueStr = fmt.Sprintf("%v", def)
+					}
+
 				default:
 					return nil, wrapError("params", value, fmt.Errorf("%w: %T", errInvalidParamValue, v))
 
@@ -2,30 +2,145 @@ package digraph
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/robfig/cron/v3"
 )
 
+// cronParser parses the standard cron expression with the optional seconds
+// field, e.g. "*/15 * * * * *", and the descriptors, e.g. "@every 15s".
 var cronParser = cron.NewParser(
-	cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow,
+	cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
 )
 
 // buildScheduler parses the schedule values and returns a list of schedules.
-// each schedule is parsed as a cron expression.
-func buildScheduler(values []string) ([]Schedule, error) {
+// each schedule is parsed as a cron expression. The expressions without the
+// CRON_TZ= (or TZ=) prefix are in the given time zone if it's not empty.
+func buildScheduler(values []string, timezone string) ([]Schedule, error) {
 	var ret []Schedule
 
 	for _, v := range values {
-		parsed, err := cronParser.Parse(v)
+		expr := v
+		if timezone != "" && !hasTimezonePrefix(v) {
+			expr = fmt.Sprintf("CRON_TZ=%s %s", timezone, v)
+		}
+		parsed, err := cronParser.Parse(expr)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s", errInvalidSchedule, err)
 		}
+		switch p := parsed.(type) {
+		case *cron.SpecSchedule:
+			parsed = dstSchedule{spec: p}
+		case cron.ConstantDelaySchedule:
+			parsed = everySchedule{delay: p.Delay}
+		}
 		ret = append(ret, Schedule{Expression: v, Parsed: parsed})
 	}
 
 	return ret, nil
 }
 
+func hasTimezonePrefix(expr string) bool {
+	expr = strings.TrimSpace(expr)
+	return strings.HasPrefix(expr, "CRON_TZ=") || strings.HasPrefix(expr, "TZ=")
+}
+
+// dstSchedule is the cron schedule whose ticks around the daylight saving
+// time transitions are well-defined:
+//
+//   - The ticks in the gap of the spring-forward transition, e.g. 02:30 when
+//     the clock jumps from 02:00 to 03:00, run once at the end of the gap.
+//   - The ticks in the overlap of the fall-back transition, e.g. 01:30 when
+//     the clock goes back from 02:00 to 01:00, run once at the first
+//     occurrence.
+//
+// The next tick is returned in the time zone of the schedule.
+type dstSchedule struct {
+	spec *cron.SpecSchedule
+}
+
+func (s dstSchedule) Next(t time.Time) time.Time {
+	loc := s.spec.Location
+	if loc == time.Local {
+		// The schedule without the time zone follows the given time.
+		loc = t.Location()
+	}
+	for {
+		next := s.spec.Next(t)
+		if next.IsZero() {
+			return next
+		}
+		if end, ok := s.gapEnd(t, next, loc); ok {
+			return end.In(loc)
+		}
+		if !isRepeatedWallTime(next, loc) {
+			return next.In(loc)
+		}
+		t = next
+	}
+}
+
+// gapEnd returns the end of the spring-forward gap between t and next if the
+// schedule has a tick in the gap.
+func (s dstSchedule) gapEnd(t, next time.Time, loc *time.Location) (time.Time, bool) {
+	_, before := t.In(loc).Zone()
+	_, after := next.In(loc).Zone()
+	if after <= before {
+		return time.Time{}, false
+	}
+
+	// Find the transition by the binary search in seconds.
+	lo, hi := t.Unix(), next.Unix()
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if _, offset := time.Unix(mid, 0).In(loc).Zone(); offset == before {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	transition := time.Unix(hi, 0)
+
+	// The wall times in the gap are the ones in the offset before the
+	// transition.
+	spec := *s.spec
+	spec.Location = time.FixedZone("", before)
+	tick := spec.Next(transition.Add(-time.Second))
+	if tick.IsZero() || !tick.Before(transition.Add(time.Duration(after-before)*time.Second)) {
+		return time.Time{}, false
+	}
+	return transition, true
+}
+
+// isRepeatedWallTime returns true if the wall time of t already appeared
+// before the fall-back transition.
+func isRepeatedWallTime(t time.Time, loc *time.Location) bool {
+	const layout = "2006-01-02T15:04:05"
+	wall := t.In(loc).Format(layout)
+	for _, shift := range []time.Duration{30 * time.Minute, time.Hour, 2 * time.Hour} {
+		if t.Add(-shift).In(loc).Format(layout) == wall {
+			return true
+		}
+	}
+	return false
+}
+
+// everySchedule is the schedule of the @every descriptor. Unlike
+// cron.ConstantDelaySchedule, the ticks are aligned to the multiples of the
+// interval since the Unix epoch, e.g. "@every 15s" runs at :00, :15, :30 and
+// :45 of every minute, so that the next tick doesn't depend on when it's
+// computed. The interval is at least a second.
+type everySchedule struct {
+	delay time.Duration
+}
+
+func (s everySchedule) Next(t time.Time) time.Time {
+	nsec, delay := t.UnixNano(), int64(s.delay)
+	return time.Unix(0, nsec-nsec%delay+delay).In(t.Location())
+}
+
 // parseScheduleMap parses the schedule map and populates the starts, stops,
 // and restarts slices. Each key in the map must be either "start", "stop", or
 // "restart". The value can be Case 1 or Case 2.
@@ -105,3 +220,96 @@ const (
 	scheduleKeyStop    scheduleKey = "stop"
 	scheduleKeyRestart scheduleKey = "restart"
 )
+
+// ScheduleTicks returns the ticks of the start schedules of the DAG after
+// the given time and before the end in chronological order.
+func (d *DAG) ScheduleTicks(after, end time.Time) []time.Time {
+	seen := make(map[time.Time]bool)
+	var ticks []time.Time
+	for _, schedule := range d.Schedule {
+		for t := schedule.Parsed.Next(after); !t.IsZero() && t.Before(end); t = schedule.Parsed.Next(t) {
+			if !seen[t] {
+				seen[t] = true
+				ticks = append(ticks, t)
+			}
+		}
+	}
+	sort.Slice(ticks, func(i, j int) bool {
+		return ticks[i].Before(ticks[j])
+	})
+	return ticks
+}
+
+// NextRun returns the earliest next tick of the start schedules after now
+// in the time zone of the schedule, or the zero time if there is none. The
+// schedules without the time zone are in the time zone of now.
+func (d *DAG) NextRun(now time.Time) time.Time {
+	var next time.Time
+	for _, schedule := range d.Schedule {
+		t := schedule.Parsed.Next(now)
+		if !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return next
+}
+
+// ScheduleLocation returns the time zone of the schedules of the DAG, or the
+// given default if the DAG has no time zone.
+func (d *DAG) ScheduleLocation(defaultLocation *time.Location) *time.Location {
+	if d.Timezone != "" {
+		if loc, err := time.LoadLocation(d.Timezone); err == nil {
+			return loc
+		}
+	}
+	return defaultLocation
+}
+
+// CatchupPolicy is the policy to run the schedule ticks missed while the
+// scheduler was down.
+type CatchupPolicy string
+
+const (
+	// CatchupNone skips the missed ticks. It's the default.
+	CatchupNone CatchupPolicy = "none"
+	// CatchupLatest runs only the latest missed tick.
+	CatchupLatest CatchupPolicy = "latest"
+	// CatchupAll runs all of the missed ticks, or the latest ones up to the
+	// limit.
+	CatchupAll CatchupPolicy = "all"
+)
+
+// Catchup is the configuration to run the missed schedule ticks.
+type Catchup struct {
+	// Policy is the catchup policy.
+	Policy CatchupPolicy `json:"Policy,omitempty"`
+	// Limit is the maximum number of the latest missed ticks to run with
+	// the all policy. 0 means no limit.
+	Limit int `json:"Limit,omitempty"`
+}
+
+// Enabled returns true if the missed ticks are run.
+func (c Catchup) Enabled() bool {
+	return c.Policy == CatchupLatest || c.Policy == CatchupAll
+}
+
+// Select returns the ticks to run out of the missed ticks in order.
+func (c Catchup) Select(missed []time.Time) []time.Time {
+	switch c.Policy {
+	case CatchupLatest:
+		if len(missed) > 0 {
+			return missed[len(missed)-1:]
+		}
+
+	case CatchupAll:
+		if c.Limit > 0 && len(missed) > c.Limit {
+			return missed[len(missed)-c.Limit:]
+		}
+		return missed
+
+	case CatchupNone:
+		// do nothing
+
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+)
+
+var (
+	// ErrNotWaitingForApproval is returned when the step to approve is not
+	// waiting for approval.
+	ErrNotWaitingForApproval = errors.New("step is not waiting for approval")
+	// ErrApprovalRejected is the error of the step rejected by an approver.
+	ErrApprovalRejected = errors.New("approval rejected")
+	// ErrApprovalTimeout is the error of the step rejected on timeout.
+	ErrApprovalTimeout = errors.New("approval timed out")
+)
+
+// ApprovalDecision is the decision on a step waiting for approval.
+type ApprovalDecision struct {
+	// Approved is true if the step is approved, false if rejected.
+	Approved bool
+	// User is the user who made the decision. It's empty when the decision
+	// is made on timeout, or the user is unknown.
+	User string
+	// Comment is the comment of the user.
+	Comment string
+	// At is the time when the decision was made.
+	At time.Time
+	// Timeout is true if the decision is made on timeout.
+	Timeout bool
+}
+
+// Approve sends the decision to the step waiting for approval.
+func (sc *Scheduler) Approve(graph *ExecutionGraph, step string, decision ApprovalDecision) error {
+	node, err := graph.findStep(step)
+	if err != nil {
+		return err
+	}
+	if decision.At.IsZero() {
+		decision.At = time.Now()
+	}
+	return node.decide(decision)
+}
+
+// waitForApproval waits until the step is approved or rejected, or the
+// timeout is reached. The node stays in the waiting status without a
+// process while waiting.
+func (sc *Scheduler) waitForApproval(ctx context.Context, node *Node, done chan *Node) {
+	approval := node.data.Step.Approval
+	decisions := node.startWaiting()
+	if done != nil {
+		done <- node
+	}
+	logger.Info(ctx, "Waiting for approval", "step", node.data.Step.Name, "message", approval.Message)
+
+	var deadline <-chan time.Time
+	if approval.Timeout > 0 {
+		// The deadline is counted from the start of the wait so that it's
+		// kept when the run is restarted.
+		timer := time.NewTimer(time.Until(node.State().StartedAt.Add(approval.Timeout)))
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	var decision ApprovalDecision
+	select {
+	case decision = <-decisions:
+	case <-deadline:
+		decision = ApprovalDecision{
+			Approved: approval.OnTimeout == digraph.ApprovalOnTimeoutApprove,
+			Comment:  fmt.Sprintf("%s on timeout", approval.OnTimeout),
+			At:       time.Now(),
+			Timeout:  true,
+		}
+	case <-sc.canceledCh:
+		node.SetStatus(NodeStatusCancel)
+		return
+	case <-ctx.Done():
+		node.SetStatus(NodeStatusCancel)
+		sc.setLastError(ctx.Err())
+		return
+	}
+
+	node.setApproval(decision)
+	if decision.Approved {
+		logger.Info(ctx, "Step approved", "step", node.data.Step.Name, "user", decision.User)
+		node.SetStatus(NodeStatusSuccess)
+		return
+	}
+
+	var err error
+	switch {
+	case decision.Timeout:
+		err = ErrApprovalTimeout
+	case decision.User != "":
+		err = fmt.Errorf("%w by %s", ErrApprovalRejected, decision.User)
+	default:
+		err = ErrApprovalRejected
+	}
+	if decision.Comment != "" {
+		err = fmt.Errorf("%w: %s", err, decision.Comment)
+	}
+	logger.Info(ctx, "Step rejected", "step", node.data.Step.Name, "user", decision.User, "err", err)
+	node.MarkError(err)
+	sc.setLastError(err)
+}
+
+// startWaiting sets the node to the waiting status and returns the channel
+// to receive the decision.
+func (n *Node) startWaiting() <-chan ApprovalDecision {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.approvalCh = make(chan ApprovalDecision, 1)
+	n.data.State.Status = NodeStatusWaiting
+	if n.data.State.StartedAt.IsZero() {
+		n.data.State.StartedAt = time.Now()
+	}
+	return n.approvalCh
+}
+
+// decide sends the decision to the node waiting for approval.
+func (n *Node) decide(decision ApprovalDecision) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.data.State.Status != NodeStatusWaiting || n.approvalCh == nil {
+		return fmt.Errorf("%w: %s", ErrNotWaitingForApproval, n.data.Step.Name)
+	}
+	select {
+	case n.approvalCh <- decision:
+		return nil
+	default:
+		// The decision has already been made.
+		return fmt.Errorf("%w: %s", ErrNotWaitingForApproval, n.data.Step.Name)
+	}
+}
+
+func (n *Node) setApproval(decision ApprovalDecision) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.data.State.Approval = &decision
+}
+
+// resumeWaiting resets the state of the node waiting for approval so that
+// it waits again with the original start time on retry.
+func (n *Node) resumeWaiting() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.data.State = NodeState{StartedAt: n.data.State.StartedAt}
+}
@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/fileutil"
+	"github.com/dagu-org/dagu/internal/logger"
+)
+
+var (
+	ErrArtifactNotFound = errors.New("artifact not found")
+)
+
+// ArtifactDir returns the directory to store the artifacts of the step in
+// the artifact directory of the run.
+func ArtifactDir(artifactDir, step string) string {
+	return filepath.Join(artifactDir, fileutil.SafeName(step))
+}
+
+// workingDir returns the working directory of the step.
+func (n *Node) workingDir() string {
+	if n.data.Step.Dir != "" {
+		return n.data.Step.Dir
+	}
+	return fileutil.MustGetwd()
+}
+
+// stageArtifacts copies the artifacts of the other steps referenced by the
+// step into its working directory.
+func (n *Node) stageArtifacts(ctx context.Context, artifactDir string) error {
+	if len(n.data.Step.Artifacts) == 0 {
+		return nil
+	}
+
+	dir := n.workingDir()
+	for _, ref := range n.data.Step.Artifacts {
+		src := filepath.Join(ArtifactDir(artifactDir, ref.From), ref.Path)
+		dst := filepath.Join(dir, ref.Path)
+		if _, err := os.Stat(src); err != nil {
+			return fmt.Errorf("%w: %s of step %q", ErrArtifactNotFound, ref.Path, ref.From)
+		}
+		if _, err := fileutil.Copy(src, dst); err != nil {
+			return fmt.Errorf("failed to stage artifact %s of step %q: %w", ref.Path, ref.From, err)
+		}
+		logger.Info(ctx, "Artifact staged", "step", n.data.Step.Name, "from", ref.From, "path", ref.Path, "dir", dir)
+	}
+
+	return nil
+}
+
+// collectArtifacts copies the files produced by the step into the artifact
+// directory and records their paths. The artifacts of the previous
+// execution of the step are replaced.
+func (n *Node) collectArtifacts(ctx context.Context, artifactDir string) error {
+	if len(n.data.Step.Produces) == 0 {
+		return nil
+	}
+
+	stepDir := ArtifactDir(artifactDir, n.data.Step.Name)
+	if err := os.RemoveAll(stepDir); err != nil {
+		return fmt.Errorf("failed to clean artifact directory: %w", err)
+	}
+
+	stepContext := digraph.GetStepContext(ctx)
+	dir := n.workingDir()
+
+	var artifacts []string
+	for _, p := range n.data.Step.Produces {
+		pattern, err := stepContext.EvalString(p)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate produces %q: %w", p, err)
+		}
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(dir, pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return err
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("%w: %s", ErrArtifactNotFound, pattern)
+		}
+		for _, match := range matches {
+			// Keep the path relative to the working directory if possible.
+			rel, err := filepath.Rel(dir, match)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				rel = filepath.Base(match)
+			}
+			copied, err := fileutil.Copy(match, filepath.Join(stepDir, rel))
+			if err != nil {
+				return fmt.Errorf("failed to collect artifact %s: %w", match, err)
+			}
+			if !fileutil.IsDir(match) {
+				artifacts = append(artifacts, filepath.ToSlash(rel))
+				continue
+			}
+			for _, c := range copied {
+				artifacts = append(artifacts, filepath.ToSlash(filepath.Join(rel, c)))
+			}
+		}
+	}
+
+	slices.Sort(artifacts)
+	artifacts = slices.Compact(artifacts)
+
+	logger.Info(ctx, "Artifacts collected", "step", n.data.Step.Name, "count", len(artifacts), "dir", stepDir)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.data.State.Artifacts = artifacts
+	return nil
+}
@@ -0,0 +1,481 @@
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+)
+
+// dispatcher runs the nodes of the execution graph as they become ready.
+// Instead of polling the graph, it keeps the number of the unfinished
+// upstream nodes of each node, and the node is pushed into the ready queue
+// when the number reaches zero. The goroutine running a node notifies the
+// dispatcher when it returns, and the number of the running steps is limited
+// by the semaphore of maxActiveRuns. The node to retry is held by the
+// dispatcher until the retry interval passes, and the next node is held until
+// the delay of the DAG passes after the last one is started.
+type dispatcher struct {
+	sc    *Scheduler
+	graph *ExecutionGraph
+	done  chan *Node
+
+	// indegree is the number of the unfinished upstream nodes of the node.
+	indegree map[int]int
+	// finished is the set of the nodes whose downstream nodes are notified.
+	finished map[int]bool
+	// parents is the foreach node of the child node.
+	parents map[int]*Node
+	ready   readyQueue
+	running int
+	// retrying is the nodes to run again, e.g. after the retry interval.
+	retrying []*Node
+	// startAt is the earliest time to start the next node by the delay.
+	startAt time.Time
+	sem     chan struct{}
+
+	mu       sync.Mutex
+	returned []*Node
+}
+
+func newDispatcher(sc *Scheduler, graph *ExecutionGraph, done chan *Node) *dispatcher {
+	d := &dispatcher{
+		sc:       sc,
+		graph:    graph,
+		done:     done,
+		indegree: make(map[int]int),
+		finished: make(map[int]bool),
+		parents:  make(map[int]*Node),
+	}
+	if sc.maxActiveRuns > 0 {
+		d.sem = make(chan struct{}, sc.maxActiveRuns)
+	}
+	return d
+}
+
+// run runs the nodes until all of them are finished or the scheduler is
+// canceled. It doesn't wait for the running nodes after the cancellation.
+func (d *dispatcher) run(ctx context.Context, wg *sync.WaitGroup) {
+	d.init(ctx)
+
+	ctxDone := ctx.Done()
+	for {
+		for _, node := range d.takeReturned() {
+			d.running--
+			if node.State().Status == NodeStatusNone {
+				// The node is run again to retry, or to run the command
+				// after waiting for the runs of other DAGs.
+				d.retrying = append(d.retrying, node)
+				continue
+			}
+			d.finish(ctx, node)
+		}
+
+		if d.sc.isCanceled() {
+			d.cancelRetrying(ctx, nil)
+			return
+		}
+		if err := ctx.Err(); err != nil {
+			// The DAG timed out while the nodes wait for the retry.
+			d.cancelRetrying(ctx, err)
+			ctxDone = nil
+		}
+
+		next := d.promoteRetrying(time.Now())
+		d.dispatch(ctx, wg)
+		if d.ready.Len() > 0 && d.startAt.After(time.Now()) &&
+			(next.IsZero() || d.startAt.Before(next)) {
+			next = d.startAt
+		}
+
+		if d.running == 0 && d.ready.Len() == 0 && len(d.retrying) == 0 {
+			return
+		}
+
+		var timer *time.Timer
+		var timerCh <-chan time.Time
+		if !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			timerCh = timer.C
+		}
+
+		select {
+		case <-d.sc.wakeCh:
+		case <-d.sc.canceledCh:
+		case <-timerCh:
+		case <-ctxDone:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// promoteRetrying pushes the nodes whose retry interval passed into the ready
+// queue, and returns the earliest time to retry the rest of them.
+func (d *dispatcher) promoteRetrying(now time.Time) time.Time {
+	var (
+		next    time.Time
+		waiting []*Node
+	)
+	for _, node := range d.retrying {
+		retryAt := node.getRetryAt()
+		if !retryAt.After(now) {
+			heap.Push(&d.ready, node)
+			continue
+		}
+		if next.IsZero() || retryAt.Before(next) {
+			next = retryAt
+		}
+		waiting = append(waiting, node)
+	}
+	d.retrying = waiting
+	return next
+}
+
+// cancelRetrying cancels the nodes waiting for the retry interval.
+func (d *dispatcher) cancelRetrying(ctx context.Context, err error) {
+	retrying := d.retrying
+	d.retrying = nil
+	for _, node := range retrying {
+		node.setRetryAt(time.Time{})
+		node.SetStatus(NodeStatusCancel)
+		if err != nil {
+			d.sc.setLastError(err)
+			d.finish(ctx, node)
+		}
+	}
+}
+
+// init counts the unfinished upstream nodes and pushes the nodes that are
+// ready to run. The nodes that are already finished, e.g. on retry, are not
+// run again.
+func (d *dispatcher) init(ctx context.Context) {
+	nodes := d.graph.Nodes()
+
+	var foreachNodes []*Node
+	for _, node := range nodes {
+		switch node.State().Status {
+		case NodeStatusNone:
+			// to run
+
+		case NodeStatusRunning:
+			// The foreach node recovered with its children.
+			if node.data.Step.Foreach != "" {
+				foreachNodes = append(foreachNodes, node)
+			}
+
+		default:
+			d.finished[node.id] = true
+
+		}
+		if node.data.Step.Foreach != "" {
+			for _, child := range d.graph.foreachChildren(node) {
+				d.parents[child.id] = node
+			}
+		}
+	}
+
+	var roots []*Node
+	for _, node := range nodes {
+		if node.State().Status != NodeStatusNone {
+			continue
+		}
+		for _, dep := range d.graph.dependencies(node.id) {
+			if !d.finished[dep] {
+				d.indegree[node.id]++
+			}
+		}
+		if d.indegree[node.id] == 0 {
+			roots = append(roots, node)
+		}
+	}
+
+	for _, node := range roots {
+		d.evaluate(ctx, node)
+	}
+
+	for _, node := range foreachNodes {
+		d.finishForeach(ctx, node)
+	}
+}
+
+// dispatch starts the ready nodes while the semaphore and the delay allow.
+func (d *dispatcher) dispatch(ctx context.Context, wg *sync.WaitGroup) {
+	for d.ready.Len() > 0 {
+		if d.sc.isPaused() || d.sc.isCanceled() {
+			// Do not launch new steps while paused.
+			return
+		}
+		if d.startAt.After(time.Now()) {
+			return
+		}
+
+		node := d.ready[0]
+		if node.State().Status != NodeStatusNone {
+			// The node is finished without running, e.g. canceled by a
+			// failed matrix instance.
+			heap.Pop(&d.ready)
+			d.finish(ctx, node)
+			continue
+		}
+
+		slot := d.needsSlot(node)
+		if slot && !d.acquire() {
+			return
+		}
+		heap.Pop(&d.ready)
+
+		if !d.start(ctx, wg, node, slot) {
+			if slot {
+				d.release()
+			}
+			continue
+		}
+		if d.sc.delay > 0 {
+			d.startAt = time.Now().Add(d.sc.delay)
+		}
+	}
+}
+
+// start starts the node and returns true if a goroutine is launched to run
+// the node. Otherwise, the node is finished or expanded synchronously.
+func (d *dispatcher) start(ctx context.Context, wg *sync.WaitGroup, node *Node, slot bool) bool {
+	sc := d.sc
+
+	// The conditions are checked only once before waiting for the runs of
+	// other DAGs.
+	if !node.hasWaited() && !d.checkConditions(ctx, node) {
+		return false
+	}
+
+	if node.data.Step.Foreach != "" {
+		if err := sc.expandForeach(ctx, d.graph, node); err != nil {
+			logger.Error(ctx, "Failed to expand foreach step", "step", node.data.Step.Name, "error", err)
+			node.MarkError(err)
+			sc.setLastError(err)
+			d.finish(ctx, node)
+			return false
+		}
+		// The children inherit the finished upstream nodes of the parent.
+		for _, child := range d.graph.foreachChildren(node) {
+			d.parents[child.id] = node
+			heap.Push(&d.ready, child)
+		}
+		d.finishForeach(ctx, node)
+		return false
+	}
+
+	wg.Add(1)
+	d.running++
+
+	if !node.getRetryAt().IsZero() {
+		node.setRetryAt(time.Time{})
+		node.SetRetriedAt(time.Now())
+	}
+
+	logger.Info(ctx, "Step execution started", "step", node.data.Step.Name)
+	node.SetStatus(NodeStatusRunning)
+	go func(ctx context.Context, node *Node) {
+		defer func() {
+			if panicObj := recover(); panicObj != nil {
+				stack := string(debug.Stack())
+				err := fmt.Errorf("panic recovered: %v\n%s", panicObj, stack)
+				logger.Error(ctx, "Panic occurred", "error", err, "step", node.data.Step.Name, "stack", stack)
+				node.MarkError(err)
+				sc.setLastError(err)
+			}
+			if slot {
+				d.release()
+			}
+			d.notify(node)
+		}()
+
+		defer func() {
+			node.Finish()
+			wg.Done()
+		}()
+
+		sc.runNode(ctx, d.graph, node, d.done)
+	}(ctx, node)
+
+	return true
+}
+
+// checkConditions checks the preconditions and the if expression of the node,
+// and returns true if the node should run. Otherwise, the node is finished.
+func (d *dispatcher) checkConditions(ctx context.Context, node *Node) bool {
+	sc := d.sc
+
+	// Check preconditions
+	if len(node.data.Step.Preconditions) > 0 {
+		logger.Infof(ctx, "Checking pre conditions for \"%s\"", node.data.Step.Name)
+		if err := digraph.EvalConditions(ctx, node.data.Step.Preconditions); err != nil {
+			logger.Infof(ctx, "Pre conditions failed for \"%s\"", node.data.Step.Name)
+			node.SetStatus(NodeStatusSkipped)
+			node.setError(err)
+			d.finish(ctx, node)
+			return false
+		}
+	}
+
+	// Check the if expression
+	if node.data.Step.If != "" {
+		ok, err := sc.evalIf(ctx, d.graph, node)
+		if err != nil {
+			logger.Error(ctx, "Failed to evaluate if expression", "step", node.data.Step.Name, "error", err)
+			node.MarkError(err)
+			sc.setLastError(err)
+			d.finish(ctx, node)
+			return false
+		}
+		if !ok {
+			logger.Infof(ctx, "If expression is false for \"%s\"", node.data.Step.Name)
+			node.SetStatus(NodeStatusSkipped)
+			node.setError(fmt.Errorf("%w: %s", digraph.ErrConditionNotMet, node.data.Step.If))
+			d.finish(ctx, node)
+			return false
+		}
+	}
+
+	return true
+}
+
+// finish notifies the downstream nodes that the node is finished, and
+// pushes the nodes that become ready. The nodes that can no longer run are
+// finished in turn.
+func (d *dispatcher) finish(ctx context.Context, node *Node) {
+	stack := []*Node{node}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if d.finished[node.id] {
+			continue
+		}
+		d.finished[node.id] = true
+
+		if parent := d.parents[node.id]; parent != nil && !d.finished[parent.id] &&
+			d.sc.finishForeach(ctx, d.graph, parent) {
+			if d.done != nil {
+				d.done <- parent
+			}
+			stack = append(stack, parent)
+		}
+
+		succeeded := node.State().Status == NodeStatusSuccess
+		for _, id := range d.graph.dependents(node.id) {
+			if d.finished[id] {
+				continue
+			}
+			d.indegree[id]--
+			if d.indegree[id] > 0 && succeeded {
+				continue
+			}
+			// The failure or skip of the upstream node is propagated
+			// without waiting for the other upstream nodes.
+			if next := d.graph.node(id); d.evaluate(ctx, next) {
+				stack = append(stack, next)
+			}
+		}
+	}
+}
+
+// evaluate pushes the node into the ready queue if it's ready to run. It
+// returns true if the node is finished without running, e.g. canceled by
+// the failure of the upstream node.
+func (d *dispatcher) evaluate(ctx context.Context, node *Node) bool {
+	if node.State().Status == NodeStatusNone {
+		// The upstream node may have finished before it's notified, so the
+		// node is pushed only when all of them are notified.
+		if isReady(ctx, d.graph, node) && d.indegree[node.id] == 0 {
+			heap.Push(&d.ready, node)
+		}
+	}
+	switch node.State().Status {
+	case NodeStatusNone, NodeStatusRunning, NodeStatusWaiting:
+		return false
+	default:
+		return true
+	}
+}
+
+// finishForeach finishes the foreach node when all of the children are
+// finished.
+func (d *dispatcher) finishForeach(ctx context.Context, node *Node) {
+	if !d.sc.finishForeach(ctx, d.graph, node) {
+		return
+	}
+	if d.done != nil {
+		d.done <- node
+	}
+	d.finish(ctx, node)
+}
+
+// needsSlot returns true if the node occupies a slot of maxActiveRuns while
+// running. The steps waiting for approval or for the runs of other DAGs
+// don't run a command while waiting.
+func (d *dispatcher) needsSlot(node *Node) bool {
+	if d.sc.dry {
+		return node.data.Step.Foreach == ""
+	}
+	return node.data.Step.Foreach == "" && node.data.Step.Approval == nil && !node.shouldWait()
+}
+
+func (d *dispatcher) acquire() bool {
+	if d.sem == nil {
+		return true
+	}
+	select {
+	case d.sem <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (d *dispatcher) release() {
+	if d.sem != nil {
+		<-d.sem
+	}
+}
+
+// notify is called by the goroutine running the node when it returns.
+func (d *dispatcher) notify(node *Node) {
+	d.mu.Lock()
+	d.returned = append(d.returned, node)
+	d.mu.Unlock()
+	d.sc.wake()
+}
+
+func (d *dispatcher) takeReturned() []*Node {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	ret := d.returned
+	d.returned = nil
+	return ret
+}
+
+// readyQueue is the queue of the ready nodes ordered by the node ID so that
+// the steps are started in the order of the definition.
+type readyQueue []*Node
+
+func (q readyQueue) Len() int           { return len(q) }
+func (q readyQueue) Less(i, j int) bool { return q[i].id < q[j].id }
+func (q readyQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *readyQueue) Push(x any) {
+	*q = append(*q, x.(*Node))
+}
+
+func (q *readyQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+)
+
+// expandForeach evaluates the items of the foreach node and adds a child node
+// for each item to the graph. The foreach node itself does not run a command;
+// it stays running until all of the children are finished.
+func (sc *Scheduler) expandForeach(ctx context.Context, graph *ExecutionGraph, node *Node) error {
+	ctx = sc.setupContext(ctx, graph, node)
+
+	value, err := digraph.GetStepContext(ctx).EvalString(node.data.Step.Foreach)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate foreach items: %w", err)
+	}
+	items := parseForeachItems(value)
+
+	node.mu.Lock()
+	node.data.State.Status = NodeStatusRunning
+	node.data.State.StartedAt = time.Now()
+	node.mu.Unlock()
+
+	children := graph.addForeachChildren(node, items)
+	logger.Info(ctx, "Foreach step expanded", "step", node.data.Step.Name, "items", len(children))
+
+	return nil
+}
+
+// finishForeach finishes the foreach node when all of the children are
+// finished. It returns true if the node is finished.
+// The outputs of the children are collected into the output variable of the
+// foreach node as a JSON array in the order of the items.
+func (sc *Scheduler) finishForeach(ctx context.Context, graph *ExecutionGraph, node *Node) bool {
+	children := graph.foreachChildren(node)
+
+	status := NodeStatusSuccess
+	var failed []string
+	for _, child := range children {
+		switch child.State().Status {
+		case NodeStatusNone, NodeStatusRunning, NodeStatusWaiting:
+			return false
+
+		case NodeStatusError, NodeStatusTimeout:
+			status = NodeStatusError
+			failed = append(failed, child.data.Step.Name)
+
+		case NodeStatusCancel:
+			if status != NodeStatusError {
+				status = NodeStatusCancel
+			}
+
+		case NodeStatusSuccess, NodeStatusSkipped:
+			// do nothing
+
+		}
+	}
+
+	node.mu.Lock()
+	if output := node.data.Step.Output; output != "" {
+		values := make([]string, 0, len(children))
+		for _, child := range children {
+			v, _ := child.getVariable(output)
+			values = append(values, v.Value())
+		}
+		js, _ := json.Marshal(values)
+		node.setVariable(output, string(js))
+	}
+	if len(failed) > 0 {
+		node.data.State.Error = fmt.Errorf("%w: %s", errForeachItemFailed, strings.Join(failed, ", "))
+	}
+	node.data.State.Status = status
+	node.data.State.FinishedAt = time.Now()
+	node.data.State.DoneCount++
+	node.mu.Unlock()
+
+	logger.Info(ctx, "Foreach step finished", "step", node.data.Step.Name, "status", status)
+
+	return true
+}
+
+// parseForeachItems parses the items of a foreach step.
+// The value is expected to be a JSON array. Otherwise, each non-empty line of
+// the value is treated as an item, e.g. the output of `ls`.
+func parseForeachItems(value string) []string {
+	value = strings.TrimSpace(value)
+
+	var items []any
+	if err := json.Unmarshal([]byte(value), &items); err == nil {
+		ret := make([]string, 0, len(items))
+		for _, item := range items {
+			if s, ok := item.(string); ok {
+				ret = append(ret, s)
+				continue
+			}
+			js, _ := json.Marshal(item)
+			ret = append(ret, string(js))
+		}
+		return ret
+	}
+
+	var ret []string
+	for _, line := range strings.Split(value, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			ret = append(ret, line)
+		}
+	}
+	return ret
+}
+
+// newForeachStep creates a child step of the foreach step for the item.
+func newForeachStep(parent digraph.Step, index int, item string) digraph.Step {
+	step := parent
+	step.Name = fmt.Sprintf("%s[%d]", parent.Name, index)
+	step.Args = slices.Clone(parent.Args)
+	step.Preconditions = nil
+	step.OutputVariables = nil
+	step.Foreach = ""
+	step.ForeachItem = &digraph.ForeachItem{
+		Parent: parent.Name,
+		Index:  index,
+		Value:  item,
+	}
+	return step
+}
+
+var errForeachItemFailed = fmt.Errorf("foreach item failed")
@@ -19,16 +19,18 @@ type ExecutionGraph struct {
 	nodes      []*Node
 	from       map[int][]int
 	to         map[int][]int
+	children   map[int][]int
 	mu         sync.RWMutex
 }
 
 // NewExecutionGraph creates a new execution graph with the given steps.
 func NewExecutionGraph(steps ...digraph.Step) (*ExecutionGraph, error) {
 	graph := &ExecutionGraph{
-		dict:  make(map[int]*Node),
-		from:  make(map[int][]int),
-		to:    make(map[int][]int),
-		nodes: []*Node{},
+		dict:     make(map[int]*Node),
+		from:     make(map[int][]int),
+		to:       make(map[int][]int),
+		children: make(map[int][]int),
+		nodes:    []*Node{},
 	}
 	for _, step := range steps {
 		node := &Node{data: NodeData{Step: step}}
@@ -45,11 +47,48 @@ func NewExecutionGraph(steps ...digraph.Step) (*ExecutionGraph, error) {
 // CreateRetryExecutionGraph creates a new execution graph for retry with
 // given nodes.
 func CreateRetryExecutionGraph(ctx context.Context, nodes ...*Node) (*ExecutionGraph, error) {
+	graph, err := newRetryExecutionGraph(nodes...)
+	if err != nil {
+		return nil, err
+	}
+	if err := graph.setupRetry(ctx); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// CreateStepRetryExecutionGraph creates a new execution graph to retry from
+// the given step. The step and all of its descendants are run again even if
+// they succeeded, while the other steps keep their states and outputs. If
+// onlyStep is false, the failed steps are also retried in the same way as
+// CreateRetryExecutionGraph.
+func CreateStepRetryExecutionGraph(
+	ctx context.Context, step string, onlyStep bool, nodes ...*Node,
+) (*ExecutionGraph, error) {
+	graph, err := newRetryExecutionGraph(nodes...)
+	if err != nil {
+		return nil, err
+	}
+	node, err := graph.findStep(step)
+	if err != nil {
+		return nil, err
+	}
+	if !onlyStep {
+		if err := graph.setupRetry(ctx); err != nil {
+			return nil, err
+		}
+	}
+	graph.setupStepRetry(ctx, node)
+	return graph, nil
+}
+
+func newRetryExecutionGraph(nodes ...*Node) (*ExecutionGraph, error) {
 	graph := &ExecutionGraph{
-		dict:  make(map[int]*Node),
-		from:  make(map[int][]int),
-		to:    make(map[int][]int),
-		nodes: []*Node{},
+		dict:     make(map[int]*Node),
+		from:     make(map[int][]int),
+		to:       make(map[int][]int),
+		children: make(map[int][]int),
+		nodes:    []*Node{},
 	}
 	for _, node := range nodes {
 		node.Init()
@@ -59,9 +98,6 @@ func CreateRetryExecutionGraph(ctx context.Context, nodes ...*Node) (*ExecutionG
 	if err := graph.setup(); err != nil {
 		return nil, err
 	}
-	if err := graph.setupRetry(ctx); err != nil {
-		return nil, err
-	}
 	return graph, nil
 }
 
@@ -96,8 +132,8 @@ func (g *ExecutionGraph) StartAt() time.Time {
 func (g *ExecutionGraph) IsRunning() bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
-	for _, node := range g.Nodes() {
-		if node.State().Status == NodeStatusRunning {
+	for _, node := range g.nodes {
+		if status := node.State().Status; status == NodeStatusRunning || status == NodeStatusWaiting {
 			return true
 		}
 	}
@@ -124,6 +160,8 @@ func (g *ExecutionGraph) Start() {
 
 // Nodes returns the nodes of the execution graph.
 func (g *ExecutionGraph) Nodes() []*Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	return g.nodes
 }
 
@@ -142,9 +180,95 @@ func (g *ExecutionGraph) NodeData() []NodeData {
 }
 
 func (g *ExecutionGraph) node(id int) *Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	return g.dict[id]
 }
 
+// dependencies returns the IDs of the nodes that the node depends on.
+func (g *ExecutionGraph) dependencies(id int) []int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.to[id]
+}
+
+// dependents returns the IDs of the nodes that depend on the node.
+func (g *ExecutionGraph) dependents(id int) []int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.from[id]
+}
+
+// addForeachChildren adds a child node for each item of the foreach node.
+// The children inherit the dependencies of the parent so that they can read
+// the outputs of the upstream steps.
+func (g *ExecutionGraph) addForeachChildren(parent *Node, items []string) []*Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var children []*Node
+	for i, item := range items {
+		child := &Node{data: NodeData{Step: newForeachStep(parent.data.Step, i, item)}}
+		child.Init()
+		g.dict[child.id] = child
+		g.nodes = append(g.nodes, child)
+		for _, dep := range g.to[parent.id] {
+			g.from[dep] = append(g.from[dep], child.id)
+			g.to[child.id] = append(g.to[child.id], dep)
+		}
+		g.children[parent.id] = append(g.children[parent.id], child.id)
+		children = append(children, child)
+	}
+	return children
+}
+
+// foreachChildren returns the child nodes of the foreach node.
+func (g *ExecutionGraph) foreachChildren(parent *Node) []*Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var children []*Node
+	for _, id := range g.children[parent.id] {
+		children = append(children, g.dict[id])
+	}
+	return children
+}
+
+// removeForeachChildren removes the child nodes of the foreach node so that
+// the node is expanded again when it is retried.
+func (g *ExecutionGraph) removeForeachChildren(parent *Node) {
+	removed := make(map[int]bool)
+	for _, id := range g.children[parent.id] {
+		removed[id] = true
+	}
+	if len(removed) == 0 {
+		return
+	}
+	delete(g.children, parent.id)
+
+	var nodes []*Node
+	for _, node := range g.nodes {
+		if removed[node.id] {
+			delete(g.dict, node.id)
+			delete(g.to, node.id)
+			delete(g.from, node.id)
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	g.nodes = nodes
+
+	for id, tos := range g.from {
+		var ret []int
+		for _, to := range tos {
+			if !removed[to] {
+				ret = append(ret, to)
+			}
+		}
+		g.from[id] = ret
+	}
+}
+
 func (g *ExecutionGraph) setupRetry(ctx context.Context) error {
 	dict := map[int]NodeStatus{}
 	retry := map[int]bool{}
@@ -162,10 +286,14 @@ func (g *ExecutionGraph) setupRetry(ctx context.Context) error {
 		var next []int
 		for _, u := range frontier {
 			if retry[u] || dict[u] == NodeStatusError ||
-				dict[u] == NodeStatusCancel {
+				dict[u] == NodeStatusCancel || dict[u] == NodeStatusTimeout {
 				logger.Info(ctx, "clear node state", "step", g.dict[u].data.Step.Name)
 				g.dict[u].ClearState()
 				retry[u] = true
+			} else if dict[u] == NodeStatusWaiting {
+				logger.Info(ctx, "resume waiting for approval", "step", g.dict[u].data.Step.Name)
+				g.dict[u].resumeWaiting()
+				retry[u] = true
 			}
 			for _, v := range g.from[u] {
 				if retry[u] {
@@ -176,9 +304,39 @@ func (g *ExecutionGraph) setupRetry(ctx context.Context) error {
 		}
 		frontier = next
 	}
+	for _, node := range g.nodes {
+		if node.data.Step.Foreach != "" && retry[node.id] {
+			g.removeForeachChildren(node)
+		}
+	}
 	return nil
 }
 
+// setupStepRetry clears the states of the node and all of its descendants
+// to run them again.
+func (g *ExecutionGraph) setupStepRetry(ctx context.Context, node *Node) {
+	retry := map[int]bool{}
+	frontier := []int{node.id}
+	for len(frontier) > 0 {
+		var next []int
+		for _, u := range frontier {
+			if retry[u] {
+				continue
+			}
+			logger.Info(ctx, "clear node state", "step", g.dict[u].data.Step.Name)
+			g.dict[u].ClearState()
+			retry[u] = true
+			next = append(next, g.from[u]...)
+		}
+		frontier = next
+	}
+	for _, node := range g.nodes {
+		if node.data.Step.Foreach != "" && retry[node.id] {
+			g.removeForeachChildren(node)
+		}
+	}
+}
+
 func (g *ExecutionGraph) setup() error {
 	for _, node := range g.nodes {
 		for _, dep := range node.data.Step.Depends {
@@ -188,6 +346,13 @@ func (g *ExecutionGraph) setup() error {
 			}
 			g.addEdge(depStep, node)
 		}
+		if item := node.data.Step.ForeachItem; item != nil {
+			parent, err := g.findStep(item.Parent)
+			if err != nil {
+				return err
+			}
+			g.children[parent.id] = append(g.children[parent.id], node.id)
+		}
 	}
 
 	if g.hasCycle() {
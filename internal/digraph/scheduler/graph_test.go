@@ -2,7 +2,9 @@ package scheduler_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
@@ -103,3 +105,137 @@ func TestRetryExecution(t *testing.T) {
 	require.Equal(t, scheduler.NodeStatusNone, nodes[6].State().Status)
 	require.Equal(t, scheduler.NodeStatusSkipped, nodes[7].State().Status)
 }
+
+func TestRetryExecutionForeach(t *testing.T) {
+	newChild := func(index int, status scheduler.NodeStatus) *scheduler.Node {
+		return scheduler.NodeWithData(scheduler.NodeData{
+			Step: digraph.Step{
+				Name:        fmt.Sprintf("2[%d]", index),
+				Command:     "true",
+				Depends:     []string{"1"},
+				ForeachItem: &digraph.ForeachItem{Parent: "2", Index: index},
+			},
+			State: scheduler.NodeState{Status: status},
+		})
+	}
+	nodes := []*scheduler.Node{
+		scheduler.NodeWithData(scheduler.NodeData{
+			Step:  digraph.Step{Name: "1", Command: "true"},
+			State: scheduler.NodeState{Status: scheduler.NodeStatusSuccess},
+		}),
+		scheduler.NodeWithData(scheduler.NodeData{
+			Step:  digraph.Step{Name: "2", Command: "true", Depends: []string{"1"}, Foreach: "[1, 2]"},
+			State: scheduler.NodeState{Status: scheduler.NodeStatusError},
+		}),
+		newChild(0, scheduler.NodeStatusSuccess),
+		newChild(1, scheduler.NodeStatusError),
+	}
+	ctx := context.Background()
+	graph, err := scheduler.CreateRetryExecutionGraph(ctx, nodes...)
+	require.NoError(t, err)
+
+	// the children are removed so that the foreach step is expanded again
+	require.Len(t, graph.Nodes(), 2)
+	require.Equal(t, scheduler.NodeStatusSuccess, nodes[0].State().Status)
+	require.Equal(t, scheduler.NodeStatusNone, nodes[1].State().Status)
+}
+
+func TestRetryExecutionWaitingForApproval(t *testing.T) {
+	startedAt := time.Now().Add(-time.Hour)
+	nodes := []*scheduler.Node{
+		scheduler.NodeWithData(scheduler.NodeData{
+			Step:  digraph.Step{Name: "1", Command: "true"},
+			State: scheduler.NodeState{Status: scheduler.NodeStatusSuccess},
+		}),
+		scheduler.NodeWithData(scheduler.NodeData{
+			Step:  digraph.Step{Name: "2", Depends: []string{"1"}, Approval: &digraph.Approval{}},
+			State: scheduler.NodeState{Status: scheduler.NodeStatusWaiting, StartedAt: startedAt},
+		}),
+	}
+	_, err := scheduler.CreateRetryExecutionGraph(context.Background(), nodes...)
+	require.NoError(t, err)
+	require.Equal(t, scheduler.NodeStatusSuccess, nodes[0].State().Status)
+	// The node waits again with the original start time to keep the deadline.
+	require.Equal(t, scheduler.NodeStatusNone, nodes[1].State().Status)
+	require.Equal(t, startedAt, nodes[1].State().StartedAt)
+}
+
+func TestRetryExecutionFromStep(t *testing.T) {
+	newNodes := func() []*scheduler.Node {
+		return []*scheduler.Node{
+			scheduler.NodeWithData(scheduler.NodeData{
+				Step:  digraph.Step{Name: "1", Command: "true"},
+				State: scheduler.NodeState{Status: scheduler.NodeStatusSuccess},
+			}),
+			scheduler.NodeWithData(scheduler.NodeData{
+				Step:  digraph.Step{Name: "2", Command: "true", Depends: []string{"1"}},
+				State: scheduler.NodeState{Status: scheduler.NodeStatusSuccess},
+			}),
+			scheduler.NodeWithData(scheduler.NodeData{
+				Step:  digraph.Step{Name: "3", Command: "true", Depends: []string{"2"}},
+				State: scheduler.NodeState{Status: scheduler.NodeStatusSuccess},
+			}),
+			scheduler.NodeWithData(scheduler.NodeData{
+				Step:  digraph.Step{Name: "4", Command: "true"},
+				State: scheduler.NodeState{Status: scheduler.NodeStatusError},
+			}),
+		}
+	}
+	ctx := context.Background()
+
+	t.Run("FromStep", func(t *testing.T) {
+		nodes := newNodes()
+		_, err := scheduler.CreateStepRetryExecutionGraph(ctx, "2", false, nodes...)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.NodeStatusSuccess, nodes[0].State().Status)
+		require.Equal(t, scheduler.NodeStatusNone, nodes[1].State().Status)
+		require.Equal(t, scheduler.NodeStatusNone, nodes[2].State().Status)
+		// the failed step is retried too
+		require.Equal(t, scheduler.NodeStatusNone, nodes[3].State().Status)
+	})
+	t.Run("OnlyStep", func(t *testing.T) {
+		nodes := newNodes()
+		_, err := scheduler.CreateStepRetryExecutionGraph(ctx, "2", true, nodes...)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.NodeStatusSuccess, nodes[0].State().Status)
+		require.Equal(t, scheduler.NodeStatusNone, nodes[1].State().Status)
+		require.Equal(t, scheduler.NodeStatusNone, nodes[2].State().Status)
+		// the steps outside of the subgraph keep their states
+		require.Equal(t, scheduler.NodeStatusError, nodes[3].State().Status)
+	})
+	t.Run("StepNotFound", func(t *testing.T) {
+		_, err := scheduler.CreateStepRetryExecutionGraph(ctx, "5", false, newNodes()...)
+		require.Error(t, err)
+	})
+}
+
+func TestRecoveryExecution(t *testing.T) {
+	nodes := []*scheduler.Node{
+		scheduler.NodeWithData(scheduler.NodeData{
+			Step:  digraph.Step{Name: "1", Command: "true"},
+			State: scheduler.NodeState{Status: scheduler.NodeStatusSuccess},
+		}),
+		scheduler.NodeWithData(scheduler.NodeData{
+			Step:  digraph.Step{Name: "2", Command: "true", Depends: []string{"1"}},
+			State: scheduler.NodeState{Status: scheduler.NodeStatusRunning, RetryCount: 1, Log: "2.log"},
+		}),
+		scheduler.NodeWithData(scheduler.NodeData{
+			Step:  digraph.Step{Name: "3", Command: "true", Depends: []string{"2"}},
+			State: scheduler.NodeState{Status: scheduler.NodeStatusNone},
+		}),
+		scheduler.NodeWithData(scheduler.NodeData{
+			Step:  digraph.Step{Name: "4", Command: "true"},
+			State: scheduler.NodeState{Status: scheduler.NodeStatusError},
+		}),
+	}
+	_, err := scheduler.CreateRecoveryExecutionGraph(context.Background(), nodes...)
+	require.NoError(t, err)
+	require.Equal(t, scheduler.NodeStatusSuccess, nodes[0].State().Status)
+	// the interrupted step is run again with the retry count kept
+	require.Equal(t, scheduler.NodeStatusNone, nodes[1].State().Status)
+	require.Equal(t, 1, nodes[1].State().RetryCount)
+	require.Empty(t, nodes[1].State().Log)
+	require.Equal(t, scheduler.NodeStatusNone, nodes[2].State().Status)
+	// the finished steps are not run again even if they failed
+	require.Equal(t, scheduler.NodeStatusError, nodes[3].State().Status)
+}
@@ -4,13 +4,19 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"golang.org/x/sys/unix"
@@ -20,6 +26,7 @@ import (
 	"github.com/dagu-org/dagu/internal/digraph/executor"
 	"github.com/dagu-org/dagu/internal/fileutil"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/secrets"
 	"github.com/dagu-org/dagu/internal/stringutil"
 )
 
@@ -34,6 +41,8 @@ type Node struct {
 	cancelFunc   func()
 	logFile      *os.File
 	logWriter    *bufio.Writer
+	logMasker    *secrets.MaskingWriter
+	masker       *secrets.Masker
 	stdoutFile   *os.File
 	stdoutWriter *bufio.Writer
 	stderrFile   *os.File
@@ -44,6 +53,18 @@ type Node struct {
 	done         bool
 	retryPolicy  retryPolicy
 	cmdEvaluated bool
+	// approvalCh receives the decision when the node is waiting for
+	// approval.
+	approvalCh chan ApprovalDecision
+	// logOffset is the size of the log file when the current execution
+	// started. It is used to read the output of each iteration.
+	logOffset int64
+	// retryAt is the time to run the node again to retry. The dispatcher
+	// holds the node until then.
+	retryAt time.Time
+	// waited is true if the runs of the DAGs in waitFor of the step are
+	// finished.
+	waited bool
 }
 
 type NodeData struct {
@@ -62,6 +83,29 @@ type NodeState struct {
 	DoneCount  int
 	Error      error
 	ExitCode   int
+	// Attempts is the history of the executions of the node.
+	Attempts []Attempt
+	// Iterations is the number of executions by the repeat policy.
+	Iterations int
+	// LastResult is the output of the last iteration of the repeat policy.
+	LastResult string
+	// Outputs is the values extracted from the output by the output rules
+	// of the step.
+	Outputs map[string]string
+	// Artifacts is the paths of the artifacts produced by the step relative
+	// to the artifact directory of the step.
+	Artifacts []string
+	// Approval is the decision on the step waiting for approval.
+	Approval *ApprovalDecision
+}
+
+// Attempt is the result of a single execution of a node.
+type Attempt struct {
+	Attempt    int
+	StartedAt  time.Time
+	FinishedAt time.Time
+	ExitCode   int
+	Error      error
 }
 
 // NodeStatus represents the status of a node.
@@ -74,6 +118,8 @@ const (
 	NodeStatusCancel
 	NodeStatusSuccess
 	NodeStatusSkipped
+	NodeStatusTimeout
+	NodeStatusWaiting
 )
 
 func (s NodeStatus) String() string {
@@ -88,6 +134,10 @@ func (s NodeStatus) String() string {
 		return "finished"
 	case NodeStatusSkipped:
 		return "skipped"
+	case NodeStatusTimeout:
+		return "timed out"
+	case NodeStatusWaiting:
+		return "waiting"
 	case NodeStatusNone:
 		fallthrough
 	default:
@@ -168,9 +218,9 @@ func (n *Node) shouldContinue(ctx context.Context) bool {
 		if continueOn.Skipped {
 			return true
 		}
-	case NodeStatusNone:
-		fallthrough
-	case NodeStatusRunning:
+	case NodeStatusTimeout:
+		return continueOn.Timeout
+	case NodeStatusNone, NodeStatusRunning, NodeStatusWaiting:
 		// Unexpected state
 		logger.Error(ctx, "unexpected node status", "status", n.data.State.Status)
 		return false
@@ -234,6 +284,12 @@ func (n *Node) Execute(ctx context.Context) error {
 		return err
 	}
 
+	startedAt := time.Now()
+	stopTimer := func() bool { return false }
+	if timeout := n.data.Step.Timeout; timeout > 0 {
+		stopTimer = n.watchTimeout(ctx, cmd, timeout)
+	}
+
 	var exitCode int
 	if err := cmd.Run(ctx); err != nil {
 		n.setError(err)
@@ -246,22 +302,122 @@ func (n *Node) Execute(ctx context.Context) error {
 		}
 	}
 
+	if stopTimer() {
+		n.setError(fmt.Errorf("%w after %s", ErrStepTimeout, n.data.Step.Timeout))
+	}
+
 	n.SetExitCode(exitCode)
 
-	if n.outputReader != nil && n.data.Step.Output != "" {
+	if n.outputReader != nil {
 		if err := n.outputWriter.Close(); err != nil {
 			logger.Error(ctx, "failed to close pipe writer", "err", err)
 		}
 		var buf bytes.Buffer
 		// TODO: handle the case where the error or output is too large
 		_, _ = io.Copy(&buf, n.outputReader)
-		value := strings.TrimSpace(buf.String())
-		n.setVariable(n.data.Step.Output, value)
+		value := n.masker.Mask(strings.TrimSpace(buf.String()))
+		if n.data.Step.Output != "" {
+			n.setVariable(n.data.Step.Output, value)
+		}
+		if n.data.State.Error == nil {
+			n.extractOutputs(ctx, value)
+		}
 	}
 
+	n.recordAttempt(startedAt, exitCode, n.data.State.Error)
+
 	return n.data.State.Error
 }
 
+// extractOutputs extracts the values from the output by the output rules of
+// the step. The step fails if any of the values cannot be extracted.
+func (n *Node) extractOutputs(ctx context.Context, stdout string) {
+	if len(n.data.Step.Outputs) == 0 {
+		return
+	}
+
+	outputs := make(map[string]string, len(n.data.Step.Outputs))
+	for _, output := range n.data.Step.Outputs {
+		value, err := output.Extract(ctx, stdout)
+		if err != nil {
+			n.setError(fmt.Errorf("%w %q: %w", ErrOutputExtraction, output.Name, err))
+			continue
+		}
+		outputs[output.Name] = n.masker.Mask(value)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.data.State.Outputs = outputs
+}
+
+// recordAttempt appends the result of the execution to the attempt history.
+func (n *Node) recordAttempt(startedAt time.Time, exitCode int, err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.data.State.Attempts = append(n.data.State.Attempts, Attempt{
+		Attempt:    len(n.data.State.Attempts) + 1,
+		StartedAt:  startedAt,
+		FinishedAt: time.Now(),
+		ExitCode:   exitCode,
+		Error:      err,
+	})
+}
+
+// watchTimeout stops the command when it does not finish within the timeout.
+// It sends the stop signal of the step (SIGTERM by default) first and kills
+// the command if it is still running after the grace period.
+// The returned function stops the watcher and reports whether the timeout
+// was exceeded.
+func (n *Node) watchTimeout(ctx context.Context, cmd executor.Executor, timeout time.Duration) func() bool {
+	done := make(chan struct{})
+	var timedOut atomic.Bool
+
+	go func() {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-done:
+			return
+		case <-timer.C:
+		}
+
+		timedOut.Store(true)
+		var sig os.Signal = syscall.SIGTERM
+		if n.data.Step.SignalOnStop != "" {
+			sig = unix.SignalNum(n.data.Step.SignalOnStop)
+		}
+		logger.Info(ctx, "Step timed out", "step", n.data.Step.Name, "timeout", timeout, "signal", sig)
+		if err := cmd.Kill(sig); err != nil {
+			logger.Error(ctx, "Failed to send signal", "err", err, "step", n.data.Step.Name)
+		}
+
+		grace := time.NewTimer(timeoutGracePeriod)
+		defer grace.Stop()
+		select {
+		case <-done:
+			return
+		case <-grace.C:
+		}
+
+		logger.Info(ctx, "Killing step after grace period", "step", n.data.Step.Name)
+		if err := cmd.Kill(syscall.SIGKILL); err != nil {
+			logger.Error(ctx, "Failed to kill step", "err", err, "step", n.data.Step.Name)
+		}
+		n.mu.RLock()
+		cancel := n.cancelFunc
+		n.mu.RUnlock()
+		if cancel != nil {
+			cancel()
+		}
+	}()
+
+	return func() bool {
+		close(done)
+		return timedOut.Load()
+	}
+}
+
 func (n *Node) clearVariable(key string) {
 	_ = os.Unsetenv(key)
 
@@ -324,6 +480,9 @@ func (n *Node) setupExec(ctx context.Context) (executor.Executor, error) {
 	// Reset the state
 	n.data.State.Error = nil
 	n.data.State.ExitCode = 0
+	n.data.State.Outputs = nil
+
+	n.logOffset = n.logSize()
 
 	// Evaluate the command and args if not already evaluated
 	if err := n.evaluateCommandArgs(ctx); err != nil {
@@ -353,7 +512,7 @@ func (n *Node) setupExec(ctx context.Context) (executor.Executor, error) {
 		stdout = io.MultiWriter(n.logWriter, n.stdoutWriter)
 	}
 
-	if n.data.Step.Output != "" {
+	if n.data.Step.Output != "" || len(n.data.Step.Outputs) > 0 {
 		var err error
 		if n.outputReader, n.outputWriter, err = os.Pipe(); err != nil {
 			return nil, err
@@ -371,6 +530,49 @@ func (n *Node) setupExec(ctx context.Context) (executor.Executor, error) {
 	return cmd, nil
 }
 
+// logSize returns the current size of the log file.
+func (n *Node) logSize() int64 {
+	n.logLock.Lock()
+	defer n.logLock.Unlock()
+	if n.logFile == nil {
+		return 0
+	}
+	_ = n.flushLog()
+	info, err := n.logFile.Stat()
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// executionOutput returns the output (stdout and stderr) of the current
+// execution, i.e. the log written after the execution started.
+func (n *Node) executionOutput() (string, error) {
+	logFilename := n.LogFilename()
+	if logFilename == "" {
+		return "", nil
+	}
+
+	n.logLock.Lock()
+	defer n.logLock.Unlock()
+	_ = n.flushLog()
+
+	file, err := os.Open(logFilename)
+	if err != nil {
+		return "", fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(n.logOffset, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to seek log file: %w", err)
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read log file: %w", err)
+	}
+	return string(data), nil
+}
+
 func (n *Node) evaluateCommandArgs(ctx context.Context) error {
 	if n.cmdEvaluated {
 		return nil
@@ -478,6 +680,38 @@ func (n *Node) SetRetriedAt(retriedAt time.Time) {
 	n.data.State.RetriedAt = retriedAt
 }
 
+func (n *Node) setRetryAt(retryAt time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.retryAt = retryAt
+}
+
+func (n *Node) getRetryAt() time.Time {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.retryAt
+}
+
+func (n *Node) hasWaited() bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.waited
+}
+
+func (n *Node) setWaited() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.waited = true
+}
+
+// shouldWait returns true if the step waits for the runs of other DAGs
+// before running the command.
+func (n *Node) shouldWait() bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return len(n.data.Step.WaitFor) > 0 && !n.waited
+}
+
 func (n *Node) GetDoneCount() int {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
@@ -597,6 +831,7 @@ func (n *Node) Setup(ctx context.Context, logDir string, requestID string) error
 	}
 	n.data.Step.Dir = dir
 
+	n.masker = secrets.NewMasker(stepContext.SecretValues())
 	if err := n.setupLog(); err != nil {
 		return fmt.Errorf("failed to setup log: %w", err)
 	}
@@ -622,11 +857,12 @@ func (n *Node) Teardown() error {
 	n.logLock.Lock()
 	n.done = true
 	var lastErr error
-	for _, w := range []*bufio.Writer{n.logWriter, n.stdoutWriter} {
-		if w != nil {
-			if err := w.Flush(); err != nil {
-				lastErr = err
-			}
+	if err := n.flushLog(); err != nil {
+		lastErr = err
+	}
+	if n.stdoutWriter != nil {
+		if err := n.stdoutWriter.Flush(); err != nil {
+			lastErr = err
 		}
 	}
 	for _, f := range []*os.File{n.logFile, n.stdoutFile} {
@@ -663,8 +899,15 @@ func (n *Node) IncDoneCount() {
 
 var (
 	ErrWorkingDirNotExist = fmt.Errorf("working directory does not exist")
+	ErrStepTimeout        = fmt.Errorf("step timed out")
+	ErrRepeatLimitReached = fmt.Errorf("repeat limit reached")
+	ErrOutputExtraction   = fmt.Errorf("failed to extract output")
 )
 
+// timeoutGracePeriod is the time to wait for a timed out step to exit after
+// the stop signal is sent before killing it.
+var timeoutGracePeriod = 5 * time.Second
+
 func (n *Node) setupScript() (err error) {
 	if n.data.Step.Script != "" {
 		if len(n.data.Step.Dir) > 0 && !fileutil.FileExists(n.data.Step.Dir) {
@@ -728,10 +971,31 @@ func (n *Node) setupLog() error {
 		n.data.State.Error = err
 		return err
 	}
+	if n.masker != nil {
+		// Mask the secrets in the log.
+		n.logMasker = secrets.NewMaskingWriter(n.logFile, n.masker)
+		n.logWriter = bufio.NewWriter(n.logMasker)
+		return nil
+	}
 	n.logWriter = bufio.NewWriter(n.logFile)
 	return nil
 }
 
+// flushLog writes the buffered log to the file.
+// It must be called with logLock held.
+func (n *Node) flushLog() error {
+	if n.logWriter == nil {
+		return nil
+	}
+	if err := n.logWriter.Flush(); err != nil {
+		return err
+	}
+	if n.logMasker != nil {
+		return n.logMasker.Flush()
+	}
+	return nil
+}
+
 // LogContainsPattern checks if any of the given patterns exist in the node's log file.
 // If a pattern starts with "regexp:", it will be treated as a regular expression.
 // Returns false if no log file exists or no pattern is found.
@@ -768,6 +1032,9 @@ func (n *Node) LogContainsPattern(ctx context.Context, patterns []string) (bool,
 	n.logLock.Lock()
 	defer n.logLock.Unlock()
 
+	// Make sure the buffered output is written before reading the file
+	_ = n.flushLog()
+
 	if stringutil.MatchPatternScanner(ctx, scanner, patterns) {
 		return true, nil
 	}
@@ -805,13 +1072,25 @@ func (n *Node) Init() {
 }
 
 type retryPolicy struct {
-	Limit    int
-	Interval time.Duration
+	Limit       int
+	Interval    time.Duration
+	Backoff     float64
+	MaxInterval time.Duration
+	Jitter      float64
+	ExitCode    []int
+	Output      []string
+	OnTimeout   bool
 }
 
 func (n *Node) setupRetryPolicy(ctx context.Context) error {
 	var retryPolicy retryPolicy
 
+	retryPolicy.Backoff = n.data.Step.RetryPolicy.Backoff
+	retryPolicy.MaxInterval = n.data.Step.RetryPolicy.MaxInterval
+	retryPolicy.Jitter = n.data.Step.RetryPolicy.Jitter
+	retryPolicy.ExitCode = n.data.Step.RetryPolicy.ExitCode
+	retryPolicy.Output = n.data.Step.RetryPolicy.Output
+	retryPolicy.OnTimeout = n.data.Step.RetryPolicy.OnTimeout
 	if n.data.Step.RetryPolicy.Limit > 0 {
 		retryPolicy.Limit = n.data.Step.RetryPolicy.Limit
 	}
@@ -837,3 +1116,122 @@ func (n *Node) setupRetryPolicy(ctx context.Context) error {
 	n.retryPolicy = retryPolicy
 	return nil
 }
+
+// shouldRetry returns true if the node should be retried after the error.
+// If exit codes or output patterns are configured, the node is retried only
+// when the exit code or the output of the last attempt matches one of them.
+func (n *Node) shouldRetry(ctx context.Context, err error) bool {
+	if n.retryPolicy.Limit <= n.GetRetryCount() {
+		return false
+	}
+	if errors.Is(err, ErrStepTimeout) {
+		return n.retryPolicy.OnTimeout
+	}
+	if len(n.retryPolicy.ExitCode) == 0 && len(n.retryPolicy.Output) == 0 {
+		return true
+	}
+
+	if slices.Contains(n.retryPolicy.ExitCode, n.GetExitCode()) {
+		return true
+	}
+
+	if len(n.retryPolicy.Output) > 0 {
+		// The log file has the output of all the attempts. Only the output
+		// of the last attempt is matched.
+		output, err := n.executionOutput()
+		if err != nil {
+			logger.Error(ctx, "failed to read the output of the attempt", "err", err)
+			return false
+		}
+		if stringutil.MatchPattern(ctx, output, n.retryPolicy.Output) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// retryInterval returns the time to wait before the next retry.
+// The interval is multiplied by the backoff for each retry, randomized by the
+// jitter and capped by the max interval.
+func (n *Node) retryInterval() time.Duration {
+	return backoffInterval(
+		n.retryPolicy.Interval, n.retryPolicy.Backoff, n.retryPolicy.Jitter,
+		n.retryPolicy.MaxInterval, n.GetRetryCount(),
+	)
+}
+
+// repeatInterval returns the time to wait before the next iteration of the
+// repeat policy.
+func (n *Node) repeatInterval() time.Duration {
+	policy := n.data.Step.RepeatPolicy
+	return backoffInterval(policy.Interval, policy.Backoff, 0, policy.MaxInterval, n.State().Iterations)
+}
+
+// backoffInterval returns the interval multiplied by the backoff for the
+// n-th (1-based) time, randomized by the jitter and capped by maxInterval.
+func backoffInterval(interval time.Duration, backoff, jitter float64, maxInterval time.Duration, n int) time.Duration {
+	d := float64(interval)
+	if backoff > 1 && n > 1 {
+		d *= math.Pow(backoff, float64(n-1))
+	}
+	if jitter > 0 {
+		d += d * jitter * (rand.Float64()*2 - 1) // nolint: gosec
+	}
+	if maxInterval > 0 && d > float64(maxInterval) {
+		d = float64(maxInterval)
+	}
+	if d > math.MaxInt64 {
+		d = math.MaxInt64
+	}
+	return time.Duration(d)
+}
+
+// repeatUntilMet evaluates the until condition of the repeat policy with the
+// output of the last execution. All of the specified conditions must be met.
+func (n *Node) repeatUntilMet(ctx context.Context, output string) (bool, error) {
+	until := n.data.Step.RepeatPolicy.Until
+	if until == nil {
+		return false, nil
+	}
+
+	if len(until.ExitCode) > 0 && !slices.Contains(until.ExitCode, n.GetExitCode()) {
+		return false, nil
+	}
+
+	if len(until.Output) > 0 && !stringutil.MatchPattern(ctx, output, until.Output) {
+		return false, nil
+	}
+
+	if until.Condition != nil {
+		// make the output variable of this step available to the condition
+		if name := n.data.Step.Output; name != "" {
+			if v, ok := n.getVariable(name); ok {
+				stepContext := digraph.GetStepContext(ctx).WithEnv(name, v.Value())
+				ctx = digraph.WithStepContext(ctx, stepContext)
+			}
+		}
+		if err := digraph.EvalConditions(ctx, []digraph.Condition{*until.Condition}); err != nil {
+			if errors.Is(err, digraph.ErrConditionNotMet) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// recordIteration increments the iteration count and keeps the last line of
+// the output of the execution as the last result. It returns the output.
+func (n *Node) recordIteration() (string, error) {
+	output, err := n.executionOutput()
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.data.State.Iterations++
+	n.data.State.LastResult = strings.TrimSpace(lines[len(lines)-1])
+
+	return output, err
+}
@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNode_ShouldRetryOutput(t *testing.T) {
+	// The attempts write to the same log file. Only the output of the last
+	// attempt is matched against the patterns.
+	counter := filepath.Join(t.TempDir(), "counter")
+	node := NodeWithData(NodeData{Step: digraph.Step{
+		Name: "1",
+		Script: fmt.Sprintf("n=$(cat %[1]s 2>/dev/null || echo 0)\nn=$((n+1))\necho $n > %[1]s\n"+
+			"[ $n -eq 1 ] && echo temporary error || echo fatal error; false", counter),
+		RetryPolicy: digraph.RetryPolicy{Limit: 3, Output: []string{"re:^temporary"}},
+	}})
+
+	ctx := digraph.NewContext(context.Background(), &digraph.DAG{}, nil, "request-id", "logFile")
+	require.NoError(t, node.Setup(ctx, t.TempDir(), "request-id"))
+	t.Cleanup(func() { _ = node.Teardown() })
+
+	err := node.Execute(ctx)
+	require.Error(t, err)
+	require.True(t, node.shouldRetry(ctx, err), "the first attempt should be retried")
+
+	node.IncRetryCount()
+	err = node.Execute(ctx)
+	require.Error(t, err)
+	require.False(t, node.shouldRetry(ctx, err), "the second attempt should not be retried")
+}
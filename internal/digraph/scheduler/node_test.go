@@ -259,4 +259,25 @@ func TestNode(t *testing.T) {
 		require.NotEmpty(t, scriptFilePath)
 		require.NoFileExists(t, scriptFilePath, "script file not removed")
 	})
+	t.Run("MaskSecrets", func(t *testing.T) {
+		node := setupNode(t, withNodeCmdArgs(`echo "token=$API_TOKEN"`), withNodeOutput("OUTPUT"))
+
+		ctx := node.execContext()
+		ctx = digraph.WithContext(ctx, digraph.GetContext(ctx).WithSecrets(map[string]string{
+			"API_TOKEN": "s3cret",
+		}))
+
+		err := node.Node.Setup(ctx, node.Config.Paths.LogDir, node.reqID)
+		require.NoError(t, err, "failed to setup node")
+		err = node.Node.Execute(ctx)
+		require.NoError(t, err, "failed to execute node")
+		require.NoError(t, node.Teardown(), "failed to teardown node")
+
+		node.AssertLogContains(t, "token=*****")
+		node.AssertOutput(t, "OUTPUT", "token=*****")
+
+		dat, err := os.ReadFile(node.Node.LogFilename())
+		require.NoError(t, err)
+		require.NotContains(t, string(dat), "s3cret")
+	})
 }
@@ -0,0 +1,49 @@
+package scheduler
+
+import "errors"
+
+var (
+	// ErrAlreadyPaused is returned when the DAG run to pause is already paused.
+	ErrAlreadyPaused = errors.New("the DAG run is already paused")
+	// ErrNotPaused is returned when the DAG run to resume is not paused.
+	ErrNotPaused = errors.New("the DAG run is not paused")
+	// ErrCanceled is returned when the DAG run to pause or resume is canceled.
+	ErrCanceled = errors.New("the DAG run is canceled")
+)
+
+// Pause stops launching new steps. The running steps keep running until
+// they finish, and the DAG run stays in the paused status until it's resumed.
+func (sc *Scheduler) Pause() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.canceled == 1 {
+		return ErrCanceled
+	}
+	if sc.paused == 1 {
+		return ErrAlreadyPaused
+	}
+	sc.paused = 1
+	return nil
+}
+
+// Resume continues the paused DAG run from where it stopped.
+func (sc *Scheduler) Resume() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.canceled == 1 {
+		return ErrCanceled
+	}
+	if sc.paused == 0 {
+		return ErrNotPaused
+	}
+	sc.paused = 0
+	sc.wake()
+	return nil
+}
+
+// isPaused returns true if the scheduler is paused.
+func (sc *Scheduler) isPaused() bool {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.paused == 1
+}
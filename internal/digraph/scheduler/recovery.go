@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/dagu-org/dagu/internal/logger"
+)
+
+// CreateRecoveryExecutionGraph creates a new execution graph to continue the
+// run whose agent process died, e.g. by OOM or a host reboot. The finished
+// steps keep their states and outputs, and the steps that were running are
+// run again. The retry counts are kept so that the retry policies continue
+// from where they stopped.
+func CreateRecoveryExecutionGraph(ctx context.Context, nodes ...*Node) (*ExecutionGraph, error) {
+	graph, err := newRetryExecutionGraph(nodes...)
+	if err != nil {
+		return nil, err
+	}
+	graph.setupRecovery(ctx)
+	return graph, nil
+}
+
+func (g *ExecutionGraph) setupRecovery(ctx context.Context) {
+	for _, node := range g.nodes {
+		switch node.data.State.Status {
+		case NodeStatusRunning:
+			if node.data.Step.Foreach != "" && len(g.children[node.id]) > 0 {
+				// The children are recovered instead, and the foreach step
+				// waits for them to finish again.
+				continue
+			}
+			logger.Info(ctx, "recover the interrupted node", "step", node.data.Step.Name)
+			node.resetInterrupted()
+
+		case NodeStatusWaiting:
+			logger.Info(ctx, "resume waiting for approval", "step", node.data.Step.Name)
+			node.resumeWaiting()
+
+		default:
+			// keep the state of the node
+
+		}
+	}
+}
+
+// resetInterrupted resets the state of the node interrupted by the death of
+// the agent process to run it again. The counts of the retries, the attempts
+// and the iterations are kept.
+func (n *Node) resetInterrupted() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.data.State = NodeState{
+		RetryCount: n.data.State.RetryCount,
+		RetriedAt:  n.data.State.RetriedAt,
+		DoneCount:  n.data.State.DoneCount,
+		Attempts:   n.data.State.Attempts,
+		Iterations: n.data.State.Iterations,
+		LastResult: n.data.State.LastResult,
+	}
+}
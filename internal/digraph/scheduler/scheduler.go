@@ -2,9 +2,13 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
-	"runtime/debug"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -20,6 +24,7 @@ const (
 	StatusError
 	StatusCancel
 	StatusSuccess
+	StatusPaused
 )
 
 func (s Status) String() string {
@@ -32,6 +37,8 @@ func (s Status) String() string {
 		return "canceled"
 	case StatusSuccess:
 		return "finished"
+	case StatusPaused:
+		return "paused"
 	case StatusNone:
 		fallthrough
 	default:
@@ -39,9 +46,16 @@ func (s Status) String() string {
 	}
 }
 
+// IsActive returns true if the DAG run is in progress, i.e. running or
+// paused.
+func (s Status) IsActive() bool {
+	return s == StatusRunning || s == StatusPaused
+}
+
 // Scheduler is a scheduler that runs a graph of steps.
 type Scheduler struct {
 	logDir        string
+	artifactDir   string
 	maxActiveRuns int
 	timeout       time.Duration
 	delay         time.Duration
@@ -50,18 +64,27 @@ type Scheduler struct {
 	onSuccess     *digraph.Step
 	onFailure     *digraph.Step
 	onCancel      *digraph.Step
+	waitFor       []digraph.WaitFor
 	requestID     string
 
 	canceled  int32
+	paused    int32
 	mu        sync.RWMutex
-	pause     time.Duration
 	lastError error
 	handlers  map[digraph.HandlerType]*Node
+
+	// canceledCh is closed when the scheduler is canceled to stop waiting.
+	canceledCh chan struct{}
+	// wakeCh wakes up the dispatcher to run the ready nodes.
+	wakeCh chan struct{}
+	// stepsDoneCh is closed when all the steps have returned.
+	stepsDoneCh chan struct{}
 }
 
 func New(cfg *Config) *Scheduler {
 	return &Scheduler{
 		logDir:        cfg.LogDir,
+		artifactDir:   cfg.ArtifactDir,
 		maxActiveRuns: cfg.MaxActiveRuns,
 		timeout:       cfg.Timeout,
 		delay:         cfg.Delay,
@@ -70,13 +93,17 @@ func New(cfg *Config) *Scheduler {
 		onSuccess:     cfg.OnSuccess,
 		onFailure:     cfg.OnFailure,
 		onCancel:      cfg.OnCancel,
+		waitFor:       cfg.WaitFor,
 		requestID:     cfg.ReqID,
-		pause:         time.Millisecond * 100,
+		canceledCh:    make(chan struct{}),
+		wakeCh:        make(chan struct{}, 1),
+		stepsDoneCh:   make(chan struct{}),
 	}
 }
 
 type Config struct {
 	LogDir        string
+	ArtifactDir   string
 	MaxActiveRuns int
 	Timeout       time.Duration
 	Delay         time.Duration
@@ -85,6 +112,7 @@ type Config struct {
 	OnSuccess     *digraph.Step
 	OnFailure     *digraph.Step
 	OnCancel      *digraph.Step
+	WaitFor       []digraph.WaitFor
 	ReqID         string
 }
 
@@ -104,154 +132,24 @@ func (sc *Scheduler) Schedule(ctx context.Context, graph *ExecutionGraph, done c
 		defer cancel()
 	}
 
-	for !sc.isFinished(graph) {
-		if sc.isCanceled() {
-			break
-		}
-
-	NodesIteration:
-		for _, node := range graph.Nodes() {
-			if node.State().Status != NodeStatusNone || !isReady(ctx, graph, node) {
-				continue NodesIteration
-			}
-			if sc.isCanceled() {
-				break NodesIteration
-			}
-			if sc.maxActiveRuns > 0 && sc.runningCount(graph) >= sc.maxActiveRuns {
-				continue NodesIteration
+	// Wait for the runs of other DAGs before running any step.
+	if len(sc.waitFor) > 0 && !sc.dry {
+		if err := sc.waitForDAGs(ctx, sc.waitFor); err != nil && !sc.isCanceled() {
+			logger.Info(ctx, "Waiting for the DAG runs failed", "error", err)
+			if !errors.Is(err, digraph.ErrConditionNotMet) {
+				sc.setLastError(err)
 			}
-
-			// Check preconditions
-			if len(node.data.Step.Preconditions) > 0 {
-				logger.Infof(ctx, "Checking pre conditions for \"%s\"", node.data.Step.Name)
-				if err := digraph.EvalConditions(ctx, node.data.Step.Preconditions); err != nil {
-					logger.Infof(ctx, "Pre conditions failed for \"%s\"", node.data.Step.Name)
-					node.SetStatus(NodeStatusSkipped)
-					node.setError(err)
-					continue NodesIteration
-				}
+			for _, node := range graph.Nodes() {
+				node.SetStatus(NodeStatusSkipped)
+				node.setError(err)
 			}
-
-			wg.Add(1)
-
-			logger.Info(ctx, "Step execution started", "step", node.data.Step.Name)
-			node.SetStatus(NodeStatusRunning)
-			go func(ctx context.Context, node *Node) {
-				defer func() {
-					if panicObj := recover(); panicObj != nil {
-						stack := string(debug.Stack())
-						err := fmt.Errorf("panic recovered: %v\n%s", panicObj, stack)
-						logger.Error(ctx, "Panic occurred", "error", err, "step", node.data.Step.Name, "stack", stack)
-						node.MarkError(err)
-						sc.setLastError(err)
-					}
-				}()
-
-				defer func() {
-					node.Finish()
-					wg.Done()
-				}()
-
-				ctx = sc.setupContext(ctx, graph, node)
-
-				setupSucceed := true
-				if err := sc.setupNode(ctx, node); err != nil {
-					setupSucceed = false
-					sc.setLastError(err)
-					node.MarkError(err)
-				}
-
-				ctx = node.SetupContextBeforeExec(ctx)
-
-				defer func() {
-					_ = sc.teardownNode(node)
-				}()
-
-			ExecRepeat: // repeat execution
-				for setupSucceed && !sc.isCanceled() {
-					execErr := sc.execNode(ctx, node)
-					if execErr != nil {
-						status := node.State().Status
-						switch {
-						case status == NodeStatusSuccess || status == NodeStatusCancel:
-							// do nothing
-
-						case sc.isTimeout(graph.startedAt):
-							logger.Info(ctx, "Step execution deadline exceeded", "step", node.data.Step.Name, "error", execErr)
-							node.SetStatus(NodeStatusCancel)
-							sc.setLastError(execErr)
-
-						case sc.isCanceled():
-							sc.setLastError(execErr)
-
-						case node.retryPolicy.Limit > node.GetRetryCount():
-							// retry
-							node.IncRetryCount()
-							logger.Info(ctx, "Step execution failed. Retrying...", "step", node.data.Step.Name, "error", execErr, "retry", node.GetRetryCount())
-							time.Sleep(node.retryPolicy.Interval)
-							node.SetRetriedAt(time.Now())
-							node.SetStatus(NodeStatusNone)
-
-						default:
-							// finish the node
-							node.SetStatus(NodeStatusError)
-							if node.shouldMarkSuccess(ctx) {
-								// mark as success if the node should be marked as success
-								// i.e. continueOn.markSuccess is set to true
-								node.SetStatus(NodeStatusSuccess)
-							} else {
-								node.MarkError(execErr)
-								sc.setLastError(execErr)
-							}
-						}
-					}
-
-					if node.State().Status != NodeStatusCancel {
-						node.IncDoneCount()
-					}
-
-					if node.data.Step.RepeatPolicy.Repeat {
-						if execErr == nil || node.data.Step.ContinueOn.Failure {
-							if !sc.isCanceled() {
-								time.Sleep(node.data.Step.RepeatPolicy.Interval)
-								if done != nil {
-									done <- node
-								}
-								continue ExecRepeat
-							}
-						}
-					}
-
-					if execErr != nil && done != nil {
-						done <- node
-						return
-					}
-
-					break ExecRepeat
-				}
-
-				// finish the node
-				if node.State().Status == NodeStatusRunning {
-					node.SetStatus(NodeStatusSuccess)
-				}
-
-				if err := sc.teardownNode(node); err != nil {
-					sc.setLastError(err)
-					node.SetStatus(NodeStatusError)
-				}
-
-				if done != nil {
-					done <- node
-				}
-			}(ctx, node)
-
-			time.Sleep(sc.delay) // TODO: check if this is necessary
 		}
-
-		time.Sleep(sc.pause) // avoid busy loop
 	}
 
+	newDispatcher(sc, graph, done).run(ctx, &wg)
+
 	wg.Wait()
+	close(sc.stepsDoneCh)
 
 	var handlers []digraph.HandlerType
 	switch sc.Status(graph) {
@@ -267,7 +165,7 @@ func (sc *Scheduler) Schedule(ctx context.Context, graph *ExecutionGraph, done c
 	case StatusNone:
 		// do nothing (should not happen)
 
-	case StatusRunning:
+	case StatusRunning, StatusPaused:
 		// do nothing (should not happen)
 
 	}
@@ -289,6 +187,178 @@ func (sc *Scheduler) Schedule(ctx context.Context, graph *ExecutionGraph, done c
 	return sc.lastError
 }
 
+// runNode runs the node until it finishes, including the retries and the
+// repetitions of the step.
+func (sc *Scheduler) runNode(ctx context.Context, graph *ExecutionGraph, node *Node, done chan *Node) {
+	ctx = sc.setupContext(ctx, graph, node)
+
+	// Wait for the runs of other DAGs before running the step. The node is
+	// returned to the dispatcher after waiting to run the command in a slot
+	// of maxActiveRuns.
+	if node.shouldWait() && !sc.dry {
+		if err := sc.waitForDAGs(ctx, node.data.Step.WaitFor); err != nil {
+			switch {
+			case sc.isCanceled():
+				node.SetStatus(NodeStatusCancel)
+			case errors.Is(err, digraph.ErrConditionNotMet):
+				logger.Info(ctx, "Step skipped after waiting for the DAG runs", "step", node.data.Step.Name, "error", err)
+				node.SetStatus(NodeStatusSkipped)
+				node.setError(err)
+			default:
+				logger.Error(ctx, "Waiting for the DAG runs failed", "step", node.data.Step.Name, "error", err)
+				node.MarkError(err)
+				sc.setLastError(err)
+			}
+			if done != nil {
+				done <- node
+			}
+			return
+		}
+		node.setWaited()
+		node.SetStatus(NodeStatusNone)
+		return
+	}
+
+	// Wait for the approval instead of running a command.
+	if node.data.Step.Approval != nil && !sc.dry {
+		sc.waitForApproval(ctx, node, done)
+		if done != nil {
+			done <- node
+		}
+		return
+	}
+
+	setupSucceed := true
+	if err := sc.setupNode(ctx, node); err != nil {
+		setupSucceed = false
+		sc.setLastError(err)
+		node.MarkError(err)
+	}
+
+	ctx = node.SetupContextBeforeExec(ctx)
+
+	defer func() {
+		_ = sc.teardownNode(node)
+	}()
+
+ExecRepeat: // repeat execution
+	for setupSucceed && !sc.isCanceled() {
+		execErr := sc.execNode(ctx, node)
+
+		// repeat until the condition is met
+		if node.data.Step.RepeatPolicy.Until != nil && !sc.isCanceled() && node.State().Status != NodeStatusCancel {
+			repeat, err := sc.repeatUntil(ctx, node, execErr)
+			if repeat {
+				node.IncDoneCount()
+				if !sc.sleep(ctx, node.repeatInterval()) {
+					sc.cancelRepeat(ctx, node)
+					break ExecRepeat
+				}
+				if done != nil {
+					done <- node
+				}
+				continue ExecRepeat
+			}
+			execErr = err
+			node.setError(execErr)
+		}
+
+		if execErr != nil {
+			status := node.State().Status
+			switch {
+			case status == NodeStatusSuccess || status == NodeStatusCancel:
+				// do nothing
+
+			case sc.isTimeout(graph.startedAt):
+				logger.Info(ctx, "Step execution deadline exceeded", "step", node.data.Step.Name, "error", execErr)
+				node.SetStatus(NodeStatusCancel)
+				sc.setLastError(execErr)
+
+			case sc.isCanceled():
+				sc.setLastError(execErr)
+
+			case node.shouldRetry(ctx, execErr):
+				// retry
+				node.IncRetryCount()
+				interval := node.retryInterval()
+				logger.Info(ctx, "Step execution failed. Retrying...", "step", node.data.Step.Name, "error", execErr, "retry", node.GetRetryCount(), "interval", interval)
+				// The node is returned to the dispatcher, which runs it
+				// again after the interval. It doesn't occupy a slot of
+				// maxActiveRuns while waiting.
+				node.setRetryAt(time.Now().Add(interval))
+				node.SetStatus(NodeStatusNone)
+
+			case errors.Is(execErr, ErrStepTimeout):
+				// finish the node as timed out
+				node.SetStatus(NodeStatusTimeout)
+				if node.shouldMarkSuccess(ctx) {
+					node.SetStatus(NodeStatusSuccess)
+				} else {
+					node.setError(execErr)
+					sc.setLastError(execErr)
+					sc.cancelMatrixSiblings(ctx, graph, node)
+				}
+
+			default:
+				// finish the node
+				node.SetStatus(NodeStatusError)
+				if node.shouldMarkSuccess(ctx) {
+					// mark as success if the node should be marked as success
+					// i.e. continueOn.markSuccess is set to true
+					node.SetStatus(NodeStatusSuccess)
+				} else {
+					node.MarkError(execErr)
+					sc.setLastError(execErr)
+					sc.cancelMatrixSiblings(ctx, graph, node)
+				}
+			}
+		}
+
+		if node.State().Status != NodeStatusCancel {
+			node.IncDoneCount()
+		}
+
+		if policy := node.data.Step.RepeatPolicy; policy.Repeat && policy.Until == nil {
+			if _, err := node.recordIteration(); err != nil {
+				logger.Error(ctx, "Failed to record iteration", "step", node.data.Step.Name, "error", err)
+			}
+			if execErr == nil || node.data.Step.ContinueOn.Failure {
+				if !sc.isCanceled() && (policy.Limit == 0 || node.State().Iterations < policy.Limit) {
+					if !sc.sleep(ctx, node.repeatInterval()) {
+						sc.cancelRepeat(ctx, node)
+						break ExecRepeat
+					}
+					if done != nil {
+						done <- node
+					}
+					continue ExecRepeat
+				}
+			}
+		}
+
+		if execErr != nil && done != nil {
+			done <- node
+			return
+		}
+
+		break ExecRepeat
+	}
+
+	// finish the node
+	if node.State().Status == NodeStatusRunning {
+		node.SetStatus(NodeStatusSuccess)
+	}
+
+	if err := sc.teardownNode(node); err != nil {
+		sc.setLastError(err)
+		node.SetStatus(NodeStatusError)
+	}
+
+	if done != nil {
+		done <- node
+	}
+}
+
 func (sc *Scheduler) setLastError(err error) {
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
@@ -323,9 +393,12 @@ func (sc *Scheduler) setupContext(ctx context.Context, graph *ExecutionGraph, no
 			continue
 		}
 		visited[curr] = struct{}{}
-		queue = append(queue, graph.to[curr]...)
+		queue = append(queue, graph.dependencies(curr)...)
 
 		node := graph.node(curr)
+		if outputs := node.State().Outputs; len(outputs) > 0 {
+			stepCtx.LoadStepOutputs(node.data.Step.Name, outputs)
+		}
 		if node.data.Step.OutputVariables == nil {
 			continue
 		}
@@ -333,6 +406,15 @@ func (sc *Scheduler) setupContext(ctx context.Context, graph *ExecutionGraph, no
 		stepCtx.LoadOutputVariables(node.data.Step.OutputVariables)
 	}
 
+	if item := node.data.Step.ForeachItem; item != nil {
+		stepCtx = stepCtx.WithEnv(digraph.EnvKeyForeachItem, item.Value)
+	}
+	if matrix := node.data.Step.Matrix; matrix != nil {
+		for k, v := range matrix.Values {
+			stepCtx = stepCtx.WithEnv(k, v)
+		}
+	}
+
 	return digraph.WithStepContext(ctx, stepCtx)
 }
 
@@ -342,6 +424,9 @@ func (sc *Scheduler) buildStepContextForHandler(ctx context.Context, graph *Exec
 
 	// get all output variables
 	for _, node := range graph.Nodes() {
+		if outputs := node.State().Outputs; len(outputs) > 0 {
+			stepCtx.LoadStepOutputs(node.data.Step.Name, outputs)
+		}
 		if node.data.Step.OutputVariables == nil {
 			continue
 		}
@@ -352,16 +437,124 @@ func (sc *Scheduler) buildStepContextForHandler(ctx context.Context, graph *Exec
 	return digraph.WithStepContext(ctx, stepCtx)
 }
 
+// repeatUntil records the iteration of the node and returns true if the node
+// should be repeated. Otherwise, it returns the error to finish the node with.
+func (sc *Scheduler) repeatUntil(ctx context.Context, node *Node, execErr error) (bool, error) {
+	output, err := node.recordIteration()
+	if err != nil {
+		logger.Error(ctx, "Failed to read output of iteration", "step", node.data.Step.Name, "error", err)
+	}
+
+	policy := node.data.Step.RepeatPolicy
+	met, err := node.repeatUntilMet(ctx, output)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate repeat condition: %w", err)
+	}
+
+	iterations := node.State().Iterations
+	if met {
+		logger.Info(ctx, "Repeat condition met", "step", node.data.Step.Name, "iterations", iterations)
+		if execErr != nil && slices.Contains(policy.Until.ExitCode, node.GetExitCode()) {
+			// the exit code is expected by the condition
+			return false, nil
+		}
+		return false, execErr
+	}
+
+	if policy.Limit > 0 && iterations >= policy.Limit {
+		logger.Info(ctx, "Repeat limit reached", "step", node.data.Step.Name, "limit", policy.Limit)
+		if policy.FailOnLimit {
+			return false, fmt.Errorf("%w: %d", ErrRepeatLimitReached, policy.Limit)
+		}
+		return false, execErr
+	}
+
+	logger.Info(ctx, "Repeat condition not met", "step", node.data.Step.Name, "iterations", iterations, "result", node.State().LastResult)
+	return true, nil
+}
+
+// evalIf evaluates the if expression of the node.
+// The expression can refer to the status, exit code, output and outputs of
+// the other steps, e.g. steps.build.status == "failed".
+func (sc *Scheduler) evalIf(ctx context.Context, graph *ExecutionGraph, node *Node) (bool, error) {
+	expr, err := digraph.ParseExpression(node.data.Step.If)
+	if err != nil {
+		return false, err
+	}
+
+	ctx = sc.setupContext(ctx, graph, node)
+	return expr.Eval(ctx, func(step, field string) (string, error) {
+		ref, err := graph.findStep(step)
+		if err != nil {
+			return "", err
+		}
+		state := ref.State()
+		switch field {
+		case "status":
+			return state.Status.String(), nil
+		case "exitCode":
+			return strconv.Itoa(state.ExitCode), nil
+		case "output":
+			if ref.data.Step.Output == "" {
+				return "", nil
+			}
+			v, _ := ref.getVariable(ref.data.Step.Output)
+			return v.Value(), nil
+		default:
+			if name, ok := strings.CutPrefix(field, "outputs."); ok {
+				return state.Outputs[name], nil
+			}
+			return "", fmt.Errorf("%w: %s", errUnknownStepField, field)
+		}
+	})
+}
+
 func (sc *Scheduler) execNode(ctx context.Context, node *Node) error {
 	if !sc.dry {
+		if err := node.stageArtifacts(ctx, sc.artifactDir); err != nil {
+			node.setError(err)
+			return fmt.Errorf("failed to stage artifacts of step %q: %w", node.data.Step.Name, err)
+		}
 		if err := node.Execute(ctx); err != nil {
 			return fmt.Errorf("failed to execute step %q: %w", node.data.Step.Name, err)
 		}
+		if err := node.collectArtifacts(ctx, sc.artifactDir); err != nil {
+			node.setError(err)
+			return fmt.Errorf("failed to collect artifacts of step %q: %w", node.data.Step.Name, err)
+		}
 	}
 
 	return nil
 }
 
+// cancelMatrixSiblings cancels the other instances of the same matrix step
+// when the failed node has the failFast flag.
+func (sc *Scheduler) cancelMatrixSiblings(ctx context.Context, graph *ExecutionGraph, node *Node) {
+	matrix := node.data.Step.Matrix
+	if matrix == nil || !matrix.FailFast {
+		return
+	}
+	for _, sibling := range graph.Nodes() {
+		if sibling == node || sibling.data.Step.Matrix == nil || sibling.data.Step.Matrix.Step != matrix.Step {
+			continue
+		}
+		switch sibling.State().Status {
+		case NodeStatusNone:
+			logger.Info(ctx, "Canceling matrix step instance", "step", sibling.data.Step.Name, "failed", node.data.Step.Name)
+			sibling.SetStatus(NodeStatusCancel)
+			sibling.setError(errMatrixFailFast)
+
+		case NodeStatusRunning:
+			logger.Info(ctx, "Canceling matrix step instance", "step", sibling.data.Step.Name, "failed", node.data.Step.Name)
+			sibling.Cancel(ctx)
+
+		default:
+			// do nothing
+
+		}
+	}
+}
+
 // Signal sends a signal to the scheduler.
 // for a node with repeat policy, it does not stop the node and
 // wait to finish current run.
@@ -385,8 +578,8 @@ func (sc *Scheduler) Signal(
 			done <- true
 		}()
 
-		for graph.IsRunning() {
-			time.Sleep(sc.pause)
+		if graph.IsRunning() {
+			<-sc.stepsDoneCh
 		}
 	}
 }
@@ -407,6 +600,9 @@ func (sc *Scheduler) Status(g *ExecutionGraph) Status {
 	if !g.IsStarted() {
 		return StatusNone
 	}
+	if sc.isPaused() && !sc.isFinished(g) {
+		return StatusPaused
+	}
 	if g.IsRunning() {
 		return StatusRunning
 	}
@@ -439,6 +635,18 @@ func (sc *Scheduler) isCanceled() bool {
 }
 
 func isReady(ctx context.Context, g *ExecutionGraph, node *Node) bool {
+	switch node.data.Step.Trigger {
+	case "", digraph.TriggerAllSuccess:
+		return isReadyAllSuccess(ctx, g, node)
+	default:
+		return isReadyByTrigger(g, node)
+	}
+}
+
+// isReadyAllSuccess returns true when all of the upstream nodes succeeded, or
+// failed or were skipped with continueOn. Otherwise, the failure or skip is
+// propagated to the node.
+func isReadyAllSuccess(ctx context.Context, g *ExecutionGraph, node *Node) bool {
 	ready := true
 	for _, dep := range g.to[node.id] {
 		dep := g.node(dep)
@@ -447,7 +655,7 @@ func isReady(ctx context.Context, g *ExecutionGraph, node *Node) bool {
 		case NodeStatusSuccess:
 			continue
 
-		case NodeStatusError:
+		case NodeStatusError, NodeStatusTimeout:
 			if dep.shouldContinue(ctx) {
 				continue
 			}
@@ -467,7 +675,7 @@ func isReady(ctx context.Context, g *ExecutionGraph, node *Node) bool {
 			ready = false
 			node.SetStatus(NodeStatusCancel)
 
-		case NodeStatusNone, NodeStatusRunning:
+		case NodeStatusNone, NodeStatusRunning, NodeStatusWaiting:
 			ready = false
 
 		default:
@@ -478,6 +686,76 @@ func isReady(ctx context.Context, g *ExecutionGraph, node *Node) bool {
 	return ready
 }
 
+// isReadyByTrigger returns true when all of the upstream nodes are finished
+// and the trigger rule of the node is met. If the rule can no longer be met,
+// the node is canceled when the rule requires upstream nodes not to fail, or
+// skipped otherwise.
+func isReadyByTrigger(g *ExecutionGraph, node *Node) bool {
+	deps := g.to[node.id]
+	if len(deps) == 0 {
+		return true
+	}
+
+	var succeeded, failed int
+	for _, dep := range deps {
+		switch g.node(dep).State().Status {
+		case NodeStatusNone, NodeStatusRunning, NodeStatusWaiting:
+			return false
+
+		case NodeStatusSuccess:
+			succeeded++
+
+		case NodeStatusError, NodeStatusTimeout, NodeStatusCancel:
+			failed++
+
+		case NodeStatusSkipped:
+			// do nothing
+
+		}
+	}
+
+	switch rule := node.data.Step.Trigger; rule {
+	case digraph.TriggerAllDone:
+		return true
+
+	case digraph.TriggerAllFailed:
+		if failed == len(deps) {
+			return true
+		}
+
+	case digraph.TriggerOneFailed:
+		if failed > 0 {
+			return true
+		}
+
+	case digraph.TriggerOneSuccess:
+		if succeeded > 0 {
+			return true
+		}
+		if failed > 0 {
+			node.SetStatus(NodeStatusCancel)
+			node.setError(errUpstreamFailed)
+			return false
+		}
+		node.SetStatus(NodeStatusSkipped)
+		node.setError(errUpstreamSkipped)
+		return false
+
+	case digraph.TriggerNoneFailed:
+		if failed == 0 {
+			return true
+		}
+		node.SetStatus(NodeStatusCancel)
+		node.setError(errUpstreamFailed)
+		return false
+
+	}
+
+	node.SetStatus(NodeStatusSkipped)
+	node.setError(fmt.Errorf("%w: %s", errTriggerRuleNotMet, node.data.Step.Trigger))
+	return false
+}
+
 func (sc *Scheduler) runHandlerNode(ctx context.Context, graph *ExecutionGraph, node *Node) error {
 	defer func() {
 		node.data.State.FinishedAt = time.Now()
@@ -519,6 +797,10 @@ func (sc *Scheduler) setup(ctx context.Context) (err error) {
 		}
 	}
 
+	if sc.artifactDir == "" {
+		sc.artifactDir = filepath.Join(sc.logDir, "artifacts", sc.requestID)
+	}
+
 	sc.handlers = map[digraph.HandlerType]*Node{}
 	if sc.onExit != nil {
 		sc.handlers[digraph.HandlerOnExit] = &Node{data: NodeData{Step: *sc.onExit}}
@@ -542,22 +824,62 @@ func (sc *Scheduler) setup(ctx context.Context) (err error) {
 func (sc *Scheduler) setCanceled() {
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
+	if sc.canceled == 0 && sc.canceledCh != nil {
+		close(sc.canceledCh)
+	}
 	sc.canceled = 1
 }
 
-func (*Scheduler) runningCount(g *ExecutionGraph) int {
-	count := 0
-	for _, node := range g.Nodes() {
-		if node.State().Status == NodeStatusRunning {
-			count++
+// waitForDAGs waits for the runs of other DAGs until they succeed or the
+// scheduler is canceled.
+func (sc *Scheduler) waitForDAGs(ctx context.Context, waits []digraph.WaitFor) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-sc.canceledCh:
+			cancel()
+		case <-ctx.Done():
 		}
+	}()
+	return digraph.WaitForDAGs(ctx, waits)
+}
+
+// sleep waits for the duration. It returns false if the scheduler is canceled
+// or the context is done in the meantime.
+func (sc *Scheduler) sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-sc.canceledCh:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// cancelRepeat cancels the node that is waiting for the next iteration.
+func (sc *Scheduler) cancelRepeat(ctx context.Context, node *Node) {
+	node.SetStatus(NodeStatusCancel)
+	if err := ctx.Err(); err != nil && !sc.isCanceled() {
+		sc.setLastError(err)
+	}
+}
+
+// wake wakes up the dispatcher without blocking.
+func (sc *Scheduler) wake() {
+	select {
+	case sc.wakeCh <- struct{}{}:
+	default:
 	}
-	return count
 }
 
 func (*Scheduler) isFinished(g *ExecutionGraph) bool {
 	for _, node := range g.Nodes() {
 		if node.State().Status == NodeStatusRunning ||
+			node.State().Status == NodeStatusWaiting ||
 			node.State().Status == NodeStatusNone {
 			return false
 		}
@@ -583,6 +905,9 @@ func (sc *Scheduler) isTimeout(startedAt time.Time) bool {
 }
 
 var (
-	errUpstreamFailed  = fmt.Errorf("upstream failed")
-	errUpstreamSkipped = fmt.Errorf("upstream skipped")
+	errUpstreamFailed    = fmt.Errorf("upstream failed")
+	errUpstreamSkipped   = fmt.Errorf("upstream skipped")
+	errUnknownStepField  = fmt.Errorf("unknown step field")
+	errTriggerRuleNotMet = fmt.Errorf("trigger rule not met")
+	errMatrixFailFast    = fmt.Errorf("canceled by a failed matrix instance")
 )
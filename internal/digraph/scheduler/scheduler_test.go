@@ -1,6 +1,7 @@
 package scheduler_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path"
@@ -13,6 +14,7 @@ import (
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
 	"github.com/dagu-org/dagu/internal/fileutil"
+	"github.com/dagu-org/dagu/internal/logger"
 	"github.com/dagu-org/dagu/internal/test"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -310,6 +312,53 @@ func TestScheduler(t *testing.T) {
 		result.AssertNodeStatus(t, "2", scheduler.NodeStatusCancel)
 		result.AssertNodeStatus(t, "3", scheduler.NodeStatusCancel)
 	})
+	t.Run("StepTimeout", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1: times out
+		// 2: depends on 1 and should be canceled
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 5"), withStepTimeout(time.Second)),
+			successStep("2", "1"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		require.ErrorIs(t, result.Error, scheduler.ErrStepTimeout)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusTimeout)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusCancel)
+	})
+	t.Run("StepTimeoutContinueOn", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 5"), withStepTimeout(time.Second),
+				withContinueOn(digraph.ContinueOn{Timeout: true})),
+			successStep("2", "1"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusTimeout)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+	})
+	t.Run("StepTimeoutRetry", func(t *testing.T) {
+		sc := setup(t)
+
+		// timeouts are retried only when onTimeout is set
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 5"), withStepTimeout(time.Second), withRetryPolicy(1, 0)),
+			newStep("2", withCommand("sleep 5"), withStepTimeout(time.Second), withRetryPolicy(1, 0),
+				func(step *digraph.Step) { step.RetryPolicy.OnTimeout = true }),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusTimeout)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusTimeout)
+		require.Equal(t, 0, result.Node(t, "1").State().RetryCount)
+		require.Equal(t, 1, result.Node(t, "2").State().RetryCount)
+	})
 	t.Run("RetryPolicyFail", func(t *testing.T) {
 		const file = "flag_test_retry_fail"
 
@@ -363,6 +412,275 @@ func TestScheduler(t *testing.T) {
 		result.AssertDoneCount(t, 2) // 1, 2(retry and success)
 		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
 	})
+	t.Run("RetryPolicyExitCode", func(t *testing.T) {
+		sc := setup(t)
+
+		// only the step that exits with a matching code is retried
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("false"), withRetryPolicy(2, 0),
+				func(step *digraph.Step) { step.RetryPolicy.ExitCode = []int{2} }),
+			newStep("2", withCommand(`sh -c "exit 2"`), withRetryPolicy(2, 0),
+				func(step *digraph.Step) { step.RetryPolicy.ExitCode = []int{2} }),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusError)
+		require.Equal(t, 0, result.Node(t, "1").State().RetryCount)
+		require.Equal(t, 2, result.Node(t, "2").State().RetryCount)
+	})
+	t.Run("RetryPolicyOutput", func(t *testing.T) {
+		sc := setup(t)
+
+		// only the step whose output matches the pattern is retried
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("echo fatal error; false"), withRetryPolicy(1, 0),
+				func(step *digraph.Step) { step.RetryPolicy.Output = []string{"re:^temporary"} }),
+			newStep("2", withCommand("echo temporary error; false"), withRetryPolicy(1, 0),
+				func(step *digraph.Step) { step.RetryPolicy.Output = []string{"re:^temporary"} }),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		require.Equal(t, 0, result.Node(t, "1").State().RetryCount)
+		require.Equal(t, 1, result.Node(t, "2").State().RetryCount)
+	})
+	t.Run("RetryPolicyOutputOfLastAttempt", func(t *testing.T) {
+		sc := setup(t)
+
+		// the first attempt matches the pattern, but the second does not
+		graph := sc.newGraph(t,
+			newStep("1",
+				withScript(counterScript(t, `[ $n -eq 1 ] && echo temporary error || echo fatal error; false`)),
+				withRetryPolicy(3, 0),
+				func(step *digraph.Step) { step.RetryPolicy.Output = []string{"re:^temporary"} }),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		require.Equal(t, 1, result.Node(t, "1").State().RetryCount)
+	})
+	t.Run("RetryPolicyBackoff", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand(`sh -c "exit 3"`), withRetryPolicy(3, time.Millisecond*100),
+				func(step *digraph.Step) {
+					step.RetryPolicy.Backoff = 3
+					step.RetryPolicy.MaxInterval = time.Millisecond * 500
+				}),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		// each attempt is recorded in the node state
+		attempts := result.Node(t, "1").State().Attempts
+		require.Len(t, attempts, 4)
+		for i, attempt := range attempts {
+			require.Equal(t, i+1, attempt.Attempt)
+			require.Equal(t, 3, attempt.ExitCode)
+			require.Error(t, attempt.Error)
+		}
+
+		// intervals: 100ms, 300ms, 500ms (capped from 900ms)
+		wait := func(i int) time.Duration {
+			return attempts[i].StartedAt.Sub(attempts[i-1].FinishedAt)
+		}
+		require.GreaterOrEqual(t, wait(1), time.Millisecond*100)
+		require.GreaterOrEqual(t, wait(2), time.Millisecond*300)
+		require.GreaterOrEqual(t, wait(3), time.Millisecond*500)
+		require.Less(t, wait(3), time.Millisecond*900)
+	})
+	t.Run("RetryPolicyCancelDuringInterval", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("false"), withRetryPolicy(3, time.Hour)),
+		)
+
+		go func() {
+			time.Sleep(time.Millisecond * 300) // wait for the retry interval
+			graph.Cancel(t)
+		}()
+
+		startedAt := time.Now()
+		result := graph.Schedule(t, scheduler.StatusCancel)
+
+		require.Less(t, time.Since(startedAt), time.Second*5)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+		require.Equal(t, 1, result.Node(t, "1").State().RetryCount)
+	})
+	t.Run("RetryPolicyTimeoutDuringInterval", func(t *testing.T) {
+		sc := setup(t, withTimeout(time.Millisecond*500))
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("false"), withRetryPolicy(3, time.Hour)),
+			successStep("2", "1"),
+		)
+
+		startedAt := time.Now()
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		require.Less(t, time.Since(startedAt), time.Second*5)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusCancel)
+	})
+	t.Run("RetryPolicyReleasesSlot", func(t *testing.T) {
+		sc := setup(t, withMaxActiveRuns(1))
+
+		// 2 runs while 1 waits for the retry interval
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("false"), withRetryPolicy(1, time.Second)),
+			successStep("2"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		retriedAt := result.Node(t, "1").State().RetriedAt
+		require.False(t, retriedAt.IsZero())
+		require.True(t, result.Node(t, "2").State().FinishedAt.Before(retriedAt))
+	})
+	t.Run("IfExpression", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 -> 2 (if true) ------------> 5 (one_success)
+		//   -> 3 (if false) -> 4 (skip) ->
+		//   -> 6 (if false)
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("echo 12"), withOutput("COUNT")),
+			newStep("2", withDepends("1"), withCommand("true"), withIf("${COUNT} > 10")),
+			newStep("3", withDepends("1"), withCommand("true"), withIf("${COUNT} <= 10")),
+			successStep("4", "3"),
+			newStep("5", withDepends("2", "4"), withCommand("true"),
+				withTrigger(digraph.TriggerOneSuccess)),
+			newStep("6", withDepends("1"), withCommand("true"),
+				withIf(`steps.1.status == "failed" || steps.1.output =~ "^9"`)),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSkipped)
+		result.AssertNodeStatus(t, "4", scheduler.NodeStatusSkipped)
+		result.AssertNodeStatus(t, "5", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "6", scheduler.NodeStatusSkipped)
+	})
+	t.Run("IfExpressionUpstreamFailed", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 (fail) -> 2 (all_done, if failed)
+		//          -> 3 (all_done, if succeeded)
+		//          -> 4 (canceled)
+		graph := sc.newGraph(t,
+			failStep("1"),
+			newStep("2", withDepends("1"), withCommand("true"),
+				withTrigger(digraph.TriggerAllDone), withIf(`steps.1.status == "failed"`)),
+			newStep("3", withDepends("1"), withCommand("true"),
+				withTrigger(digraph.TriggerAllDone), withIf(`steps.1.status == "finished"`)),
+			newStep("4", withDepends("1"), withCommand("true"), withIf(`steps.1.status == "failed"`)),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSkipped)
+		result.AssertNodeStatus(t, "4", scheduler.NodeStatusCancel)
+	})
+	t.Run("IfExpressionError", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("true"), withIf(`"abc" > 1`)),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+	})
+	t.Run("TriggerOneSuccessAllSkipped", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("true"), withIf("false")),
+			newStep("2", withCommand("true"), withIf("false")),
+			newStep("3", withDepends("1", "2"), withCommand("true"),
+				withTrigger(digraph.TriggerOneSuccess)),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSkipped)
+	})
+	t.Run("TriggerOneSuccessFailed", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			failStep("1"),
+			newStep("2", withCommand("true"), withIf("false")),
+			newStep("3", withDepends("1", "2"), withCommand("true"),
+				withTrigger(digraph.TriggerOneSuccess)),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusCancel)
+	})
+	t.Run("TriggerRules", func(t *testing.T) {
+		sc := setup(t)
+
+		// ok (success), ng (failed), skip (skipped)
+		//   -> all_done, all_failed, one_failed, none_failed
+		trigger := func(name string, rule digraph.TriggerRule, depends ...string) digraph.Step {
+			return newStep(name, withDepends(depends...), withCommand("true"), withTrigger(rule))
+		}
+		graph := sc.newGraph(t,
+			successStep("ok"),
+			failStep("ng"),
+			newStep("skip", withCommand("true"), withIf("false")),
+			trigger("all_done", digraph.TriggerAllDone, "ok", "ng", "skip"),
+			trigger("all_failed", digraph.TriggerAllFailed, "ng"),
+			trigger("all_failed_not_met", digraph.TriggerAllFailed, "ok", "ng"),
+			trigger("one_failed", digraph.TriggerOneFailed, "ok", "ng"),
+			trigger("one_failed_not_met", digraph.TriggerOneFailed, "ok", "skip"),
+			trigger("none_failed", digraph.TriggerNoneFailed, "ok", "skip"),
+			trigger("none_failed_not_met", digraph.TriggerNoneFailed, "ok", "ng"),
+			// the skip and cancel are propagated to the downstream steps
+			successStep("after_skip", "all_failed_not_met"),
+			successStep("after_cancel", "none_failed_not_met"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "all_done", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "all_failed", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "all_failed_not_met", scheduler.NodeStatusSkipped)
+		result.AssertNodeStatus(t, "one_failed", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "one_failed_not_met", scheduler.NodeStatusSkipped)
+		result.AssertNodeStatus(t, "none_failed", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "none_failed_not_met", scheduler.NodeStatusCancel)
+		result.AssertNodeStatus(t, "after_skip", scheduler.NodeStatusSkipped)
+		result.AssertNodeStatus(t, "after_cancel", scheduler.NodeStatusCancel)
+	})
+	t.Run("TriggerAllDoneAfterCancel", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 (failed) -> 2 (canceled) -> 3 (all_done)
+		graph := sc.newGraph(t,
+			failStep("1"),
+			successStep("2", "1"),
+			newStep("3", withDepends("2"), withCommand("true"),
+				withTrigger(digraph.TriggerAllDone)),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusCancel)
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSuccess)
+	})
 	t.Run("PreconditionMatch", func(t *testing.T) {
 		sc := setup(t)
 
@@ -510,220 +828,856 @@ func TestScheduler(t *testing.T) {
 
 		result := graph.Schedule(t, scheduler.StatusError)
 
-		result.AssertDoneCount(t, 2)
-		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
-		result.AssertNodeStatus(t, "onFailure", scheduler.NodeStatusSuccess)
+		result.AssertDoneCount(t, 2)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "onFailure", scheduler.NodeStatusSuccess)
+	})
+	t.Run("CancelOnSignal", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 10")),
+		)
+
+		go func() {
+			time.Sleep(time.Millisecond * 100) // wait for step 1 to start
+			graph.Signal(syscall.SIGTERM)
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusCancel)
+
+		result.AssertDoneCount(t, 1)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+	})
+	t.Run("SignalWaitsForSteps", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 10")),
+		)
+
+		done := make(chan bool, 1)
+		go func() {
+			time.Sleep(time.Millisecond * 100) // wait for step 1 to start
+			graph.Scheduler.Signal(graph.Context, graph.ExecutionGraph, syscall.SIGTERM, done, false)
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusCancel)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second * 5):
+			t.Fatal("the signal doesn't return after the steps finished")
+		}
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+	})
+	t.Run("Delay", func(t *testing.T) {
+		sc := setup(t, withDelay(time.Millisecond*300))
+
+		graph := sc.newGraph(t,
+			successStep("1"),
+			successStep("2"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+		startedAt1 := result.Node(t, "1").State().StartedAt
+		startedAt2 := result.Node(t, "2").State().StartedAt
+		// The start times are taken in the goroutines of the steps, so they
+		// can be a little closer than the delay.
+		require.GreaterOrEqual(t, startedAt2.Sub(startedAt1), time.Millisecond*250)
+	})
+	t.Run("CancelDuringDelay", func(t *testing.T) {
+		sc := setup(t, withDelay(time.Hour))
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 10")),
+			successStep("2"),
+		)
+
+		go func() {
+			time.Sleep(time.Millisecond * 300) // wait for step 1 to start
+			graph.Cancel(t)
+		}()
+
+		startedAt := time.Now()
+		result := graph.Schedule(t, scheduler.StatusCancel)
+
+		require.Less(t, time.Since(startedAt), time.Second*5)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusNone)
+	})
+	t.Run("Repeat", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand("sleep 1"),
+				withRepeatPolicy(true, time.Millisecond*500),
+			),
+		)
+
+		go func() {
+			time.Sleep(time.Millisecond * 1750)
+			graph.Cancel(t)
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusCancel)
+
+		// 1 should be repeated 2 times
+		result.AssertDoneCount(t, 2)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+
+		node := result.Node(t, "1")
+		// done count should be 1 because 2nd execution is canceled
+		require.Equal(t, 1, node.State().DoneCount)
+	})
+	t.Run("RepeatFail", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand("false"),
+				withRepeatPolicy(true, time.Millisecond*300),
+			),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		// Done count should be 1 because it failed and not repeated
+		result.AssertDoneCount(t, 1)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+
+		node := result.Node(t, "1")
+		require.Equal(t, 1, node.State().DoneCount)
+	})
+	t.Run("RepeatLimit", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand("echo hello"),
+				withRepeatPolicy(true, time.Millisecond*10),
+				withRepeatUntil(nil, 3, false),
+			),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		state := result.Node(t, "1").State()
+		require.Equal(t, 3, state.Iterations)
+		require.Equal(t, "hello", state.LastResult)
+	})
+	t.Run("RepeatCancelDuringInterval", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand("echo PENDING"),
+				withRepeatPolicy(false, time.Hour),
+				withRepeatUntil(&digraph.RepeatUntil{Output: []string{"DONE"}}, 0, false),
+				func(step *digraph.Step) { step.RepeatPolicy.Backoff = 2 },
+			),
+			successStep("2", "1"),
+		)
+
+		go func() {
+			time.Sleep(time.Millisecond * 300) // wait for the repeat interval
+			graph.Cancel(t)
+		}()
+
+		startedAt := time.Now()
+		result := graph.Schedule(t, scheduler.StatusCancel)
+
+		require.Less(t, time.Since(startedAt), time.Second*5)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusNone)
+		require.Equal(t, 1, result.Node(t, "1").State().Iterations)
+	})
+	t.Run("RepeatTimeoutDuringInterval", func(t *testing.T) {
+		sc := setup(t, withTimeout(time.Millisecond*500))
+
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand("echo hello"),
+				withRepeatPolicy(true, time.Hour),
+			),
+			successStep("2", "1"),
+		)
+
+		startedAt := time.Now()
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		require.Less(t, time.Since(startedAt), time.Second*5)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusCancel)
+	})
+	t.Run("RepeatUntilOutput", func(t *testing.T) {
+		sc := setup(t)
+
+		// PENDING, PENDING, DONE
+		graph := sc.newGraph(t,
+			newStep("1",
+				withScript(counterScript(t, `[ $n -ge 3 ] && echo DONE || echo PENDING`)),
+				withRepeatPolicy(false, time.Millisecond*10),
+				withRepeatUntil(&digraph.RepeatUntil{Output: []string{"re:^DONE$"}}, 10, true),
+			),
+			successStep("2", "1"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+		state := result.Node(t, "1").State()
+		require.Equal(t, 3, state.Iterations)
+		require.Equal(t, "DONE", state.LastResult)
+	})
+	t.Run("RepeatUntilExitCode", func(t *testing.T) {
+		sc := setup(t)
+
+		// exit 0, 0, 3 (expected)
+		graph := sc.newGraph(t,
+			newStep("1",
+				withScript(counterScript(t, `[ $n -ge 3 ] && exit 3; echo $n`)),
+				withRepeatPolicy(false, time.Millisecond*10),
+				withRepeatUntil(&digraph.RepeatUntil{ExitCode: []int{3}}, 0, false),
+			),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		require.Equal(t, 3, result.Node(t, "1").State().Iterations)
+	})
+	t.Run("RepeatUntilCondition", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1",
+				withScript(counterScript(t, `[ $n -ge 2 ] && echo DONE || echo RUNNING`)),
+				withOutput("JOB_STATUS"),
+				withRepeatPolicy(false, time.Millisecond*10),
+				withRepeatUntil(&digraph.RepeatUntil{
+					Condition: &digraph.Condition{Condition: "${JOB_STATUS}", Expected: "DONE"},
+				}, 10, true),
+			),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		require.Equal(t, 2, result.Node(t, "1").State().Iterations)
+	})
+	t.Run("RepeatUntilFailOnLimit", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand("echo PENDING"),
+				withRepeatPolicy(false, time.Millisecond*10),
+				withRepeatUntil(&digraph.RepeatUntil{Output: []string{"DONE"}}, 3, true),
+			),
+			successStep("2", "1"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusCancel)
+		state := result.Node(t, "1").State()
+		require.Equal(t, 3, state.Iterations)
+		require.Equal(t, "PENDING", state.LastResult)
+		require.ErrorIs(t, state.Error, scheduler.ErrRepeatLimitReached)
+	})
+	t.Run("StopRepetitiveTaskGracefully", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand("sleep 1"),
+				withRepeatPolicy(true, time.Millisecond*300),
+			),
+		)
+
+		done := make(chan struct{})
+		go func() {
+			time.Sleep(time.Millisecond * 100)
+			graph.Signal(syscall.SIGTERM)
+			close(done)
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+		<-done
+
+		result.AssertDoneCount(t, 1)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+	})
+	t.Run("NodeSetupFailure", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withWorkingDir("/nonexistent"),
+				withScript("echo 1"),
+			),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertDoneCount(t, 1)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+
+		require.Contains(t, result.Error.Error(), "failed to setup script")
+	})
+	t.Run("NodeTeardownFailure", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 1")),
+		)
+
+		nodes := graph.Nodes()
+		go func() {
+			time.Sleep(time.Millisecond * 300)
+			_ = nodes[0].CloseLog()
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		// file already closed
+		require.Error(t, result.Error)
+
+		result.AssertDoneCount(t, 1)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		require.Contains(t, result.Error.Error(), "file already closed")
+	})
+	t.Run("OutputVariables", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1: echo hello > OUT
+		// 2: echo $OUT > RESULT
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("echo hello"), withOutput("OUT")),
+			newStep("2", withCommand("echo $OUT"), withDepends("1"), withOutput("RESULT")),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertDoneCount(t, 2)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+
+		node := result.Node(t, "2")
+
+		// check if RESULT variable is set to "hello"
+		output, ok := node.Data().Step.OutputVariables.Load("RESULT")
+		require.True(t, ok, "output variable not found")
+		require.Equal(t, "RESULT=hello", output, "expected output %q, got %q", "hello", output)
+	})
+	t.Run("OutputInheritance", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1: echo hello > OUT
+		// 2: echo world > OUT2 (depends on 1)
+		// 3: echo $OUT $OUT2 > RESULT (depends on 2)
+		// RESULT should be "hello world"
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("echo hello"), withOutput("OUT")),
+			newStep("2", withCommand("echo world"), withOutput("OUT2"), withDepends("1")),
+			newStep("3", withCommand("echo $OUT $OUT2"), withDepends("2"), withOutput("RESULT")),
+			newStep("4", withCommand("sleep 1")),
+			// 5 should not have reference to OUT or OUT2
+			newStep("5", withCommand("echo $OUT $OUT2"), withDepends("4"), withOutput("RESULT2")),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertDoneCount(t, 5)
+
+		node := result.Node(t, "3")
+		output, _ := node.Data().Step.OutputVariables.Load("RESULT")
+		require.Equal(t, "RESULT=hello world", output, "expected output %q, got %q", "hello world", output)
+
+		node2 := result.Node(t, "5")
+		output2, _ := node2.Data().Step.OutputVariables.Load("RESULT2")
+		require.Equal(t, "RESULT2=", output2, "expected output %q, got %q", "", output)
+	})
+	t.Run("OutputJSONReference", func(t *testing.T) {
+		sc := setup(t)
+
+		jsonData := `{"key": "value"}`
+		graph := sc.newGraph(t,
+			newStep("1", withCommand(fmt.Sprintf("echo '%s'", jsonData)), withOutput("OUT")),
+			newStep("2", withCommand("echo ${OUT.key}"), withDepends("1"), withOutput("RESULT")),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertDoneCount(t, 2)
+
+		// check if RESULT variable is set to "value"
+		node := result.Node(t, "2")
+
+		output, _ := node.Data().Step.OutputVariables.Load("RESULT")
+		require.Equal(t, "RESULT=value", output, "expected output %q, got %q", "value", output)
+	})
+	t.Run("StepOutputs", func(t *testing.T) {
+		sc := setup(t)
+
+		file := filepath.Join(t.TempDir(), "report.txt")
+		require.NoError(t, os.WriteFile(file, []byte("all green\n"), 0600))
+
+		jsonData := `{"version": "1.2.3", "items": [{"name": "a"}], "commit": "abc123"}`
+		graph := sc.newGraph(t,
+			newStep("build", withCommand(fmt.Sprintf("echo '%s'", jsonData)), withOutputs(
+				digraph.StepOutput{Name: "version", Path: "$.version"},
+				digraph.StepOutput{Name: "item", Path: ".items[0].name"},
+				digraph.StepOutput{Name: "items", Path: ".items"},
+				digraph.StepOutput{Name: "commit", Regex: `"commit": "([a-f0-9]+)"`},
+				digraph.StepOutput{Name: "report", File: file},
+			)),
+			newStep("2", withCommand("echo ${steps.build.outputs.version}-${steps.build.outputs.commit}"), withDepends("build"), withOutput("RESULT")),
+			newStep("3", withCommand("true"), withDepends("build"), withIf(`steps.build.outputs.item == "a"`)),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertDoneCount(t, 3)
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSuccess)
+
+		require.Equal(t, map[string]string{
+			"version": "1.2.3",
+			"item":    "a",
+			"items":   `[{"name":"a"}]`,
+			"commit":  "abc123",
+			"report":  "all green",
+		}, result.Node(t, "build").State().Outputs)
+
+		output, _ := result.Node(t, "2").Data().Step.OutputVariables.Load("RESULT")
+		require.Equal(t, "RESULT=1.2.3-abc123", output)
+	})
+	t.Run("StepOutputsNotFound", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("echo not-a-json"), withOutputs(
+				digraph.StepOutput{Name: "version", Path: ".version"},
+			)),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		require.ErrorIs(t, result.Node(t, "1").State().Error, scheduler.ErrOutputExtraction)
+	})
+	t.Run("Artifacts", func(t *testing.T) {
+		artifactDir := filepath.Join(t.TempDir(), "artifacts")
+		sc := setup(t, func(cfg *scheduler.Config) { cfg.ArtifactDir = artifactDir })
+
+		buildDir, deployDir := t.TempDir(), t.TempDir()
+		graph := sc.newGraph(t,
+			newStep("build",
+				withWorkingDir(buildDir),
+				withScript("mkdir -p dist/lib && echo app > dist/app.txt && echo lib > dist/lib/lib.txt"),
+				withProduces("dist"),
+			),
+			newStep("deploy",
+				withWorkingDir(deployDir),
+				withCommand("cat dist/app.txt"),
+				withDepends("build"),
+				withArtifacts(digraph.ArtifactRef{From: "build", Path: "dist"}),
+				withOutput("OUT"),
+			),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertDoneCount(t, 2)
+		require.Equal(t, []string{"dist/app.txt", "dist/lib/lib.txt"}, result.Node(t, "build").State().Artifacts)
+		require.FileExists(t, filepath.Join(scheduler.ArtifactDir(artifactDir, "build"), "dist", "lib", "lib.txt"))
+		require.FileExists(t, filepath.Join(deployDir, "dist", "lib", "lib.txt"))
+
+		output, _ := result.Node(t, "deploy").Data().Step.OutputVariables.Load("OUT")
+		require.Equal(t, "OUT=app", output)
+	})
+	t.Run("ArtifactNotFound", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withWorkingDir(t.TempDir()), withCommand("true"), withProduces("missing.txt")),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		require.ErrorIs(t, result.Node(t, "1").State().Error, scheduler.ErrArtifactNotFound)
+	})
+	t.Run("WaitForDAGRun", func(t *testing.T) {
+		sc := setup(t)
+		sc.DBClient = &mockDBClient{statuses: map[string][]*digraph.Status{
+			"ingest": {{RequestID: "req", Succeeded: true, StartedAt: time.Now()}},
+		}}
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("true"), withWaitFor(digraph.WaitFor{
+				DAG: "ingest", Since: "1h", Timeout: time.Second, Interval: 10 * time.Millisecond,
+			})),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+	})
+	t.Run("WaitForDAGRunReleasesSlot", func(t *testing.T) {
+		sc := setup(t, withMaxActiveRuns(1))
+		sc.DBClient = &mockDBClient{statuses: map[string][]*digraph.Status{
+			"ingest": {{RequestID: "req", Succeeded: true, StartedAt: time.Now()}},
+		}}
+
+		// 2 and 3 run while 1 waits for the run of missing
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("true"), withWaitFor(digraph.WaitFor{
+				DAG: "missing", Since: "1h", Timeout: time.Second, Interval: 10 * time.Millisecond,
+				OnTimeout: digraph.WaitForOnTimeoutSkip,
+			})),
+			newStep("2", withCommand("true"), withWaitFor(digraph.WaitFor{
+				DAG: "ingest", Since: "1h", Timeout: time.Second, Interval: 10 * time.Millisecond,
+			})),
+			successStep("3"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSkipped)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSuccess)
+		skippedAt := result.Node(t, "1").State().FinishedAt
+		require.True(t, result.Node(t, "2").State().FinishedAt.Before(skippedAt))
+		require.True(t, result.Node(t, "3").State().FinishedAt.Before(skippedAt))
+	})
+	t.Run("WaitForDAGRunTimeoutSkip", func(t *testing.T) {
+		sc := setup(t)
+		sc.DBClient = &mockDBClient{}
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("true"), withWaitFor(digraph.WaitFor{
+				DAG: "ingest", Since: "1h", Timeout: 50 * time.Millisecond, Interval: 10 * time.Millisecond,
+				OnTimeout: digraph.WaitForOnTimeoutSkip,
+			})),
+			successStep("2", "1"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSkipped)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSkipped)
+	})
+	t.Run("WaitForDAGRunTimeoutFail", func(t *testing.T) {
+		sc := setup(t, func(cfg *scheduler.Config) {
+			cfg.WaitFor = []digraph.WaitFor{{
+				DAG: "ingest", RequestID: "req", Timeout: 50 * time.Millisecond, Interval: 10 * time.Millisecond,
+				OnTimeout: digraph.WaitForOnTimeoutFail,
+			}}
+		})
+		sc.DBClient = &mockDBClient{}
+
+		graph := sc.newGraph(t,
+			successStep("1"),
+			successStep("2", "1"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSkipped)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSkipped)
+		require.ErrorIs(t, result.Error, digraph.ErrWaitForTimeout)
 	})
-	t.Run("CancelOnSignal", func(t *testing.T) {
+	t.Run("ApprovalApproved", func(t *testing.T) {
 		sc := setup(t)
 
 		graph := sc.newGraph(t,
-			newStep("1", withCommand("sleep 10")),
+			newStep("approve", withApproval(digraph.Approval{OnTimeout: digraph.ApprovalOnTimeoutReject})),
+			successStep("2", "approve"),
 		)
 
 		go func() {
-			time.Sleep(time.Millisecond * 100) // wait for step 1 to start
-			graph.Signal(syscall.SIGTERM)
+			require.Eventually(t, func() bool {
+				return graph.Scheduler.Approve(graph.ExecutionGraph, "approve", scheduler.ApprovalDecision{
+					Approved: true, User: "alice", Comment: "LGTM",
+				}) == nil
+			}, 5*time.Second, 10*time.Millisecond)
 		}()
 
-		result := graph.Schedule(t, scheduler.StatusCancel)
+		result := graph.Schedule(t, scheduler.StatusSuccess)
 
-		result.AssertDoneCount(t, 1)
-		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+		result.AssertNodeStatus(t, "approve", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+
+		approval := result.Node(t, "approve").State().Approval
+		require.NotNil(t, approval)
+		require.True(t, approval.Approved)
+		require.Equal(t, "alice", approval.User)
+		require.Equal(t, "LGTM", approval.Comment)
 	})
-	t.Run("Repeat", func(t *testing.T) {
+	t.Run("ApprovalRejected", func(t *testing.T) {
 		sc := setup(t)
 
 		graph := sc.newGraph(t,
-			newStep("1",
-				withCommand("sleep 1"),
-				withRepeatPolicy(true, time.Millisecond*500),
-			),
+			newStep("approve", withApproval(digraph.Approval{OnTimeout: digraph.ApprovalOnTimeoutReject})),
+			successStep("2", "approve"),
 		)
 
 		go func() {
-			time.Sleep(time.Millisecond * 1750)
-			graph.Cancel(t)
+			require.Eventually(t, func() bool {
+				return graph.Scheduler.Approve(graph.ExecutionGraph, "approve", scheduler.ApprovalDecision{
+					Approved: false, User: "bob", Comment: "not now",
+				}) == nil
+			}, 5*time.Second, 10*time.Millisecond)
 		}()
 
-		result := graph.Schedule(t, scheduler.StatusCancel)
-
-		// 1 should be repeated 2 times
-		result.AssertDoneCount(t, 2)
-		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+		result := graph.Schedule(t, scheduler.StatusError)
 
-		node := result.Node(t, "1")
-		// done count should be 1 because 2nd execution is canceled
-		require.Equal(t, 1, node.State().DoneCount)
+		result.AssertNodeStatus(t, "approve", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusCancel)
+		require.ErrorIs(t, result.Error, scheduler.ErrApprovalRejected)
+		require.Equal(t, "bob", result.Node(t, "approve").State().Approval.User)
 	})
-	t.Run("RepeatFail", func(t *testing.T) {
+	t.Run("ApprovalTimeout", func(t *testing.T) {
 		sc := setup(t)
 
 		graph := sc.newGraph(t,
-			newStep("1",
-				withCommand("false"),
-				withRepeatPolicy(true, time.Millisecond*300),
-			),
+			newStep("approve", withApproval(digraph.Approval{
+				Timeout: 50 * time.Millisecond, OnTimeout: digraph.ApprovalOnTimeoutReject,
+			})),
+			successStep("2", "approve"),
 		)
 
 		result := graph.Schedule(t, scheduler.StatusError)
 
-		// Done count should be 1 because it failed and not repeated
-		result.AssertDoneCount(t, 1)
-		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
-
-		node := result.Node(t, "1")
-		require.Equal(t, 1, node.State().DoneCount)
+		result.AssertNodeStatus(t, "approve", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusCancel)
+		require.ErrorIs(t, result.Error, scheduler.ErrApprovalTimeout)
+		require.True(t, result.Node(t, "approve").State().Approval.Timeout)
 	})
-	t.Run("StopRepetitiveTaskGracefully", func(t *testing.T) {
+	t.Run("ApprovalRejectedWithoutUser", func(t *testing.T) {
 		sc := setup(t)
 
 		graph := sc.newGraph(t,
-			newStep("1",
-				withCommand("sleep 1"),
-				withRepeatPolicy(true, time.Millisecond*300),
-			),
+			newStep("approve", withApproval(digraph.Approval{
+				Timeout: time.Minute, OnTimeout: digraph.ApprovalOnTimeoutApprove,
+			})),
+			successStep("2", "approve"),
 		)
 
-		done := make(chan struct{})
 		go func() {
-			time.Sleep(time.Millisecond * 100)
-			graph.Signal(syscall.SIGTERM)
-			close(done)
+			require.Eventually(t, func() bool {
+				return graph.Scheduler.Approve(graph.ExecutionGraph, "approve", scheduler.ApprovalDecision{
+					Approved: false,
+				}) == nil
+			}, 5*time.Second, 10*time.Millisecond)
 		}()
 
-		result := graph.Schedule(t, scheduler.StatusSuccess)
-		<-done
+		result := graph.Schedule(t, scheduler.StatusError)
 
-		result.AssertDoneCount(t, 1)
-		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "approve", scheduler.NodeStatusError)
+		require.ErrorIs(t, result.Error, scheduler.ErrApprovalRejected)
+		require.NotErrorIs(t, result.Error, scheduler.ErrApprovalTimeout)
+		require.False(t, result.Node(t, "approve").State().Approval.Timeout)
 	})
-	t.Run("NodeSetupFailure", func(t *testing.T) {
+	t.Run("ApprovalTimeoutApprove", func(t *testing.T) {
 		sc := setup(t)
 
 		graph := sc.newGraph(t,
-			newStep("1", withWorkingDir("/nonexistent"),
-				withScript("echo 1"),
-			),
+			newStep("approve", withApproval(digraph.Approval{
+				Timeout: 50 * time.Millisecond, OnTimeout: digraph.ApprovalOnTimeoutApprove,
+			})),
+			successStep("2", "approve"),
 		)
 
-		result := graph.Schedule(t, scheduler.StatusError)
+		result := graph.Schedule(t, scheduler.StatusSuccess)
 
-		result.AssertDoneCount(t, 1)
-		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "approve", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+		require.True(t, result.Node(t, "approve").State().Approval.Approved)
+	})
+	t.Run("ApprovalNotWaiting", func(t *testing.T) {
+		sc := setup(t)
 
-		require.Contains(t, result.Error.Error(), "failed to setup script")
+		graph := sc.newGraph(t, successStep("1"))
+		graph.Schedule(t, scheduler.StatusSuccess)
+
+		err := graph.Scheduler.Approve(graph.ExecutionGraph, "1", scheduler.ApprovalDecision{Approved: true})
+		require.ErrorIs(t, err, scheduler.ErrNotWaitingForApproval)
 	})
-	t.Run("NodeTeardownFailure", func(t *testing.T) {
+	t.Run("PauseAndResume", func(t *testing.T) {
 		sc := setup(t)
 
 		graph := sc.newGraph(t,
-			newStep("1", withCommand("sleep 1")),
+			newStep("1", withCommand("sleep 0.3")),
+			successStep("2", "1"),
 		)
 
-		nodes := graph.Nodes()
 		go func() {
-			time.Sleep(time.Millisecond * 300)
-			_ = nodes[0].CloseLog()
+			// Pause while the first step is running.
+			require.Eventually(t, func() bool {
+				return graph.Node(t, "1").State().Status == scheduler.NodeStatusRunning
+			}, 5*time.Second, 10*time.Millisecond)
+			require.NoError(t, graph.Scheduler.Pause())
+			require.ErrorIs(t, graph.Scheduler.Pause(), scheduler.ErrAlreadyPaused)
+
+			// The running step finishes, but the next step is not launched.
+			require.Eventually(t, func() bool {
+				return graph.Node(t, "1").State().Status == scheduler.NodeStatusSuccess
+			}, 5*time.Second, 10*time.Millisecond)
+			time.Sleep(300 * time.Millisecond)
+			require.Equal(t, scheduler.NodeStatusNone, graph.Node(t, "2").State().Status)
+			require.Equal(t, scheduler.StatusPaused, graph.Scheduler.Status(graph.ExecutionGraph))
+
+			require.NoError(t, graph.Scheduler.Resume())
 		}()
 
-		result := graph.Schedule(t, scheduler.StatusError)
+		result := graph.Schedule(t, scheduler.StatusSuccess)
 
-		// file already closed
-		require.Error(t, result.Error)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+	})
+	t.Run("ResumeNotPaused", func(t *testing.T) {
+		sc := setup(t)
 
-		result.AssertDoneCount(t, 1)
-		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
-		require.Contains(t, result.Error.Error(), "file already closed")
+		graph := sc.newGraph(t, successStep("1"))
+		require.ErrorIs(t, graph.Scheduler.Resume(), scheduler.ErrNotPaused)
 	})
-	t.Run("OutputVariables", func(t *testing.T) {
+	t.Run("HandlingJSONWithSpecialChars", func(t *testing.T) {
 		sc := setup(t)
 
-		// 1: echo hello > OUT
-		// 2: echo $OUT > RESULT
+		jsonData := `{\n\t"key": "value"\n}`
 		graph := sc.newGraph(t,
-			newStep("1", withCommand("echo hello"), withOutput("OUT")),
-			newStep("2", withCommand("echo $OUT"), withDepends("1"), withOutput("RESULT")),
+			newStep("1", withCommand(fmt.Sprintf("echo '%s'", jsonData)), withOutput("OUT")),
+			newStep("2", withCommand("echo '${OUT.key}'"), withDepends("1"), withOutput("RESULT")),
 		)
 
 		result := graph.Schedule(t, scheduler.StatusSuccess)
 
 		result.AssertDoneCount(t, 2)
-		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
-		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
 
+		// check if RESULT variable is set to "value"
 		node := result.Node(t, "2")
 
-		// check if RESULT variable is set to "hello"
-		output, ok := node.Data().Step.OutputVariables.Load("RESULT")
-		require.True(t, ok, "output variable not found")
-		require.Equal(t, "RESULT=hello", output, "expected output %q, got %q", "hello", output)
+		output, _ := node.Data().Step.OutputVariables.Load("RESULT")
+		require.Equal(t, "RESULT=value", output, "expected output %q, got %q", "value", output)
 	})
-	t.Run("OutputInheritance", func(t *testing.T) {
-		sc := setup(t)
+	t.Run("Foreach", func(t *testing.T) {
+		sc := setup(t, withMaxActiveRuns(1))
 
-		// 1: echo hello > OUT
-		// 2: echo world > OUT2 (depends on 1)
-		// 3: echo $OUT $OUT2 > RESULT (depends on 2)
-		// RESULT should be "hello world"
+		// 1: echo $ITEM for each item > OUT
+		// 2: echo $OUT > RESULT (depends on 1)
 		graph := sc.newGraph(t,
-			newStep("1", withCommand("echo hello"), withOutput("OUT")),
-			newStep("2", withCommand("echo world"), withOutput("OUT2"), withDepends("1")),
-			newStep("3", withCommand("echo $OUT $OUT2"), withDepends("2"), withOutput("RESULT")),
-			newStep("4", withCommand("sleep 1")),
-			// 5 should not have reference to OUT or OUT2
-			newStep("5", withCommand("echo $OUT $OUT2"), withDepends("4"), withOutput("RESULT2")),
+			newStep("1", withCommand("echo $ITEM"), withForeach(`["a", "b", "c"]`), withOutput("OUT")),
+			newStep("2", withCommand("echo $OUT"), withDepends("1"), withOutput("RESULT")),
 		)
 
 		result := graph.Schedule(t, scheduler.StatusSuccess)
 
+		// 3 children, the foreach step and the downstream step
 		result.AssertDoneCount(t, 5)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "1[0]", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "1[1]", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "1[2]", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
 
-		node := result.Node(t, "3")
-		output, _ := node.Data().Step.OutputVariables.Load("RESULT")
-		require.Equal(t, "RESULT=hello world", output, "expected output %q, got %q", "hello world", output)
+		output, _ := result.Node(t, "1[1]").Data().Step.OutputVariables.Load("OUT")
+		require.Equal(t, "OUT=b", output, "unexpected output %q", output)
 
-		node2 := result.Node(t, "5")
-		output2, _ := node2.Data().Step.OutputVariables.Load("RESULT2")
-		require.Equal(t, "RESULT2=", output2, "expected output %q, got %q", "", output)
+		// outputs of the children are collected into a JSON array
+		output, _ = result.Node(t, "1").Data().Step.OutputVariables.Load("OUT")
+		require.Equal(t, `OUT=["a","b","c"]`, output, "unexpected output %q", output)
+
+		output, _ = result.Node(t, "2").Data().Step.OutputVariables.Load("RESULT")
+		require.Equal(t, "RESULT=[a,b,c]", output, "unexpected output %q", output)
 	})
-	t.Run("OutputJSONReference", func(t *testing.T) {
+	t.Run("ForeachFromOutput", func(t *testing.T) {
 		sc := setup(t)
 
-		jsonData := `{"key": "value"}`
+		// 1: printf 'x\ny\n' > ITEMS
+		// 2: echo $ITEM for each item of $ITEMS (depends on 1)
 		graph := sc.newGraph(t,
-			newStep("1", withCommand(fmt.Sprintf("echo '%s'", jsonData)), withOutput("OUT")),
-			newStep("2", withCommand("echo ${OUT.key}"), withDepends("1"), withOutput("RESULT")),
+			newStep("1", withScript("printf 'x\\ny\\n'"), withOutput("ITEMS")),
+			newStep("2", withCommand("echo $ITEM"), withForeach("${ITEMS}"), withDepends("1"), withOutput("OUT")),
 		)
 
 		result := graph.Schedule(t, scheduler.StatusSuccess)
 
-		result.AssertDoneCount(t, 2)
+		result.AssertDoneCount(t, 4)
+		result.AssertNodeStatus(t, "2[0]", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2[1]", scheduler.NodeStatusSuccess)
 
-		// check if RESULT variable is set to "value"
-		node := result.Node(t, "2")
+		output, _ := result.Node(t, "2").Data().Step.OutputVariables.Load("OUT")
+		require.Equal(t, `OUT=["x","y"]`, output, "unexpected output %q", output)
+	})
+	t.Run("ForeachWithFailure", func(t *testing.T) {
+		sc := setup(t)
 
-		output, _ := node.Data().Step.OutputVariables.Load("RESULT")
-		require.Equal(t, "RESULT=value", output, "expected output %q, got %q", "value", output)
+		// 1: fails when the item is 2
+		// 2: depends on 1 and should be canceled
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("test $ITEM != 2"), withForeach("[1, 2, 3]")),
+			successStep("2", "1"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "1[0]", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "1[1]", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "1[2]", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusCancel)
 	})
-	t.Run("HandlingJSONWithSpecialChars", func(t *testing.T) {
+	t.Run("ForeachEmpty", func(t *testing.T) {
 		sc := setup(t)
 
-		jsonData := `{\n\t"key": "value"\n}`
 		graph := sc.newGraph(t,
-			newStep("1", withCommand(fmt.Sprintf("echo '%s'", jsonData)), withOutput("OUT")),
-			newStep("2", withCommand("echo '${OUT.key}'"), withDepends("1"), withOutput("RESULT")),
+			newStep("1", withCommand("echo $ITEM"), withForeach("[]"), withOutput("OUT")),
+			successStep("2", "1"),
 		)
 
 		result := graph.Schedule(t, scheduler.StatusSuccess)
 
 		result.AssertDoneCount(t, 2)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+	})
+	t.Run("MatrixValues", func(t *testing.T) {
+		sc := setup(t)
 
-		// check if RESULT variable is set to "value"
-		node := result.Node(t, "2")
+		graph := sc.newGraph(t,
+			newStep("1[os=linux,version=1]", withCommand("echo $os-$version"), withOutput("OUT"),
+				withMatrix("1", map[string]string{"os": "linux", "version": "1"}, false)),
+		)
 
-		output, _ := node.Data().Step.OutputVariables.Load("RESULT")
-		require.Equal(t, "RESULT=value", output, "expected output %q, got %q", "value", output)
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		output, _ := result.Node(t, "1[os=linux,version=1]").Data().Step.OutputVariables.Load("OUT")
+		require.Equal(t, "OUT=linux-1", output, "unexpected output %q", output)
+	})
+	t.Run("MatrixFailFast", func(t *testing.T) {
+		sc := setup(t, withMaxActiveRuns(2))
+
+		// 1[v=1] fails and the other instances should be canceled
+		graph := sc.newGraph(t,
+			newStep("1[v=1]", withCommand("false"), withMatrix("1", map[string]string{"v": "1"}, true)),
+			newStep("1[v=2]", withCommand("sleep 3"), withMatrix("1", map[string]string{"v": "2"}, true)),
+			newStep("1[v=3]", withCommand("true"), withMatrix("1", map[string]string{"v": "3"}, true)),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "1[v=1]", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "1[v=2]", scheduler.NodeStatusCancel)
+		result.AssertNodeStatus(t, "1[v=3]", scheduler.NodeStatusCancel)
 	})
 	t.Run("SpecialVars_DAG_EXECUTION_LOG_PATH", func(t *testing.T) {
 		sc := setup(t)
@@ -811,6 +1765,76 @@ func TestScheduler(t *testing.T) {
 	})
 }
 
+func BenchmarkSchedule(b *testing.B) {
+	const size = 1000
+
+	// Each step depends on the previous one.
+	chain := make([]digraph.Step, size)
+	for i := range chain {
+		chain[i] = successStep(fmt.Sprintf("%d", i))
+		if i > 0 {
+			chain[i].Depends = []string{fmt.Sprintf("%d", i-1)}
+		}
+	}
+
+	// All of the steps are independent.
+	wide := make([]digraph.Step, size)
+	for i := range wide {
+		wide[i] = successStep(fmt.Sprintf("%d", i))
+	}
+
+	// 10 layers of 100 steps, each of which depends on two steps of the
+	// previous layer.
+	const width = 100
+	layered := make([]digraph.Step, size)
+	for i := range layered {
+		layered[i] = successStep(fmt.Sprintf("%d", i))
+		if i >= width {
+			prev := i - width
+			layered[i].Depends = []string{
+				fmt.Sprintf("%d", prev),
+				fmt.Sprintf("%d", prev-prev%width+(prev+1)%width),
+			}
+		}
+	}
+
+	benchmarks := []struct {
+		name          string
+		steps         []digraph.Step
+		maxActiveRuns int
+	}{
+		{name: "Chain", steps: chain},
+		{name: "Wide", steps: wide},
+		{name: "WideMaxActiveRuns", steps: wide, maxActiveRuns: 10},
+		{name: "Layered", steps: layered},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			logDir := b.TempDir()
+			dag := &digraph.DAG{Name: "bench_dag"}
+			baseCtx := logger.WithLogger(context.Background(), logger.NewLogger(logger.WithQuiet()))
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				graph, err := scheduler.NewExecutionGraph(bm.steps...)
+				require.NoError(b, err)
+				reqID := uuid.Must(uuid.NewRandom()).String()
+				sc := scheduler.New(&scheduler.Config{
+					LogDir:        logDir,
+					ReqID:         reqID,
+					Dry:           true,
+					MaxActiveRuns: bm.maxActiveRuns,
+				})
+				ctx := digraph.NewContext(baseCtx, dag, nil, reqID, "")
+				b.StartTimer()
+
+				require.NoError(b, sc.Schedule(ctx, graph, nil))
+				require.Equal(b, scheduler.StatusSuccess, sc.Status(graph))
+			}
+		})
+	}
+}
+
 func successStep(name string, depends ...string) digraph.Step {
 	return newStep(name, withDepends(depends...), withCommand("true"))
 }
@@ -833,6 +1857,24 @@ func withContinueOn(c digraph.ContinueOn) stepOption {
 	}
 }
 
+func withIf(expr string) stepOption {
+	return func(step *digraph.Step) {
+		step.If = expr
+	}
+}
+
+func withTrigger(rule digraph.TriggerRule) stepOption {
+	return func(step *digraph.Step) {
+		step.Trigger = rule
+	}
+}
+
+func withStepTimeout(d time.Duration) stepOption {
+	return func(step *digraph.Step) {
+		step.Timeout = d
+	}
+}
+
 func withRetryPolicy(limit int, interval time.Duration) stepOption {
 	return func(step *digraph.Step) {
 		step.RetryPolicy.Limit = limit
@@ -847,6 +1889,23 @@ func withRepeatPolicy(repeat bool, interval time.Duration) stepOption {
 	}
 }
 
+func withRepeatUntil(until *digraph.RepeatUntil, limit int, failOnLimit bool) stepOption {
+	return func(step *digraph.Step) {
+		step.RepeatPolicy.Repeat = true
+		step.RepeatPolicy.Until = until
+		step.RepeatPolicy.Limit = limit
+		step.RepeatPolicy.FailOnLimit = failOnLimit
+	}
+}
+
+// counterScript returns a script that increments a counter in a temporary
+// file as $n on each execution and then runs the body.
+func counterScript(t *testing.T, body string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "counter")
+	return fmt.Sprintf("n=$(cat %[1]s 2>/dev/null || echo 0)\nn=$((n+1))\necho $n > %[1]s\n%[2]s", file, body)
+}
+
 func withPrecondition(condition digraph.Condition) stepOption {
 	return func(step *digraph.Step) {
 		step.Preconditions = []digraph.Condition{condition}
@@ -865,12 +1924,54 @@ func withWorkingDir(dir string) stepOption {
 	}
 }
 
+func withForeach(items string) stepOption {
+	return func(step *digraph.Step) {
+		step.Foreach = items
+	}
+}
+
+func withMatrix(name string, values map[string]string, failFast bool) stepOption {
+	return func(step *digraph.Step) {
+		step.Matrix = &digraph.MatrixInstance{Step: name, Values: values, FailFast: failFast}
+	}
+}
+
 func withOutput(output string) stepOption {
 	return func(step *digraph.Step) {
 		step.Output = output
 	}
 }
 
+func withOutputs(outputs ...digraph.StepOutput) stepOption {
+	return func(step *digraph.Step) {
+		step.Outputs = outputs
+	}
+}
+
+func withProduces(patterns ...string) stepOption {
+	return func(step *digraph.Step) {
+		step.Produces = patterns
+	}
+}
+
+func withWaitFor(waits ...digraph.WaitFor) stepOption {
+	return func(step *digraph.Step) {
+		step.WaitFor = waits
+	}
+}
+
+func withApproval(approval digraph.Approval) stepOption {
+	return func(step *digraph.Step) {
+		step.Approval = &approval
+	}
+}
+
+func withArtifacts(refs ...digraph.ArtifactRef) stepOption {
+	return func(step *digraph.Step) {
+		step.Artifacts = refs
+	}
+}
+
 func withCommand(command string) stepOption {
 	return func(step *digraph.Step) {
 		cmd, args, err := cmdutil.SplitCommand(command)
@@ -897,6 +1998,29 @@ type testHelper struct {
 
 	Scheduler *scheduler.Scheduler
 	Config    *scheduler.Config
+	DBClient  digraph.DBClient
+}
+
+// mockDBClient returns the statuses of the DAGs to wait for.
+type mockDBClient struct {
+	statuses map[string][]*digraph.Status
+}
+
+func (m *mockDBClient) GetDAG(_ context.Context, name string) (*digraph.DAG, error) {
+	return &digraph.DAG{Name: name, Location: name}, nil
+}
+
+func (m *mockDBClient) GetStatus(_ context.Context, name string, requestID string) (*digraph.Status, error) {
+	for _, status := range m.statuses[name] {
+		if status.RequestID == requestID {
+			return status, nil
+		}
+	}
+	return nil, fmt.Errorf("status of %s not found", name)
+}
+
+func (m *mockDBClient) GetRecentStatuses(_ context.Context, name string, _ int) ([]*digraph.Status, error) {
+	return m.statuses[name], nil
 }
 
 type schedulerOption func(*scheduler.Config)
@@ -907,6 +2031,12 @@ func withTimeout(d time.Duration) schedulerOption {
 	}
 }
 
+func withDelay(d time.Duration) schedulerOption {
+	return func(cfg *scheduler.Config) {
+		cfg.Delay = d
+	}
+}
+
 func withMaxActiveRuns(n int) schedulerOption {
 	return func(cfg *scheduler.Config) {
 		cfg.MaxActiveRuns = n
@@ -982,7 +2112,7 @@ func (gh graphHelper) Schedule(t *testing.T, expectedStatus scheduler.Status) sc
 	logFilename := fmt.Sprintf("%s_%s.log", dag.Name, gh.Config.ReqID)
 	logFilePath := path.Join(gh.Config.LogDir, logFilename)
 
-	ctx := digraph.NewContext(gh.Context, dag, nil, gh.Config.ReqID, logFilePath)
+	ctx := digraph.NewContext(gh.Context, dag, gh.DBClient, gh.Config.ReqID, logFilePath)
 
 	var doneNodes []*scheduler.Node
 	nodeCompletedChan := make(chan *scheduler.Node)
@@ -1079,27 +2209,27 @@ func (sr scheduleResult) AssertNodeStatus(t *testing.T, stepName string, expecte
 	require.Equal(t, expected.String(), target.State().Status.String(), "expected status %q, got %q", expected.String(), target.State().Status.String())
 }
 
-func (sr scheduleResult) Node(t *testing.T, stepName string) *scheduler.Node {
+func (gh graphHelper) Node(t *testing.T, stepName string) *scheduler.Node {
 	t.Helper()
 
-	nodes := sr.ExecutionGraph.Nodes()
+	nodes := gh.ExecutionGraph.Nodes()
 	for _, node := range nodes {
 		if node.Data().Step.Name == stepName {
 			return node
 		}
 	}
 
-	if sr.Config.OnExit != nil && sr.Config.OnExit.Name == stepName {
-		return sr.Scheduler.HandlerNode(digraph.HandlerOnExit)
+	if gh.Config.OnExit != nil && gh.Config.OnExit.Name == stepName {
+		return gh.Scheduler.HandlerNode(digraph.HandlerOnExit)
 	}
-	if sr.Config.OnSuccess != nil && sr.Config.OnSuccess.Name == stepName {
-		return sr.Scheduler.HandlerNode(digraph.HandlerOnSuccess)
+	if gh.Config.OnSuccess != nil && gh.Config.OnSuccess.Name == stepName {
+		return gh.Scheduler.HandlerNode(digraph.HandlerOnSuccess)
 	}
-	if sr.Config.OnFailure != nil && sr.Config.OnFailure.Name == stepName {
-		return sr.Scheduler.HandlerNode(digraph.HandlerOnFailure)
+	if gh.Config.OnFailure != nil && gh.Config.OnFailure.Name == stepName {
+		return gh.Scheduler.HandlerNode(digraph.HandlerOnFailure)
 	}
-	if sr.Config.OnCancel != nil && sr.Config.OnCancel.Name == stepName {
-		return sr.Scheduler.HandlerNode(digraph.HandlerOnCancel)
+	if gh.Config.OnCancel != nil && gh.Config.OnCancel.Name == stepName {
+		return gh.Scheduler.HandlerNode(digraph.HandlerOnCancel)
 	}
 
 	t.Fatalf("step %s not found", stepName)
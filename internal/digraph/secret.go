@@ -0,0 +1,79 @@
+package digraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dagu-org/dagu/internal/secrets"
+)
+
+// Secret is a reference to a secret value. The value is resolved when the
+// DAG runs and is passed to the steps only as an environment variable.
+type Secret struct {
+	// Name is the name of the environment variable to pass the value.
+	Name string `json:"Name"`
+	// Provider is the provider of the value, e.g. env, file or keystore.
+	Provider string `json:"Provider"`
+	// Key is the key of the value in the provider, e.g. the name of the
+	// environment variable or the path of the file.
+	Key string `json:"Key"`
+}
+
+// secretNameRegex matches a valid environment variable name.
+var secretNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// buildSecrets builds the references to the secrets.
+// The key defaults to the name except for the file provider.
+func buildSecrets(_ BuildContext, spec *definition, dag *DAG) error {
+	seen := make(map[string]bool, len(spec.Secrets))
+	for _, def := range spec.Secrets {
+		if !secretNameRegex.MatchString(def.Name) {
+			return wrapError("secrets.name", def.Name, errInvalidSecretName)
+		}
+		if seen[def.Name] {
+			return wrapError("secrets.name", def.Name, errDuplicateSecret)
+		}
+		seen[def.Name] = true
+
+		if !secrets.IsValidProvider(def.Provider) {
+			return wrapError("secrets."+def.Name+".provider", def.Provider, fmt.Errorf(
+				"%w: must be one of %s", errInvalidSecretProvider, strings.Join(secrets.Providers(), ", "),
+			))
+		}
+
+		key := def.Key
+		if key == "" {
+			if def.Provider == secrets.ProviderFile {
+				return wrapError("secrets."+def.Name+".key", def.Key, errSecretKeyRequired)
+			}
+			key = def.Name
+		}
+
+		dag.Secrets = append(dag.Secrets, Secret{
+			Name:     def.Name,
+			Provider: def.Provider,
+			Key:      key,
+		})
+	}
+
+	return nil
+}
+
+// ResolveSecrets resolves the values of the secrets of the DAG by name.
+// It returns the resolved values even if some of them fail to resolve.
+func (d *DAG) ResolveSecrets(ctx context.Context, resolver *secrets.Resolver) (map[string]string, error) {
+	values := make(map[string]string, len(d.Secrets))
+	var errs []error
+	for _, s := range d.Secrets {
+		value, err := resolver.Resolve(ctx, s.Provider, s.Key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("secret %s: %w", s.Name, err))
+			continue
+		}
+		values[s.Name] = value
+	}
+	return values, errors.Join(errs...)
+}
@@ -14,9 +14,14 @@ type definition struct {
 	Dotenv any
 	// Schedule is the cron schedule to run the DAG.
 	Schedule any
+	// Timezone is the time zone of the schedule, e.g. Asia/Tokyo.
+	Timezone string
 	// SkipIfSuccessful is the flag to skip the DAG on schedule when it is
 	// executed manually before the schedule.
 	SkipIfSuccessful bool
+	// Catchup is the policy to run the schedule ticks missed while the
+	// scheduler was down (none, latest, all or the number of ticks).
+	Catchup any
 	// LogFile is the file to write the log.
 	LogDir string
 	// Env is the environment variables setting.
@@ -57,6 +62,16 @@ type definition struct {
 	MaxCleanUpTimeSec *int
 	// Tags is the tags for the DAG.
 	Tags any
+	// DAGs is the map of local DAGs which are private to this DAG and can be
+	// run by the steps with `run: <name>`.
+	DAGs map[string]any
+	// Secrets is the list of secrets to pass to the steps.
+	Secrets []secretDef
+	// WaitFor is the list of runs of other DAGs to wait for before running
+	// the steps.
+	WaitFor []waitForDef
+	// Triggers is the DAGs to start when the run finishes.
+	Triggers *triggersDef
 }
 
 // handlerOnDef defines the steps to be executed on different events.
@@ -89,6 +104,16 @@ type stepDef struct {
 	Stderr string
 	// Output is the variable name to store the output.
 	Output string
+	// Outputs is the map of the output names to the rules to extract the
+	// values from the output. A rule is a JSON path or a jq expression
+	// (string) or a map of stepOutputDef.
+	Outputs map[string]any
+	// Produces is the list of files or glob patterns that the step produces
+	// as artifacts.
+	Produces any // string or []string
+	// Artifacts is the list of artifacts of other steps to stage into the
+	// working directory.
+	Artifacts []artifactDef
 	// Depends is the list of steps to depend on.
 	Depends any // string or []string
 	// ContinueOn is the condition to continue on.
@@ -103,6 +128,19 @@ type stepDef struct {
 	Precondition any
 	// Preconditions is the condition to run the step.
 	Preconditions any
+	// WaitFor is the list of runs of other DAGs to wait for before running
+	// the step.
+	WaitFor []waitForDef
+	// Approval makes the step wait for a manual approval.
+	Approval *approvalDef
+	// If is the expression to decide whether to run the step.
+	// The step is skipped when it evaluates to false.
+	If string
+	// Trigger is the rule to combine the statuses of the upstream steps.
+	Trigger string
+	// TimeoutSec is the timeout in seconds to finish the step.
+	// When it is exceeded, the step is stopped and marked as timed out.
+	TimeoutSec int
 	// SignalOnStop is the signal when the step is requested to stop.
 	// When it is empty, the same signal as the parent process is sent.
 	// It can be KILL when the process does not stop over the timeout.
@@ -113,6 +151,37 @@ type stepDef struct {
 	Run string
 	// Params is the parameters for the sub workflow
 	Params string
+	// Foreach is the list of items to fan out the step over.
+	// It can be a list or a string that evaluates to a JSON array at run time.
+	Foreach any
+	// Matrix is the dimensions to run the step for each combination of.
+	// It can also have include, exclude and failFast keys.
+	Matrix map[string]any
+	// Uses is the reference to the step template to build the step from.
+	// The template is merged into the step definition before building it.
+	Uses string
+	// With is the inputs of the step template.
+	With map[string]any
+}
+
+// artifactDef defines the artifacts to stage from another step.
+type artifactDef struct {
+	From string // Name of the step that produced the artifacts
+	Path string // Path in the artifacts of the step
+}
+
+// stepOutputDef defines the rule to extract a value from the output of a step.
+type stepOutputDef struct {
+	Path  string // JSON path or jq expression to apply to the stdout
+	Regex string // Regular expression to find in the stdout
+	File  string // File to read the value from
+}
+
+// stepTemplateInputDef defines an input of a step template.
+type stepTemplateInputDef struct {
+	Description string // Description of the input
+	Required    bool   // The input must be given with `with`
+	Default     any    // Default value of the input
 }
 
 // funcDef defines a function in the DAG.
@@ -132,6 +201,7 @@ type callFuncDef struct {
 type continueOnDef struct {
 	Failure     bool // Continue on failure
 	Skipped     bool // Continue on skipped
+	Timeout     bool // Continue on timeout
 	ExitCode    any  // Continue on specific exit codes
 	Output      any  // Continue on specific output (string or []string)
 	MarkSuccess bool // Mark the step as success when the condition is met
@@ -139,14 +209,34 @@ type continueOnDef struct {
 
 // repeatPolicyDef defines the repeat policy for a step.
 type repeatPolicyDef struct {
-	Repeat      bool // Flag to indicate if the step should be repeated
-	IntervalSec int  // Interval in seconds between repeats
+	Repeat         bool            // Flag to indicate if the step should be repeated
+	IntervalSec    int             // Interval in seconds between repeats
+	Until          *repeatUntilDef // Condition to stop repeating
+	Limit          int             // Maximum number of executions
+	Backoff        any             // Multiplier applied to the interval after each repeat
+	MaxIntervalSec int             // Maximum interval in seconds between repeats
+	FailOnLimit    bool            // Fail the step when the limit is reached
+}
+
+// repeatUntilDef defines the condition to stop repeating a step.
+type repeatUntilDef struct {
+	Output    any    // Output patterns (string or []string)
+	ExitCode  any    // Exit codes (int or []int)
+	Condition string // Condition to evaluate
+	Expected  string // Expected value of the condition
+	Command   string // Command to run; the condition is met when it exits with 0
 }
 
 // retryPolicyDef defines the retry policy for a step.
 type retryPolicyDef struct {
-	Limit       any // Limit on the number of retries
-	IntervalSec any // Interval in seconds between retries
+	Limit          any  // Limit on the number of retries
+	IntervalSec    any  // Interval in seconds between retries
+	Backoff        any  // Multiplier applied to the interval after each retry
+	MaxIntervalSec int  // Maximum interval in seconds between retries
+	Jitter         any  // Fraction of the interval to randomize (0.0 - 1.0)
+	ExitCode       any  // Retry only on specific exit codes
+	Output         any  // Retry only on specific output (string or []string)
+	OnTimeout      bool // Retry when the step times out
 }
 
 // smtpConfigDef defines the SMTP configuration.
@@ -170,3 +260,36 @@ type mailOnDef struct {
 	Failure bool // Send mail on failure
 	Success bool // Send mail on success
 }
+
+// secretDef defines a reference to a secret.
+type secretDef struct {
+	Name     string // Name of the environment variable to pass the value
+	Provider string // Provider of the value (env, file or keystore)
+	Key      string // Key of the value in the provider
+}
+
+// waitForDef defines a run of another DAG to wait for.
+type waitForDef struct {
+	DAG         string // Name of the DAG to wait for
+	Since       string // today, schedule, or a duration like 6h
+	RequestID   string // Request ID of the specific run to wait for
+	TimeoutSec  int    // Time to wait before giving up (0 means no timeout)
+	IntervalSec int    // Interval to check the status of the DAG
+	OnTimeout   string // Action on timeout (fail or skip)
+}
+
+// triggersDef defines the DAGs to start on the events. Each value is a DAG
+// name or a list of DAG names or maps with the dag and params keys.
+type triggersDef struct {
+	OnSuccess any // DAGs to start on success
+	OnFailure any // DAGs to start on failure
+	OnCancel  any // DAGs to start on cancel
+	OnExit    any // DAGs to start on exit
+}
+
+// approvalDef defines a manual approval gate.
+type approvalDef struct {
+	Message    string // Message to show to the approvers
+	TimeoutSec int    // Time to wait for the decision (0 means no timeout)
+	OnTimeout  string // Action on timeout (approve or reject)
+}
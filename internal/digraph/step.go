@@ -41,6 +41,15 @@ type Step struct {
 	Stderr string `json:"Stderr,omitempty"`
 	// Output is the variable name to store the output.
 	Output string `json:"Output,omitempty"`
+	// Outputs contains the rules to extract the values from the output.
+	// The values are referenced by ${steps.<name>.outputs.<output>}.
+	Outputs []StepOutput `json:"Outputs,omitempty"`
+	// Produces contains the files or glob patterns that the step produces.
+	// They are copied to the artifact directory of the run.
+	Produces []string `json:"Produces,omitempty"`
+	// Artifacts contains the artifacts of other steps to stage into the
+	// working directory before the step runs.
+	Artifacts []ArtifactRef `json:"Artifacts,omitempty"`
 	// Depends contains the list of step names to depend on.
 	Depends []string `json:"Depends,omitempty"`
 	// ContinueOn contains the conditions to continue on failure or skipped.
@@ -53,12 +62,63 @@ type Step struct {
 	MailOnError bool `json:"MailOnError,omitempty"`
 	// Preconditions contains the conditions to be met before running the step.
 	Preconditions []Condition `json:"Preconditions,omitempty"`
+	// WaitFor contains the runs of other DAGs to wait for before running
+	// the step.
+	WaitFor []WaitFor `json:"WaitFor,omitempty"`
+	// Approval makes the step a gate that waits until someone approves or
+	// rejects it instead of running a command.
+	Approval *Approval `json:"Approval,omitempty"`
+	// If is the expression to decide whether to run the step.
+	// See Expression for the syntax.
+	If string `json:"If,omitempty"`
+	// Trigger is the rule to combine the statuses of the upstream steps.
+	// It is TriggerAllSuccess if empty.
+	Trigger TriggerRule `json:"Trigger,omitempty"`
 	// SignalOnStop is the signal to send on stop.
 	SignalOnStop string `json:"SignalOnStop,omitempty"`
+	// Timeout is the time limit for the step to finish.
+	// The step is stopped and marked as timed out when it is exceeded.
+	Timeout time.Duration `json:"Timeout,omitempty"`
 	// SubWorkflow contains the information about a sub DAG to be executed.
 	SubWorkflow *SubWorkflow `json:"SubWorkflow,omitempty"`
+	// Foreach is the expression that evaluates to the list of items (a JSON
+	// array) to fan out the step over. The step is expanded at run time into
+	// a child step for each item.
+	Foreach string `json:"Foreach,omitempty"`
+	// ForeachItem is the item assigned to a child step expanded from a
+	// foreach step.
+	ForeachItem *ForeachItem `json:"ForeachItem,omitempty"`
+	// Matrix is the combination assigned to a step expanded from a matrix.
+	Matrix *MatrixInstance `json:"Matrix,omitempty"`
+	// Template is the step template that the step is built from.
+	Template *StepTemplate `json:"Template,omitempty"`
 }
 
+// TriggerRule is the rule to decide whether a step runs based on the
+// statuses of the upstream steps.
+type TriggerRule string
+
+// All of the rules except TriggerAllSuccess wait for all of the upstream
+// steps to finish. Failed includes timed out and canceled steps.
+const (
+	// TriggerAllSuccess runs the step when all upstream steps succeeded, or
+	// failed or were skipped with continueOn.
+	TriggerAllSuccess TriggerRule = "all_success"
+	// TriggerAllFailed runs the step when all upstream steps failed.
+	TriggerAllFailed TriggerRule = "all_failed"
+	// TriggerAllDone runs the step when all upstream steps are done,
+	// regardless of their statuses, e.g. for cleanup.
+	TriggerAllDone TriggerRule = "all_done"
+	// TriggerOneSuccess runs the step when at least one upstream step
+	// succeeded, e.g. to join conditional branches.
+	TriggerOneSuccess TriggerRule = "one_success"
+	// TriggerOneFailed runs the step when at least one upstream step failed.
+	TriggerOneFailed TriggerRule = "one_failed"
+	// TriggerNoneFailed runs the step when no upstream step failed, i.e. all
+	// of them succeeded or were skipped.
+	TriggerNoneFailed TriggerRule = "none_failed"
+)
+
 // setup sets the default values for the step.
 func (s *Step) setup(workDir string) {
 	// If the working directory is not set, use the directory of the DAG file.
@@ -94,6 +154,37 @@ type SubWorkflow struct {
 	Params string `json:"Params,omitempty"`
 }
 
+// ForeachItem contains the item assigned to a child step of a foreach step.
+type ForeachItem struct {
+	// Parent is the name of the foreach step that the child belongs to.
+	Parent string `json:"Parent"`
+	// Index is the position of the item in the list.
+	Index int `json:"Index"`
+	// Value is the item. Non-string items are encoded as JSON.
+	Value string `json:"Value"`
+}
+
+// MatrixInstance contains the combination assigned to a step expanded from
+// a matrix step. The values are available to the step as environment
+// variables.
+type MatrixInstance struct {
+	// Step is the name of the matrix step that the instance belongs to.
+	Step string `json:"Step"`
+	// Values is the combination of the dimensions.
+	Values map[string]string `json:"Values"`
+	// FailFast cancels the sibling instances when the instance fails.
+	FailFast bool `json:"FailFast,omitempty"`
+}
+
+// StepTemplate contains the information about the step template that a step
+// is built from with `uses`.
+type StepTemplate struct {
+	// Uses is the reference to the template.
+	Uses string `json:"Uses"`
+	// Inputs is the inputs of the template including the default values.
+	Inputs map[string]string `json:"Inputs,omitempty"`
+}
+
 // ExecutorTypeSubWorkflow is defined here in order to parse
 // the `run` field in the DAG file.
 const ExecutorTypeSubWorkflow = "subworkflow"
@@ -121,6 +212,22 @@ type RetryPolicy struct {
 	LimitStr string `json:"LimitStr,omitempty"`
 	// IntervalSecStr is the string representation of the interval.
 	IntervalSecStr string `json:"IntervalSecStr,omitempty"`
+	// Backoff is the multiplier applied to the interval after each retry.
+	// If it's 0 or 1, the interval is fixed.
+	Backoff float64 `json:"Backoff,omitempty"`
+	// MaxInterval is the upper bound of the interval between retries.
+	MaxInterval time.Duration `json:"MaxInterval,omitempty"`
+	// Jitter is the fraction of the interval to randomize, e.g. 0.1 means the
+	// interval varies by up to 10% in either direction.
+	Jitter float64 `json:"Jitter,omitempty"`
+	// ExitCode is the list of exit codes to retry on.
+	// If it's empty, the step is retried on any exit code.
+	ExitCode []int `json:"ExitCode,omitempty"`
+	// Output is the list of patterns in the output to retry on.
+	// If a pattern starts with "re:", it is treated as a regular expression.
+	Output []string `json:"Output,omitempty"`
+	// OnTimeout determines if the step should be retried when it times out.
+	OnTimeout bool `json:"OnTimeout,omitempty"`
 }
 
 // RepeatPolicy contains the repeat policy for a step.
@@ -129,6 +236,30 @@ type RepeatPolicy struct {
 	Repeat bool `json:"Repeat,omitempty"`
 	// Interval is the time to wait between repeats.
 	Interval time.Duration `json:"Interval,omitempty"`
+	// Until is the condition to stop repeating the step.
+	// If it's nil, the step is repeated until the limit or cancellation.
+	Until *RepeatUntil `json:"Until,omitempty"`
+	// Limit is the maximum number of executions. 0 means no limit.
+	Limit int `json:"Limit,omitempty"`
+	// Backoff is the multiplier applied to the interval after each repeat.
+	Backoff float64 `json:"Backoff,omitempty"`
+	// MaxInterval is the upper bound of the interval between repeats.
+	MaxInterval time.Duration `json:"MaxInterval,omitempty"`
+	// FailOnLimit marks the step as failed when the limit is reached before
+	// the until condition is met.
+	FailOnLimit bool `json:"FailOnLimit,omitempty"`
+}
+
+// RepeatUntil is the condition to stop repeating a step.
+// All of the specified conditions must be met.
+type RepeatUntil struct {
+	// Output is the list of patterns to match the output of the execution.
+	// If a pattern starts with "re:", it is treated as a regular expression.
+	Output []string `json:"Output,omitempty"`
+	// ExitCode is the list of exit codes to stop on.
+	ExitCode []int `json:"ExitCode,omitempty"`
+	// Condition is evaluated after each execution.
+	Condition *Condition `json:"Condition,omitempty"`
 }
 
 // ContinueOn contains the conditions to continue on failure or skipped.
@@ -139,6 +270,7 @@ type RepeatPolicy struct {
 type ContinueOn struct {
 	Failure     bool     `json:"Failure,omitempty"`     // Failure is the flag to continue to the next step on failure.
 	Skipped     bool     `json:"Skipped,omitempty"`     // Skipped is the flag to continue to the next step on skipped.
+	Timeout     bool     `json:"Timeout,omitempty"`     // Timeout is the flag to continue to the next step on timeout.
 	ExitCode    []int    `json:"ExitCode,omitempty"`    // ExitCode is the list of exit codes to continue to the next step.
 	Output      []string `json:"Output,omitempty"`      // Output is the list of output (stdout/stderr) to continue to the next step.
 	MarkSuccess bool     `json:"MarkSuccess,omitempty"` // MarkSuccess is the flag to mark the step as success when the condition is met.
@@ -0,0 +1,190 @@
+package digraph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/itchyny/gojq"
+)
+
+// StepOutput is the rule to extract a value from the output of a step.
+// Exactly one of Path, Regex and File is set.
+type StepOutput struct {
+	// Name is the name of the output.
+	Name string `json:"Name"`
+	// Path is a JSON path (e.g. $.version) or a jq expression (e.g.
+	// .items[0].name) to apply to the stdout parsed as JSON.
+	Path string `json:"Path,omitempty"`
+	// Regex is the regular expression to find in the stdout. The first
+	// capturing group is the value if any, otherwise the whole match.
+	Regex string `json:"Regex,omitempty"`
+	// File is the file to read the value from.
+	File string `json:"File,omitempty"`
+}
+
+// ErrStepOutputNotFound is returned when the output does not contain the
+// value to extract.
+var ErrStepOutputNotFound = errors.New("output value not found")
+
+// Extract extracts the value from the stdout of the step.
+func (o StepOutput) Extract(ctx context.Context, stdout string) (string, error) {
+	switch {
+	case o.Path != "":
+		return extractJSONPath(o.Path, stdout)
+
+	case o.Regex != "":
+		re, err := regexp.Compile(o.Regex)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", errInvalidStepOutputRegex, err)
+		}
+		m := re.FindStringSubmatch(stdout)
+		if m == nil {
+			return "", fmt.Errorf("%w: no match for %q", ErrStepOutputNotFound, o.Regex)
+		}
+		if len(m) > 1 {
+			return m[1], nil
+		}
+		return m[0], nil
+
+	case o.File != "":
+		file, err := GetStepContext(ctx).EvalString(o.File)
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", ErrStepOutputNotFound, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+
+	default:
+		return "", errStepOutputSource
+
+	}
+}
+
+// extractJSONPath applies the JSON path or the jq expression to the JSON
+// document. A string value is returned as is and other values are returned
+// as JSON.
+func extractJSONPath(path, document string) (string, error) {
+	query, err := parseJSONPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	var raw any
+	if err := json.Unmarshal([]byte(document), &raw); err != nil {
+		return "", fmt.Errorf("%w: output is not a JSON: %s", ErrStepOutputNotFound, err)
+	}
+
+	iter := query.Run(raw)
+	v, ok := iter.Next()
+	if !ok || v == nil {
+		return "", fmt.Errorf("%w: no value for %q", ErrStepOutputNotFound, path)
+	}
+	if err, ok := v.(error); ok {
+		return "", fmt.Errorf("%w: %s", ErrStepOutputNotFound, err)
+	}
+
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	ret, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+// parseJSONPath parses the JSON path or the jq expression.
+// The root of a JSON path ($) is converted to the identity of jq (.).
+func parseJSONPath(path string) (*gojq.Query, error) {
+	expr := path
+	if rest, ok := strings.CutPrefix(expr, "$"); ok {
+		expr = "." + strings.TrimPrefix(rest, ".")
+	}
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidStepOutputPath, err)
+	}
+	return query, nil
+}
+
+// buildStepOutputs builds the rules to extract the outputs of the step.
+func buildStepOutputs(_ BuildContext, def stepDef, step *Step) error {
+	if len(def.Outputs) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(def.Outputs))
+	for name := range def.Outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		output, err := buildStepOutput(name, def.Outputs[name])
+		if err != nil {
+			return wrapError("outputs."+name, def.Outputs[name], err)
+		}
+		step.Outputs = append(step.Outputs, *output)
+	}
+
+	return nil
+}
+
+func buildStepOutput(name string, value any) (*StepOutput, error) {
+	var def stepOutputDef
+	switch v := value.(type) {
+	case string:
+		def.Path = v
+
+	case map[any]any, map[string]any:
+		md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			ErrorUnused: true,
+			Result:      &def,
+		})
+		if err := md.Decode(v); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, errStepOutputMustBeStringOrMap
+
+	}
+
+	var sources int
+	for _, s := range []string{def.Path, def.Regex, def.File} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, errStepOutputSource
+	}
+
+	if def.Path != "" {
+		if _, err := parseJSONPath(def.Path); err != nil {
+			return nil, err
+		}
+	}
+	if def.Regex != "" {
+		if _, err := regexp.Compile(def.Regex); err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidStepOutputRegex, err)
+		}
+	}
+
+	return &StepOutput{
+		Name:  name,
+		Path:  def.Path,
+		Regex: def.Regex,
+		File:  def.File,
+	}, nil
+}
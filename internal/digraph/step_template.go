@@ -0,0 +1,296 @@
+package digraph
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dagu-org/dagu/internal/fileutil"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// Reserved keys in the step and the step template definitions.
+const (
+	stepTemplateKeyUses   = "uses"
+	stepTemplateKeyWith   = "with"
+	stepTemplateKeyInputs = "inputs"
+)
+
+// stepTemplateInputRegex matches the reference to an input of the step
+// template, e.g. ${{ inputs.channel }}.
+var stepTemplateInputRegex = regexp.MustCompile(`\$\{\{\s*inputs\.([A-Za-z0-9_-]+)\s*\}\}`)
+
+// resolveStepTemplates resolves the step templates of the step definitions
+// in the list or map of steps.
+func resolveStepTemplates(ctx BuildContext, steps any) (any, error) {
+	switch v := steps.(type) {
+	case []any:
+		ret := make([]any, 0, len(v))
+		for _, item := range v {
+			def, ok := item.(map[any]any)
+			if !ok {
+				ret = append(ret, item)
+				continue
+			}
+			resolved, err := resolveStepTemplate(ctx, def)
+			if err != nil {
+				return nil, wrapError("steps", def["name"], err)
+			}
+			ret = append(ret, resolved)
+		}
+		return ret, nil
+
+	case map[any]any:
+		ret := make(map[any]any, len(v))
+		for name, item := range v {
+			def, ok := item.(map[any]any)
+			if !ok {
+				ret[name] = item
+				continue
+			}
+			resolved, err := resolveStepTemplate(ctx, def)
+			if err != nil {
+				return nil, wrapError("steps", name, err)
+			}
+			ret[name] = resolved
+		}
+		return ret, nil
+
+	default:
+		return steps, nil
+
+	}
+}
+
+// resolveStepTemplate merges the step template referenced by `uses` into the
+// step definition. The fields of the step take precedence over the fields
+// of the template. The `with` field of the returned definition contains the
+// resolved inputs including the default values.
+func resolveStepTemplate(ctx BuildContext, def map[any]any) (map[any]any, error) {
+	if _, ok := def[stepTemplateKeyUses]; !ok {
+		return def, nil
+	}
+	baseDir := ""
+	if ctx.file != "" {
+		baseDir = filepath.Dir(ctx.file)
+	}
+	return mergeStepTemplate(ctx, def, baseDir, nil)
+}
+
+func mergeStepTemplate(ctx BuildContext, def map[any]any, baseDir string, stack []string) (map[any]any, error) {
+	uses, ok := def[stepTemplateKeyUses].(string)
+	if !ok || uses == "" {
+		return nil, wrapError(stepTemplateKeyUses, def[stepTemplateKeyUses], errStepTemplateUsesMustBeString)
+	}
+
+	file, err := resolveStepTemplatePath(uses, baseDir, ctx.opts.templatesDir)
+	if err != nil {
+		return nil, wrapError(stepTemplateKeyUses, uses, err)
+	}
+	if slices.Contains(stack, file) {
+		chain := strings.Join(append(stack, file), " -> ")
+		return nil, wrapError(stepTemplateKeyUses, uses, fmt.Errorf("%w: %s", errStepTemplateCycle, chain))
+	}
+	stack = append(stack, file)
+
+	raw, err := readFile(file)
+	if err != nil {
+		return nil, wrapError(stepTemplateKeyUses, uses, err)
+	}
+
+	inputs, err := resolveStepTemplateInputs(raw[stepTemplateKeyInputs], def[stepTemplateKeyWith])
+	if err != nil {
+		return nil, wrapError(stepTemplateKeyWith, def[stepTemplateKeyWith], err)
+	}
+
+	tmpl := make(map[any]any, len(raw))
+	for k, v := range raw {
+		if k == stepTemplateKeyInputs {
+			continue
+		}
+		rendered, err := renderStepTemplateInputs(v, inputs)
+		if err != nil {
+			return nil, wrapError(k, v, err)
+		}
+		tmpl[k] = rendered
+	}
+
+	// A template can be built from another template.
+	if _, ok := tmpl[stepTemplateKeyUses]; ok {
+		tmpl, err = mergeStepTemplate(ctx, tmpl, filepath.Dir(file), stack)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ret := make(map[any]any, len(tmpl)+len(def))
+	for k, v := range tmpl {
+		ret[k] = v
+	}
+	for k, v := range def {
+		ret[k] = v
+	}
+
+	with := make(map[any]any, len(inputs))
+	for k, v := range inputs {
+		with[k] = v
+	}
+	ret[stepTemplateKeyWith] = with
+
+	return ret, nil
+}
+
+// resolveStepTemplatePath resolves the path of the step template.
+// A relative path is resolved from the directory of the referencing file
+// first and then from the templates directory. The extension can be omitted.
+func resolveStepTemplatePath(uses, baseDir, templatesDir string) (string, error) {
+	if !fileutil.IsYAMLFile(uses) {
+		uses += ".yaml"
+	}
+
+	var candidates []string
+	if filepath.IsAbs(uses) {
+		candidates = append(candidates, uses)
+	} else {
+		candidates = append(candidates, filepath.Join(baseDir, uses))
+		if templatesDir != "" {
+			candidates = append(candidates, filepath.Join(templatesDir, uses))
+		}
+	}
+
+	for _, candidate := range candidates {
+		if fileutil.FileExists(candidate) {
+			return filepath.Abs(candidate)
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", errStepTemplateNotFound, uses)
+}
+
+// resolveStepTemplateInputs validates the inputs given by `with` against the
+// inputs defined in the template and fills in the default values.
+func resolveStepTemplateInputs(defs, with any) (map[string]any, error) {
+	inputDefs := make(map[string]stepTemplateInputDef)
+	if defs != nil {
+		md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			ErrorUnused: true,
+			Result:      &inputDefs,
+		})
+		if err := md.Decode(defs); err != nil {
+			return nil, wrapError(stepTemplateKeyInputs, defs, err)
+		}
+	}
+
+	given := make(map[string]any)
+	switch v := with.(type) {
+	case nil:
+	case map[any]any:
+		for k, v := range v {
+			key, err := parseKey(k)
+			if err != nil {
+				return nil, err
+			}
+			given[key] = v
+		}
+	default:
+		return nil, errStepTemplateWithMustBeMap
+	}
+
+	ret := make(map[string]any, len(inputDefs))
+	for name := range given {
+		if _, ok := inputDefs[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", errStepTemplateUnknownInput, name)
+		}
+	}
+	for name, def := range inputDefs {
+		if v, ok := given[name]; ok {
+			ret[name] = v
+			continue
+		}
+		if def.Required {
+			return nil, fmt.Errorf("%w: %s", errStepTemplateInputRequired, name)
+		}
+		ret[name] = def.Default
+	}
+
+	return ret, nil
+}
+
+// renderStepTemplateInputs replaces the references to the inputs in the
+// value of the template. A string that consists of only a reference is
+// replaced with the input value as is to keep its type.
+func renderStepTemplateInputs(value any, inputs map[string]any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if m := stepTemplateInputRegex.FindStringSubmatch(v); m != nil && m[0] == v {
+			input, ok := inputs[m[1]]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", errStepTemplateUnknownInput, m[1])
+			}
+			return input, nil
+		}
+		var err error
+		ret := stepTemplateInputRegex.ReplaceAllStringFunc(v, func(ref string) string {
+			name := stepTemplateInputRegex.FindStringSubmatch(ref)[1]
+			input, ok := inputs[name]
+			if !ok {
+				err = fmt.Errorf("%w: %s", errStepTemplateUnknownInput, name)
+				return ref
+			}
+			if input == nil {
+				return ""
+			}
+			return fmt.Sprintf("%v", input)
+		})
+		return ret, err
+
+	case []any:
+		ret := make([]any, 0, len(v))
+		for _, item := range v {
+			rendered, err := renderStepTemplateInputs(item, inputs)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, rendered)
+		}
+		return ret, nil
+
+	case map[any]any:
+		ret := make(map[any]any, len(v))
+		for k, item := range v {
+			rendered, err := renderStepTemplateInputs(item, inputs)
+			if err != nil {
+				return nil, err
+			}
+			ret[k] = rendered
+		}
+		return ret, nil
+
+	default:
+		return value, nil
+
+	}
+}
+
+// buildStepTemplate sets the information about the step template.
+func buildStepTemplate(_ BuildContext, def stepDef, step *Step) error {
+	if def.Uses == "" {
+		return nil
+	}
+
+	step.Template = &StepTemplate{Uses: def.Uses}
+	if len(def.With) > 0 {
+		step.Template.Inputs = make(map[string]string, len(def.With))
+		for k, v := range def.With {
+			if v == nil {
+				step.Template.Inputs[k] = ""
+				continue
+			}
+			step.Template.Inputs[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,285 @@
+package digraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/cmdutil"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/robfig/cron/v3"
+)
+
+// ErrWaitForTimeout is returned when the run of the DAG to wait for did not
+// succeed before the timeout.
+var ErrWaitForTimeout = errors.New("timed out waiting for the DAG run")
+
+const (
+	// WaitForSinceToday waits for a run that started today.
+	WaitForSinceToday = "today"
+	// WaitForSinceSchedule waits for a run that started at or after the
+	// latest schedule tick of the DAG.
+	WaitForSinceSchedule = "schedule"
+
+	// WaitForOnTimeoutFail fails the DAG or the step on timeout.
+	WaitForOnTimeoutFail = "fail"
+	// WaitForOnTimeoutSkip skips the DAG or the step on timeout.
+	WaitForOnTimeoutSkip = "skip"
+
+	// defaultWaitForInterval is the default interval to check the status.
+	defaultWaitForInterval = time.Minute
+	// waitForHistoryLimit is the number of the recent runs to check.
+	waitForHistoryLimit = 30
+)
+
+// WaitFor is a run of another DAG to wait for until it finishes
+// successfully.
+type WaitFor struct {
+	// DAG is the name of the DAG to wait for.
+	DAG string `json:"DAG"`
+	// Since is the start of the window in which the run must have started.
+	// It is "today", "schedule", or a duration before now (e.g., "6h").
+	Since string `json:"Since,omitempty"`
+	// RequestID is the request ID of the specific run to wait for.
+	// Since is ignored if it is set.
+	RequestID string `json:"RequestID,omitempty"`
+	// Timeout is the time to wait before giving up. Zero means no timeout.
+	Timeout time.Duration `json:"Timeout,omitempty"`
+	// Interval is the interval to check the status of the DAG.
+	Interval time.Duration `json:"Interval,omitempty"`
+	// OnTimeout is the action on timeout: "fail" (default) or "skip".
+	OnTimeout string `json:"OnTimeout,omitempty"`
+}
+
+// buildWaitFor builds the runs to wait for before running the steps.
+func buildWaitFor(_ BuildContext, spec *definition, dag *DAG) error {
+	waits, err := parseWaitFor(spec.WaitFor)
+	if err != nil {
+		return err
+	}
+	dag.WaitFor = waits
+	return nil
+}
+
+// buildStepWaitFor builds the runs to wait for before running the step.
+func buildStepWaitFor(_ BuildContext, def stepDef, step *Step) error {
+	waits, err := parseWaitFor(def.WaitFor)
+	if err != nil {
+		return err
+	}
+	step.WaitFor = waits
+	return nil
+}
+
+func parseWaitFor(defs []waitForDef) ([]WaitFor, error) {
+	var ret []WaitFor
+	for _, def := range defs {
+		if def.DAG == "" {
+			return nil, wrapError("waitFor.dag", def.DAG, errWaitForDAGRequired)
+		}
+		switch def.Since {
+		case "", WaitForSinceToday, WaitForSinceSchedule:
+		default:
+			if _, err := time.ParseDuration(def.Since); err != nil {
+				return nil, wrapError("waitFor.since", def.Since, errInvalidWaitForSince)
+			}
+		}
+		switch def.OnTimeout {
+		case "", WaitForOnTimeoutFail, WaitForOnTimeoutSkip:
+		default:
+			return nil, wrapError("waitFor.onTimeout", def.OnTimeout, errInvalidWaitForOnTimeout)
+		}
+		if def.TimeoutSec < 0 || def.IntervalSec < 0 {
+			return nil, wrapError("waitFor", def.DAG, errInvalidWaitForTimeout)
+		}
+
+		since := def.Since
+		if since == "" && def.RequestID == "" {
+			since = WaitForSinceToday
+		}
+		onTimeout := def.OnTimeout
+		if onTimeout == "" {
+			onTimeout = WaitForOnTimeoutFail
+		}
+		interval := time.Duration(def.IntervalSec) * time.Second
+		if interval == 0 {
+			interval = defaultWaitForInterval
+		}
+
+		ret = append(ret, WaitFor{
+			DAG:       def.DAG,
+			Since:     since,
+			RequestID: def.RequestID,
+			Timeout:   time.Duration(def.TimeoutSec) * time.Second,
+			Interval:  interval,
+			OnTimeout: onTimeout,
+		})
+	}
+	return ret, nil
+}
+
+// WaitForDAGs waits until the runs of the DAGs finish successfully.
+// When the timeout is reached, it returns ErrWaitForTimeout, or
+// ErrConditionNotMet if the action on timeout is "skip" so that it is
+// handled in the same way as the preconditions.
+func WaitForDAGs(ctx context.Context, waits []WaitFor) error {
+	for _, w := range waits {
+		if err := w.wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w WaitFor) wait(ctx context.Context) error {
+	w, err := w.eval(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deadline <-chan time.Time
+	if w.Timeout > 0 {
+		timer := time.NewTimer(w.Timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	interval := w.Interval
+	if interval <= 0 {
+		interval = defaultWaitForInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger.Info(ctx, "Waiting for the DAG run", "dag", w.DAG, "since", w.Since, "requestId", w.RequestID)
+	for {
+		ok, err := w.succeeded(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		if ok {
+			logger.Info(ctx, "The DAG run to wait for succeeded", "dag", w.DAG)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			err := fmt.Errorf("%w: %s", ErrWaitForTimeout, w.DAG)
+			if w.OnTimeout == WaitForOnTimeoutSkip {
+				return fmt.Errorf("%w: %w", ErrConditionNotMet, err)
+			}
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+// eval evaluates the variables in the DAG name and the request ID.
+func (w WaitFor) eval(ctx context.Context) (WaitFor, error) {
+	evalString := func(s string) (string, error) {
+		if IsStepContext(ctx) {
+			return GetStepContext(ctx).EvalString(s, cmdutil.OnlyReplaceVars())
+		}
+		return GetContext(ctx).EvalString(s, cmdutil.OnlyReplaceVars())
+	}
+	var err error
+	if w.DAG, err = evalString(w.DAG); err != nil {
+		return w, err
+	}
+	if w.RequestID, err = evalString(w.RequestID); err != nil {
+		return w, err
+	}
+	return w, nil
+}
+
+// succeeded returns true if the run of the DAG finished successfully.
+func (w WaitFor) succeeded(ctx context.Context, now time.Time) (bool, error) {
+	c := GetContext(ctx)
+	if c.client == nil {
+		return false, fmt.Errorf("cannot wait for %s: the history is not available", w.DAG)
+	}
+
+	// The history is stored by the location of the DAG.
+	dag, err := c.GetDAGByName(w.DAG)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the DAG %s: %w", w.DAG, err)
+	}
+
+	if w.RequestID != "" {
+		status, err := c.GetResult(dag.Location, w.RequestID)
+		if err != nil {
+			// The run may not be started yet.
+			logger.Debug(ctx, "The DAG run to wait for is not found", "dag", w.DAG, "requestId", w.RequestID, "err", err)
+			return false, nil
+		}
+		return status.Succeeded, nil
+	}
+
+	since, err := w.since(dag, now)
+	if err != nil {
+		return false, err
+	}
+	statuses, err := c.GetRecentResults(dag.Location, waitForHistoryLimit)
+	if err != nil {
+		return false, err
+	}
+	for _, status := range statuses {
+		if status.Succeeded && !status.StartedAt.Before(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// since returns the start of the window in which the run must have started.
+func (w WaitFor) since(dag *DAG, now time.Time) (time.Time, error) {
+	switch w.Since {
+	case WaitForSinceToday:
+		year, month, day := now.Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, now.Location()), nil
+
+	case WaitForSinceSchedule:
+		var latest time.Time
+		for _, s := range dag.Schedule {
+			if tick := prevScheduleTick(s.Parsed, now); tick.After(latest) {
+				latest = tick
+			}
+		}
+		if latest.IsZero() {
+			return time.Time{}, fmt.Errorf("the DAG %s has no schedule to wait for", w.DAG)
+		}
+		return latest, nil
+
+	default:
+		d, err := time.ParseDuration(w.Since)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %s", errInvalidWaitForSince, w.Since)
+		}
+		return now.Add(-d), nil
+	}
+}
+
+// prevScheduleTick returns the latest time at or before now when the
+// schedule is activated. It returns the zero time if there is none within
+// about two years.
+func prevScheduleTick(s cron.Schedule, now time.Time) time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	for d := time.Minute; d <= 2*366*24*time.Hour; d *= 2 {
+		tick := s.Next(now.Add(-d))
+		if tick.After(now) {
+			continue
+		}
+		for {
+			next := s.Next(tick)
+			if next.After(now) {
+				return tick
+			}
+			tick = next
+		}
+	}
+	return time.Time{}
+}
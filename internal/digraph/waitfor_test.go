@@ -0,0 +1,117 @@
+package digraph
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockDBClient struct {
+	dags     map[string]*DAG
+	statuses map[string][]*Status
+}
+
+func (m *mockDBClient) GetDAG(_ context.Context, name string) (*DAG, error) {
+	if dag, ok := m.dags[name]; ok {
+		return dag, nil
+	}
+	return nil, errors.New("DAG not found")
+}
+
+func (m *mockDBClient) GetStatus(_ context.Context, name string, requestID string) (*Status, error) {
+	for _, status := range m.statuses[name] {
+		if status.RequestID == requestID {
+			return status, nil
+		}
+	}
+	return nil, errors.New("status not found")
+}
+
+func (m *mockDBClient) GetRecentStatuses(_ context.Context, name string, _ int) ([]*Status, error) {
+	return m.statuses[name], nil
+}
+
+func TestWaitForDAGs(t *testing.T) {
+	now := time.Now()
+	hourly, err := cronParser.Parse("0 * * * *")
+	require.NoError(t, err)
+
+	client := &mockDBClient{
+		dags: map[string]*DAG{
+			"ingest": {Name: "ingest", Location: "ingest", Schedule: []Schedule{{Expression: "0 * * * *", Parsed: hourly}}},
+		},
+		statuses: map[string][]*Status{
+			"ingest": {
+				{RequestID: "req-2", Succeeded: false, StartedAt: now.Add(-time.Second)},
+				{RequestID: "req-1", Succeeded: true, StartedAt: now.Add(-2 * time.Hour)},
+			},
+		},
+	}
+	ctx := NewContext(context.Background(), &DAG{Name: "report"}, client, "req", "")
+
+	newWait := func(w WaitFor) WaitFor {
+		w.DAG = "ingest"
+		w.Timeout = 50 * time.Millisecond
+		w.Interval = 10 * time.Millisecond
+		if w.OnTimeout == "" {
+			w.OnTimeout = WaitForOnTimeoutFail
+		}
+		return w
+	}
+
+	t.Run("SinceDuration", func(t *testing.T) {
+		err := WaitForDAGs(ctx, []WaitFor{newWait(WaitFor{Since: "3h"})})
+		require.NoError(t, err)
+	})
+	t.Run("RequestID", func(t *testing.T) {
+		err := WaitForDAGs(ctx, []WaitFor{newWait(WaitFor{RequestID: "req-1"})})
+		require.NoError(t, err)
+	})
+	t.Run("Timeout", func(t *testing.T) {
+		err := WaitForDAGs(ctx, []WaitFor{newWait(WaitFor{Since: "1h"})})
+		require.ErrorIs(t, err, ErrWaitForTimeout)
+		require.NotErrorIs(t, err, ErrConditionNotMet)
+	})
+	t.Run("TimeoutSkip", func(t *testing.T) {
+		err := WaitForDAGs(ctx, []WaitFor{newWait(WaitFor{RequestID: "req-2", OnTimeout: WaitForOnTimeoutSkip})})
+		require.ErrorIs(t, err, ErrWaitForTimeout)
+		require.ErrorIs(t, err, ErrConditionNotMet)
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		w := newWait(WaitFor{RequestID: "req-3"})
+		w.Timeout = 0
+		err := WaitForDAGs(ctx, []WaitFor{w})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+	t.Run("SinceSchedule", func(t *testing.T) {
+		w := newWait(WaitFor{Since: WaitForSinceSchedule})
+		since, err := w.since(client.dags["ingest"], now)
+		require.NoError(t, err)
+		want := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, now.Location())
+		require.Equal(t, want, since)
+	})
+}
+
+func TestPrevScheduleTick(t *testing.T) {
+	now := time.Date(2024, 3, 10, 10, 30, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "*/15 * * * *", want: time.Date(2024, 3, 10, 10, 30, 0, 0, time.UTC)},
+		{expr: "0 */4 * * *", want: time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 * *", want: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 1 *", want: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			s, err := cronParser.Parse(tc.expr)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, prevScheduleTick(s, now))
+		})
+	}
+}
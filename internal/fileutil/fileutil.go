@@ -2,6 +2,8 @@ package fileutil
 
 import (
 	"errors"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"slices"
@@ -127,3 +129,70 @@ func EnsureYAMLExtension(filename string) string {
 		return filename + yamlExtension
 	}
 }
+
+// Copy copies the file or the directory (recursively) to dst and returns
+// the paths of the copied files relative to dst. The parent directories of
+// dst are created if necessary.
+func Copy(src, dst string) ([]string, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		if err := copyFile(src, dst, info.Mode()); err != nil {
+			return nil, err
+		}
+		return []string{filepath.Base(dst)}, nil
+	}
+
+	var copied []string
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			// Skip symlinks, sockets, etc.
+			return nil
+		}
+		if err := copyFile(path, target, info.Mode()); err != nil {
+			return err
+		}
+		copied = append(copied, filepath.ToSlash(rel))
+		return nil
+	})
+	return copied, err
+}
+
+// copyFile copies the regular file to dst with the mode.
+func copyFile(src, dst string, mode fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
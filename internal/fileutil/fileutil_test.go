@@ -143,3 +143,38 @@ func TestAddYamlExtension(t *testing.T) {
 		}
 	}
 }
+
+func TestCopy(t *testing.T) {
+	t.Run("File", func(t *testing.T) {
+		src := filepath.Join(t.TempDir(), "src.txt")
+		require.NoError(t, os.WriteFile(src, []byte("hello"), 0600))
+
+		dst := filepath.Join(t.TempDir(), "a", "b", "dst.txt")
+		copied, err := Copy(src, dst)
+		require.NoError(t, err)
+		require.Equal(t, []string{"dst.txt"}, copied)
+
+		data, err := os.ReadFile(dst)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(data))
+	})
+	t.Run("Directory", func(t *testing.T) {
+		src := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("a"), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "sub", "b.txt"), []byte("b"), 0600))
+
+		dst := filepath.Join(t.TempDir(), "dst")
+		copied, err := Copy(src, dst)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a.txt", "sub/b.txt"}, copied)
+
+		data, err := os.ReadFile(filepath.Join(dst, "sub", "b.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "b", string(data))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Copy(filepath.Join(t.TempDir(), "missing"), t.TempDir())
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -1,6 +1,9 @@
 package dag
 
 import (
+	"path/filepath"
+	"strings"
+
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/frontend/gen/models"
 	"github.com/dagu-org/dagu/internal/persistence/model"
@@ -23,6 +26,7 @@ func convertToDAG(dag *digraph.DAG) *models.Dag {
 		DefaultParams: swag.String(dag.DefaultParams),
 		Tags:          dag.Tags,
 		Schedule:      schedules,
+		Timezone:      dag.Timezone,
 	}
 }
 
@@ -37,6 +41,7 @@ func convertToStatusDetail(s model.Status) *models.DagStatusDetail {
 		FinishedAt: swag.String(s.FinishedAt),
 		Status:     swag.Int64(int64(s.Status)),
 		StatusText: swag.String(s.StatusText),
+		Error:      s.Error,
 	}
 	for _, n := range s.Nodes {
 		status.Nodes = append(status.Nodes, convertToNode(n))
@@ -53,15 +58,62 @@ func convertToStatusDetail(s model.Status) *models.DagStatusDetail {
 	if s.OnExit != nil {
 		status.OnExit = convertToNode(s.OnExit)
 	}
+	if s.TriggeredBy != nil {
+		status.TriggeredBy = convertToDAGRunRef(*s.TriggeredBy)
+	}
+	for _, ref := range s.Triggered {
+		status.Triggered = append(status.Triggered, convertToDAGRunRef(ref))
+	}
 	return status
 }
 
+func convertToDAGRunRef(ref model.DAGRunRef) *models.DagRunRef {
+	// The DAG is identified by the file name without the extension in the UI.
+	id := strings.TrimSuffix(filepath.Base(ref.Location), filepath.Ext(ref.Location))
+	if ref.Location == "" {
+		id = ref.Name
+	}
+	return &models.DagRunRef{
+		DagID:     swag.String(id),
+		Error:     ref.Error,
+		Name:      swag.String(ref.Name),
+		On:        ref.On,
+		RequestID: swag.String(ref.RequestID),
+	}
+}
+
 func convertToNode(node *model.Node) *models.StatusNode {
+	var attempts []*models.StatusNodeAttempt
+	for _, a := range node.Attempts {
+		attempts = append(attempts, &models.StatusNodeAttempt{
+			Attempt:    int64(a.Attempt),
+			Error:      a.Error,
+			ExitCode:   int64(a.ExitCode),
+			FinishedAt: a.FinishedAt,
+			StartedAt:  a.StartedAt,
+		})
+	}
+	var approval *models.StatusNodeApproval
+	if node.Step.Approval != nil {
+		approval = &models.StatusNodeApproval{Message: node.Step.Approval.Message}
+		if node.Approval != nil {
+			approval.Approved = node.Approval.Approved
+			approval.User = node.Approval.User
+			approval.Comment = node.Approval.Comment
+			approval.At = node.Approval.At
+		}
+	}
 	return &models.StatusNode{
+		Approval:   approval,
+		Artifacts:  node.Artifacts,
+		Attempts:   attempts,
 		DoneCount:  swag.Int64(int64(node.DoneCount)),
 		Error:      swag.String(node.Error),
 		FinishedAt: swag.String(node.FinishedAt),
+		Iterations: int64(node.Iterations),
+		LastResult: node.LastResult,
 		Log:        swag.String(node.Log),
+		Outputs:    node.Outputs,
 		RetryCount: swag.Int64(int64(node.RetryCount)),
 		StartedAt:  swag.String(node.StartedAt),
 		Status:     swag.Int64(int64(node.Status)),
@@ -106,3 +158,24 @@ func convertToStepObject(step digraph.Step) *models.StepObject {
 	}
 	return so
 }
+
+func convertToParamSchema(schema digraph.ParamSchema) *models.ParamSchema {
+	ps := &models.ParamSchema{
+		Default:     schema.Default,
+		Description: schema.Description,
+		Enum:        schema.Enum,
+		Maximum:     schema.Maximum,
+		Minimum:     schema.Minimum,
+		Name:        schema.Name,
+		Pattern:     schema.Pattern,
+		Required:    schema.Required,
+		Type:        string(schema.Type),
+	}
+	if schema.MinLength != nil {
+		ps.MinLength = swag.Int64(int64(*schema.MinLength))
+	}
+	if schema.MaxLength != nil {
+		ps.MaxLength = swag.Int64(int64(*schema.MaxLength))
+	}
+	return ps
+}
@@ -1,6 +1,7 @@
 package dag
 
 import (
+	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/frontend/gen/models"
 	"github.com/go-openapi/swag"
 )
@@ -30,3 +31,17 @@ func newBadRequestError(err error) *codedError {
 		DetailedMessage: swag.String(err.Error()),
 	}}
 }
+
+func newParamsError(errs digraph.ParamErrors) *codedError {
+	apiErr := &models.APIError{
+		Message:         swag.String("Invalid Parameters"),
+		DetailedMessage: swag.String(errs.Error()),
+	}
+	for _, err := range errs {
+		apiErr.FieldErrors = append(apiErr.FieldErrors, &models.APIFieldError{
+			Field:   err.Name,
+			Message: err.Err.Error(),
+		})
+	}
+	return &codedError{Code: 400, APIError: apiErr}
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"sort"
 	"strings"
 	"time"
@@ -53,6 +54,7 @@ type Handler struct {
 	logEncodingCharset string
 	remoteNodes        map[string]config.RemoteNode
 	apiBasePath        string
+	location           *time.Location
 }
 
 type NewHandlerArgs struct {
@@ -60,6 +62,7 @@ type NewHandlerArgs struct {
 	LogEncodingCharset string
 	RemoteNodes        []config.RemoteNode
 	ApiBasePath        string
+	Location           *time.Location
 }
 
 func NewHandler(args *NewHandlerArgs) server.Handler {
@@ -67,11 +70,16 @@ func NewHandler(args *NewHandlerArgs) server.Handler {
 	for _, node := range args.RemoteNodes {
 		remoteNodes[node.Name] = node
 	}
+	location := args.Location
+	if location == nil {
+		location = time.Local
+	}
 	return &Handler{
 		client:             args.Client,
 		logEncodingCharset: args.LogEncodingCharset,
 		remoteNodes:        remoteNodes,
 		apiBasePath:        args.ApiBasePath,
+		location:           location,
 	}
 }
 
@@ -104,6 +112,19 @@ func (h *Handler) Configure(api *operations.DaguAPI) {
 			return dags.NewGetDagDetailsOK().WithPayload(resp)
 		})
 
+	api.DagsGetDagArtifactHandler = dags.GetDagArtifactHandlerFunc(
+		func(params dags.GetDagArtifactParams) middleware.Responder {
+			ctx := params.HTTPRequest.Context()
+			file, name, err := h.getArtifact(ctx, params)
+			if err != nil {
+				return dags.NewGetDagArtifactDefault(err.Code).
+					WithPayload(err.APIError)
+			}
+			return dags.NewGetDagArtifactOK().
+				WithContentDisposition(fmt.Sprintf("attachment; filename=%q", name)).
+				WithPayload(file)
+		})
+
 	api.DagsPostDagActionHandler = dags.PostDagActionHandlerFunc(
 		func(params dags.PostDagActionParams) middleware.Responder {
 			if resp := h.handleRemoteNodeProxy(params.Body, params.HTTPRequest); resp != nil {
@@ -409,6 +430,9 @@ func (h *Handler) getList(ctx context.Context, params dags.ListDagsParams) (*mod
 			Suspended: swag.Bool(dagStatus.Suspended),
 			DAG:       convertToDAG(dagStatus.DAG),
 		}
+		if !dagStatus.Suspended {
+			item.NextRun, item.NextRunServer = h.nextRun(dagStatus.DAG)
+		}
 
 		if dagStatus.Error != nil {
 			item.Error = swag.String(dagStatus.Error.Error())
@@ -468,6 +492,11 @@ func (h *Handler) getDetail(
 		})
 	}
 
+	var paramsSchema []*models.ParamSchema
+	for _, schema := range dagStatus.DAG.ParamsSchema {
+		paramsSchema = append(paramsSchema, convertToParamSchema(schema))
+	}
+
 	dag := dagStatus.DAG
 	dagDetail := &models.DagDetail{
 		DefaultParams:     swag.String(dag.DefaultParams),
@@ -482,10 +511,12 @@ func (h *Handler) getDetail(
 		MaxActiveRuns:     swag.Int64(int64(dag.MaxActiveRuns)),
 		Name:              swag.String(dag.Name),
 		Params:            dag.Params,
+		ParamsSchema:      paramsSchema,
 		Preconditions:     preconditions,
 		Schedule:          schedules,
 		Steps:             steps,
 		Tags:              dag.Tags,
+		Timezone:          dag.Timezone,
 	}
 
 	statusWithDetails := &models.DagStatusWithDetails{
@@ -496,6 +527,9 @@ func (h *Handler) getDetail(
 		Status:    convertToStatusDetail(dagStatus.Status),
 		Suspended: swag.Bool(dagStatus.Suspended),
 	}
+	if !dagStatus.Suspended {
+		statusWithDetails.NextRun, statusWithDetails.NextRunServer = h.nextRun(dag)
+	}
 
 	if dagStatus.Error != nil {
 		statusWithDetails.Error = swag.String(dagStatus.Error.Error())
@@ -535,6 +569,16 @@ func (h *Handler) getDetail(
 	}
 }
 
+// nextRun returns the next scheduled run of the DAG in the time zone of the
+// DAG and in the time zone of the server.
+func (h *Handler) nextRun(dag *digraph.DAG) (string, string) {
+	next := dag.NextRun(time.Now().In(h.location))
+	if next.IsZero() {
+		return "", ""
+	}
+	return next.Format(time.RFC3339), next.In(h.location).Format(time.RFC3339)
+}
+
 func (h *Handler) processSchedulerLogRequest(
 	ctx context.Context,
 	dag *digraph.DAG,
@@ -782,9 +826,15 @@ func (h *Handler) postAction(
 
 	switch *params.Body.Action {
 	case "start":
-		if dagStatus.Status.Status == scheduler.StatusRunning {
+		if dagStatus.Status.Status.IsActive() {
 			return nil, newBadRequestError(errInvalidArgs)
 		}
+		if err := dagStatus.DAG.ValidateParams(params.Body.Params); err != nil {
+			if errs, ok := digraph.AsParamErrors(err); ok {
+				return nil, newParamsError(errs)
+			}
+			return nil, newBadRequestError(err)
+		}
 		h.client.StartAsync(ctx, dagStatus.DAG, client.StartOptions{
 			Params: params.Body.Params,
 		})
@@ -795,7 +845,11 @@ func (h *Handler) postAction(
 		return &models.PostDagActionResponse{}, nil
 
 	case "stop":
-		if dagStatus.Status.Status != scheduler.StatusRunning {
+		if params.Body.RequestID != "" {
+			// The run may be a backfill run, which listens on its own socket.
+			return h.processStopRun(ctx, dagStatus, params.Body.RequestID)
+		}
+		if !dagStatus.Status.Status.IsActive() {
 			return nil, newBadRequestError(
 				fmt.Errorf("the DAG is not running: %w", errInvalidArgs),
 			)
@@ -807,13 +861,51 @@ func (h *Handler) postAction(
 		}
 		return &models.PostDagActionResponse{}, nil
 
+	case "pause":
+		if dagStatus.Status.Status != scheduler.StatusRunning {
+			return nil, newBadRequestError(
+				fmt.Errorf("the DAG is not running: %w", errInvalidArgs),
+			)
+		}
+		if err := h.client.Pause(ctx, dagStatus.DAG); err != nil {
+			return nil, newBadRequestError(
+				fmt.Errorf("error trying to pause the DAG: %w", err),
+			)
+		}
+		return &models.PostDagActionResponse{}, nil
+
+	case "resume":
+		if dagStatus.Status.Status != scheduler.StatusPaused {
+			return nil, newBadRequestError(
+				fmt.Errorf("the DAG is not paused: %w", errInvalidArgs),
+			)
+		}
+		if err := h.client.Resume(ctx, dagStatus.DAG); err != nil {
+			return nil, newBadRequestError(
+				fmt.Errorf("error trying to resume the DAG: %w", err),
+			)
+		}
+		return &models.PostDagActionResponse{}, nil
+
 	case "retry":
 		if params.Body.RequestID == "" {
 			return nil, newBadRequestError(
 				fmt.Errorf("request-id is required: %w", errInvalidArgs),
 			)
 		}
-		if err := h.client.Retry(ctx, dagStatus.DAG, params.Body.RequestID); err != nil {
+		// Retry from the step, or only the step and its descendants.
+		var opts client.RetryOptions
+		if params.Body.OnlyStep && params.Body.Step == "" {
+			return nil, newBadRequestError(
+				fmt.Errorf("step name is required: %w", errInvalidArgs),
+			)
+		}
+		if params.Body.OnlyStep {
+			opts.OnlyStep = params.Body.Step
+		} else {
+			opts.FromStep = params.Body.Step
+		}
+		if err := h.client.Retry(ctx, dagStatus.DAG, params.Body.RequestID, opts); err != nil {
 			return nil, newInternalError(
 				fmt.Errorf("error trying to retry the DAG: %w", err),
 			)
@@ -844,6 +936,29 @@ func (h *Handler) postAction(
 		}
 		return &models.PostDagActionResponse{NewDagID: params.Body.Value}, nil
 
+	case "approve", "reject":
+		if params.Body.Step == "" {
+			return nil, newBadRequestError(fmt.Errorf("step name is required: %w", errInvalidArgs))
+		}
+		if !dagStatus.Status.Status.IsActive() {
+			return nil, newBadRequestError(
+				fmt.Errorf("the DAG is not running: %w", errInvalidArgs),
+			)
+		}
+		// The user name of the basic auth is recorded as the approver.
+		user := "web"
+		if name, _, ok := params.HTTPRequest.BasicAuth(); ok && name != "" {
+			user = name
+		}
+		if err := h.client.Approve(ctx, dagStatus.DAG, params.Body.Step, client.ApproveOptions{
+			Reject:  *params.Body.Action == "reject",
+			User:    user,
+			Comment: params.Body.Value,
+		}); err != nil {
+			return nil, newBadRequestError(err)
+		}
+		return &models.PostDagActionResponse{}, nil
+
 	default:
 		return nil, newBadRequestError(
 			fmt.Errorf("invalid action: %s", *params.Body.Action),
@@ -851,6 +966,26 @@ func (h *Handler) postAction(
 	}
 }
 
+func (h *Handler) processStopRun(
+	ctx context.Context, dagStatus client.DAGStatus, requestID string,
+) (*models.PostDagActionResponse, *codedError) {
+	status, err := h.client.GetStatusByRequestID(ctx, dagStatus.DAG, requestID)
+	if err != nil {
+		return nil, newNotFoundError(err)
+	}
+	if !status.Status.IsActive() {
+		return nil, newBadRequestError(
+			fmt.Errorf("the DAG is not running: %w", errInvalidArgs),
+		)
+	}
+	if err := h.client.StopRun(ctx, dagStatus.DAG, requestID); err != nil {
+		return nil, newBadRequestError(
+			fmt.Errorf("error trying to stop the DAG: %w", err),
+		)
+	}
+	return &models.PostDagActionResponse{}, nil
+}
+
 func (h *Handler) processUpdateStatus(
 	ctx context.Context,
 	params dags.PostDagActionParams,
@@ -865,7 +1000,7 @@ func (h *Handler) processUpdateStatus(
 	}
 
 	// Do not allow updating the status if the DAG is still running.
-	if dagStatus.Status.Status == scheduler.StatusRunning {
+	if dagStatus.Status.Status.IsActive() {
 		return nil, newBadRequestError(
 			fmt.Errorf("the DAG is still running: %w", errInvalidArgs),
 		)
@@ -955,6 +1090,36 @@ func readFileContent(f string, decoder *encoding.Decoder) ([]byte, error) {
 	return ret, err
 }
 
+// getArtifact opens the artifact produced by the step of the DAG run.
+// It returns the file and the file name to download it as.
+func (h *Handler) getArtifact(ctx context.Context, params dags.GetDagArtifactParams) (
+	io.ReadCloser, string, *codedError,
+) {
+	dagStatus, err := h.client.GetStatus(ctx, params.DagID)
+	if err != nil {
+		return nil, "", newNotFoundError(err)
+	}
+
+	status, err := h.client.GetStatusByRequestID(ctx, dagStatus.DAG, params.RequestID)
+	if err != nil {
+		return nil, "", newNotFoundError(err)
+	}
+
+	file, err := status.ArtifactPath(params.Step, params.Path)
+	if err != nil {
+		return nil, "", newNotFoundError(err)
+	}
+
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, "", newNotFoundError(fmt.Errorf("%w: %s", model.ErrArtifactNotFound, params.Path))
+	} else if err != nil {
+		return nil, "", newInternalError(err)
+	}
+
+	return f, path.Base(params.Path), nil
+}
+
 func (h *Handler) getTagList(ctx context.Context, _ dags.ListTagsParams) (*models.ListTagResponse, *codedError) {
 	tags, errs, err := h.client.GetTagList(ctx)
 	if err != nil {
@@ -5,9 +5,16 @@ import (
 	"github.com/dagu-org/dagu/internal/config"
 	"github.com/dagu-org/dagu/internal/frontend/dag"
 	"github.com/dagu-org/dagu/internal/frontend/server"
+	"github.com/dagu-org/dagu/internal/frontend/system"
+	"github.com/dagu-org/dagu/internal/persistence"
 )
 
-func New(cfg *config.Config, cli client.Client) *server.Server {
+func New(
+	cfg *config.Config,
+	cli client.Client,
+	statusStore persistence.SchedulerStatusStore,
+	leaderLock persistence.LeaderLock,
+) *server.Server {
 	var hs []server.Handler
 
 	hs = append(hs, dag.NewHandler(
@@ -16,6 +23,14 @@ func New(cfg *config.Config, cli client.Client) *server.Server {
 			LogEncodingCharset: cfg.UI.LogEncodingCharset,
 			RemoteNodes:        cfg.RemoteNodes,
 			ApiBasePath:        cfg.APIBaseURL,
+			Location:           cfg.Location,
+		},
+	))
+
+	hs = append(hs, system.NewHandler(
+		&system.NewHandlerArgs{
+			SchedulerStatusStore: statusStore,
+			LeaderLock:           leaderLock,
 		},
 	))
 
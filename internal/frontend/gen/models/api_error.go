@@ -7,6 +7,7 @@ package models
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
@@ -23,6 +24,9 @@ type APIError struct {
 	// Required: true
 	DetailedMessage *string `json:"detailedMessage"`
 
+	// field errors
+	FieldErrors []*APIFieldError `json:"fieldErrors"`
+
 	// message
 	// Required: true
 	Message *string `json:"message"`
@@ -36,6 +40,10 @@ func (m *APIError) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateFieldErrors(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateMessage(formats); err != nil {
 		res = append(res, err)
 	}
@@ -55,6 +63,32 @@ func (m *APIError) validateDetailedMessage(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *APIError) validateFieldErrors(formats strfmt.Registry) error {
+	if swag.IsZero(m.FieldErrors) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.FieldErrors); i++ {
+		if swag.IsZero(m.FieldErrors[i]) { // not required
+			continue
+		}
+
+		if m.FieldErrors[i] != nil {
+			if err := m.FieldErrors[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("fieldErrors" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("fieldErrors" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *APIError) validateMessage(formats strfmt.Registry) error {
 
 	if err := validate.Required("message", "body", m.Message); err != nil {
@@ -64,8 +98,42 @@ func (m *APIError) validateMessage(formats strfmt.Registry) error {
 	return nil
 }
 
-// ContextValidate validates this Api error based on context it is used
+// ContextValidate validate this Api error based on the context it is used
 func (m *APIError) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateFieldErrors(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APIError) contextValidateFieldErrors(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.FieldErrors); i++ {
+
+		if m.FieldErrors[i] != nil {
+
+			if swag.IsZero(m.FieldErrors[i]) { // not required
+				return nil
+			}
+
+			if err := m.FieldErrors[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("fieldErrors" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("fieldErrors" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
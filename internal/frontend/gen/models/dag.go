@@ -47,6 +47,9 @@ type Dag struct {
 	// tags
 	// Required: true
 	Tags []string `json:"Tags"`
+
+	// The time zone of the schedules.
+	Timezone string `json:"Timezone,omitempty"`
 }
 
 // Validate validates this dag
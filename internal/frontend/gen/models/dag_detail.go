@@ -68,6 +68,9 @@ type DagDetail struct {
 	// Required: true
 	Params []string `json:"Params"`
 
+	// params schema
+	ParamsSchema []*ParamSchema `json:"ParamsSchema"`
+
 	// preconditions
 	// Required: true
 	Preconditions []*Condition `json:"Preconditions"`
@@ -83,6 +86,9 @@ type DagDetail struct {
 	// tags
 	// Required: true
 	Tags []string `json:"Tags"`
+
+	// The time zone of the schedules.
+	Timezone string `json:"Timezone,omitempty"`
 }
 
 // Validate validates this dag detail
@@ -137,6 +143,10 @@ func (m *DagDetail) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateParamsSchema(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validatePreconditions(formats); err != nil {
 		res = append(res, err)
 	}
@@ -278,6 +288,32 @@ func (m *DagDetail) validateParams(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DagDetail) validateParamsSchema(formats strfmt.Registry) error {
+	if swag.IsZero(m.ParamsSchema) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.ParamsSchema); i++ {
+		if swag.IsZero(m.ParamsSchema[i]) { // not required
+			continue
+		}
+
+		if m.ParamsSchema[i] != nil {
+			if err := m.ParamsSchema[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("ParamsSchema" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("ParamsSchema" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *DagDetail) validatePreconditions(formats strfmt.Registry) error {
 
 	if err := validate.Required("Preconditions", "body", m.Preconditions); err != nil {
@@ -376,6 +412,10 @@ func (m *DagDetail) ContextValidate(ctx context.Context, formats strfmt.Registry
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateParamsSchema(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidatePreconditions(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -411,6 +451,31 @@ func (m *DagDetail) contextValidateHandlerOn(ctx context.Context, formats strfmt
 	return nil
 }
 
+func (m *DagDetail) contextValidateParamsSchema(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.ParamsSchema); i++ {
+
+		if m.ParamsSchema[i] != nil {
+
+			if swag.IsZero(m.ParamsSchema[i]) { // not required
+				return nil
+			}
+
+			if err := m.ParamsSchema[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("ParamsSchema" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("ParamsSchema" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *DagDetail) contextValidatePreconditions(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.Preconditions); i++ {
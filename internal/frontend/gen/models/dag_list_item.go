@@ -39,6 +39,12 @@ type DagListItem struct {
 	// Required: true
 	File *string `json:"File"`
 
+	// The next scheduled run in the time zone of the DAG (RFC3339).
+	NextRun string `json:"NextRun,omitempty"`
+
+	// The next scheduled run in the time zone of the server (RFC3339).
+	NextRunServer string `json:"NextRunServer,omitempty"`
+
 	// status
 	// Required: true
 	Status *DagStatus `json:"Status"`
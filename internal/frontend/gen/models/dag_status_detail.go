@@ -20,6 +20,9 @@ import (
 // swagger:model dagStatusDetail
 type DagStatusDetail struct {
 
+	// error
+	Error string `json:"Error,omitempty"`
+
 	// finished at
 	// Required: true
 	FinishedAt *string `json:"FinishedAt"`
@@ -75,6 +78,12 @@ type DagStatusDetail struct {
 	// status text
 	// Required: true
 	StatusText *string `json:"StatusText"`
+
+	// triggered
+	Triggered []*DagRunRef `json:"Triggered"`
+
+	// triggered by
+	TriggeredBy *DagRunRef `json:"TriggeredBy,omitempty"`
 }
 
 // Validate validates this dag status detail
@@ -137,6 +146,14 @@ func (m *DagStatusDetail) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTriggered(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateTriggeredBy(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -331,6 +348,51 @@ func (m *DagStatusDetail) validateStatusText(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DagStatusDetail) validateTriggered(formats strfmt.Registry) error {
+	if swag.IsZero(m.Triggered) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Triggered); i++ {
+		if swag.IsZero(m.Triggered[i]) { // not required
+			continue
+		}
+
+		if m.Triggered[i] != nil {
+			if err := m.Triggered[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Triggered" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Triggered" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *DagStatusDetail) validateTriggeredBy(formats strfmt.Registry) error {
+	if swag.IsZero(m.TriggeredBy) { // not required
+		return nil
+	}
+
+	if m.TriggeredBy != nil {
+		if err := m.TriggeredBy.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("TriggeredBy")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("TriggeredBy")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ContextValidate validate this dag status detail based on the context it is used
 func (m *DagStatusDetail) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
@@ -355,6 +417,14 @@ func (m *DagStatusDetail) ContextValidate(ctx context.Context, formats strfmt.Re
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateTriggered(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateTriggeredBy(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -454,6 +524,52 @@ func (m *DagStatusDetail) contextValidateOnSuccess(ctx context.Context, formats
 	return nil
 }
 
+func (m *DagStatusDetail) contextValidateTriggered(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Triggered); i++ {
+
+		if m.Triggered[i] != nil {
+
+			if swag.IsZero(m.Triggered[i]) { // not required
+				return nil
+			}
+
+			if err := m.Triggered[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Triggered" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Triggered" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *DagStatusDetail) contextValidateTriggeredBy(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.TriggeredBy != nil {
+
+		if swag.IsZero(m.TriggeredBy) { // not required
+			return nil
+		}
+
+		if err := m.TriggeredBy.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("TriggeredBy")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("TriggeredBy")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *DagStatusDetail) MarshalBinary() ([]byte, error) {
 	if m == nil {
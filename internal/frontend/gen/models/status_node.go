@@ -7,6 +7,7 @@ package models
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
@@ -19,6 +20,15 @@ import (
 // swagger:model statusNode
 type StatusNode struct {
 
+	// approval
+	Approval *StatusNodeApproval `json:"Approval,omitempty"`
+
+	// artifacts
+	Artifacts []string `json:"Artifacts"`
+
+	// attempts
+	Attempts []*StatusNodeAttempt `json:"Attempts"`
+
 	// done count
 	// Required: true
 	DoneCount *int64 `json:"DoneCount"`
@@ -31,10 +41,19 @@ type StatusNode struct {
 	// Required: true
 	FinishedAt *string `json:"FinishedAt"`
 
+	// iterations
+	Iterations int64 `json:"Iterations,omitempty"`
+
+	// last result
+	LastResult string `json:"LastResult,omitempty"`
+
 	// log
 	// Required: true
 	Log *string `json:"Log"`
 
+	// outputs
+	Outputs map[string]string `json:"Outputs,omitempty"`
+
 	// retry count
 	// Required: true
 	RetryCount *int64 `json:"RetryCount"`
@@ -60,6 +79,14 @@ type StatusNode struct {
 func (m *StatusNode) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateApproval(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateAttempts(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDoneCount(formats); err != nil {
 		res = append(res, err)
 	}
@@ -102,6 +129,51 @@ func (m *StatusNode) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *StatusNode) validateApproval(formats strfmt.Registry) error {
+	if swag.IsZero(m.Approval) { // not required
+		return nil
+	}
+
+	if m.Approval != nil {
+		if err := m.Approval.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("Approval")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("Approval")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *StatusNode) validateAttempts(formats strfmt.Registry) error {
+	if swag.IsZero(m.Attempts) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Attempts); i++ {
+		if swag.IsZero(m.Attempts[i]) { // not required
+			continue
+		}
+
+		if m.Attempts[i] != nil {
+			if err := m.Attempts[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Attempts" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Attempts" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *StatusNode) validateDoneCount(formats strfmt.Registry) error {
 
 	if err := validate.Required("DoneCount", "body", m.DoneCount); err != nil {
@@ -198,6 +270,14 @@ func (m *StatusNode) validateStep(formats strfmt.Registry) error {
 func (m *StatusNode) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateApproval(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateAttempts(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateStep(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -208,6 +288,52 @@ func (m *StatusNode) ContextValidate(ctx context.Context, formats strfmt.Registr
 	return nil
 }
 
+func (m *StatusNode) contextValidateApproval(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Approval != nil {
+
+		if swag.IsZero(m.Approval) { // not required
+			return nil
+		}
+
+		if err := m.Approval.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("Approval")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("Approval")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *StatusNode) contextValidateAttempts(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Attempts); i++ {
+
+		if m.Attempts[i] != nil {
+
+			if swag.IsZero(m.Attempts[i]) { // not required
+				return nil
+			}
+
+			if err := m.Attempts[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Attempts" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Attempts" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *StatusNode) contextValidateStep(ctx context.Context, formats strfmt.Registry) error {
 
 	if m.Step != nil {
@@ -38,6 +38,8 @@ func configureAPI(api *operations.DaguAPI) http.Handler {
 
 	api.JSONConsumer = runtime.JSONConsumer()
 
+	api.BinProducer = runtime.ByteStreamProducer()
+
 	api.JSONProducer = runtime.JSONProducer()
 
 	if api.DagsListDagsHandler == nil {
@@ -215,9 +215,16 @@ func init() {
                     "mark-success",
                     "mark-failed",
                     "save",
-                    "rename"
+                    "rename",
+                    "approve",
+                    "reject",
+                    "pause",
+                    "resume"
                   ]
                 },
+                "onlyStep": {
+                  "type": "boolean"
+                },
                 "params": {
                   "type": "string"
                 },
@@ -279,6 +286,90 @@ func init() {
         }
       }
     },
+    "/dags/{dagId}/artifacts": {
+      "get": {
+        "description": "Downloads an artifact produced by a step of a DAG run.",
+        "produces": [
+          "application/octet-stream",
+          "application/json"
+        ],
+        "tags": [
+          "dags"
+        ],
+        "operationId": "getDagArtifact",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "step",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "path",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "type": "file"
+            },
+            "headers": {
+              "Content-Disposition": {
+                "type": "string"
+              }
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/scheduler": {
+      "get": {
+        "description": "Returns the status of the schedulers.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "system"
+        ],
+        "operationId": "getSchedulerStatus",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/schedulerStatusResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
     "/search": {
       "get": {
         "description": "Searches for DAGs.",
@@ -351,6 +442,23 @@ func init() {
         "detailedMessage": {
           "type": "string"
         },
+        "fieldErrors": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ApiFieldError"
+          }
+        },
+        "message": {
+          "type": "string"
+        }
+      }
+    },
+    "ApiFieldError": {
+      "type": "object",
+      "properties": {
+        "field": {
+          "type": "string"
+        },
         "message": {
           "type": "string"
         }
@@ -419,6 +527,10 @@ func init() {
           "items": {
             "type": "string"
           }
+        },
+        "Timezone": {
+          "description": "The time zone of the schedules.",
+          "type": "string"
         }
       }
     },
@@ -485,6 +597,12 @@ func init() {
             "type": "string"
           }
         },
+        "ParamsSchema": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/paramSchema"
+          }
+        },
         "Preconditions": {
           "type": "array",
           "items": {
@@ -508,6 +626,10 @@ func init() {
           "items": {
             "type": "string"
           }
+        },
+        "Timezone": {
+          "description": "The time zone of the schedules.",
+          "type": "string"
         }
       }
     },
@@ -538,6 +660,14 @@ func init() {
         "File": {
           "type": "string"
         },
+        "NextRun": {
+          "description": "The next scheduled run in the time zone of the DAG (RFC3339).",
+          "type": "string"
+        },
+        "NextRunServer": {
+          "description": "The next scheduled run in the time zone of the server (RFC3339).",
+          "type": "string"
+        },
         "Status": {
           "$ref": "#/definitions/dagStatus"
         },
@@ -585,6 +715,31 @@ func init() {
         }
       }
     },
+    "dagRunRef": {
+      "type": "object",
+      "required": [
+        "Name",
+        "DagId",
+        "RequestId"
+      ],
+      "properties": {
+        "DagId": {
+          "type": "string"
+        },
+        "Error": {
+          "type": "string"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "On": {
+          "type": "string"
+        },
+        "RequestId": {
+          "type": "string"
+        }
+      }
+    },
     "dagSchedulerLogResponse": {
       "type": "object",
       "required": [
@@ -662,6 +817,9 @@ func init() {
         "Params"
       ],
       "properties": {
+        "Error": {
+          "type": "string"
+        },
         "FinishedAt": {
           "type": "string"
         },
@@ -706,6 +864,15 @@ func init() {
         },
         "StatusText": {
           "type": "string"
+        },
+        "Triggered": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/dagRunRef"
+          }
+        },
+        "TriggeredBy": {
+          "$ref": "#/definitions/dagRunRef"
         }
       }
     },
@@ -750,6 +917,14 @@ func init() {
         "File": {
           "type": "string"
         },
+        "NextRun": {
+          "description": "The next scheduled run in the time zone of the DAG (RFC3339).",
+          "type": "string"
+        },
+        "NextRunServer": {
+          "description": "The next scheduled run in the time zone of the server (RFC3339).",
+          "type": "string"
+        },
         "Status": {
           "$ref": "#/definitions/dagStatusDetail"
         },
@@ -894,6 +1069,49 @@ func init() {
         }
       }
     },
+    "paramSchema": {
+      "type": "object",
+      "properties": {
+        "Default": {
+          "type": "string"
+        },
+        "Description": {
+          "type": "string"
+        },
+        "Enum": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "MaxLength": {
+          "type": "integer"
+        },
+        "Maximum": {
+          "type": "number",
+          "format": "double"
+        },
+        "MinLength": {
+          "type": "integer"
+        },
+        "Minimum": {
+          "type": "number",
+          "format": "double"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Pattern": {
+          "type": "string"
+        },
+        "Required": {
+          "type": "boolean"
+        },
+        "Type": {
+          "type": "string"
+        }
+      }
+    },
     "postDagActionResponse": {
       "type": "object",
       "properties": {
@@ -924,6 +1142,65 @@ func init() {
         }
       }
     },
+    "schedulerInstance": {
+      "type": "object",
+      "required": [
+        "Id",
+        "Host",
+        "Pid",
+        "Role"
+      ],
+      "properties": {
+        "Host": {
+          "type": "string"
+        },
+        "Id": {
+          "type": "string"
+        },
+        "LastTick": {
+          "type": "string"
+        },
+        "Pid": {
+          "type": "integer"
+        },
+        "Role": {
+          "type": "string"
+        },
+        "Token": {
+          "type": "integer"
+        },
+        "UpdatedAt": {
+          "type": "string"
+        }
+      }
+    },
+    "schedulerStatusResponse": {
+      "type": "object",
+      "required": [
+        "LeaderElection",
+        "Schedulers"
+      ],
+      "properties": {
+        "Leader": {
+          "type": "string"
+        },
+        "LeaderElection": {
+          "type": "boolean"
+        },
+        "LeaseExpiresAt": {
+          "type": "string"
+        },
+        "Schedulers": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/schedulerInstance"
+          }
+        },
+        "Token": {
+          "type": "integer"
+        }
+      }
+    },
     "searchDagsMatchItem": {
       "type": "object",
       "properties": {
@@ -990,6 +1267,21 @@ func init() {
         "StatusText"
       ],
       "properties": {
+        "Approval": {
+          "$ref": "#/definitions/statusNodeApproval"
+        },
+        "Artifacts": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Attempts": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/statusNodeAttempt"
+          }
+        },
         "DoneCount": {
           "type": "integer"
         },
@@ -999,9 +1291,21 @@ func init() {
         "FinishedAt": {
           "type": "string"
         },
+        "Iterations": {
+          "type": "integer"
+        },
+        "LastResult": {
+          "type": "string"
+        },
         "Log": {
           "type": "string"
         },
+        "Outputs": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
         "RetryCount": {
           "type": "integer"
         },
@@ -1019,6 +1323,46 @@ func init() {
         }
       }
     },
+    "statusNodeApproval": {
+      "type": "object",
+      "properties": {
+        "Approved": {
+          "type": "boolean"
+        },
+        "At": {
+          "type": "string"
+        },
+        "Comment": {
+          "type": "string"
+        },
+        "Message": {
+          "type": "string"
+        },
+        "User": {
+          "type": "string"
+        }
+      }
+    },
+    "statusNodeAttempt": {
+      "type": "object",
+      "properties": {
+        "Attempt": {
+          "type": "integer"
+        },
+        "Error": {
+          "type": "string"
+        },
+        "ExitCode": {
+          "type": "integer"
+        },
+        "FinishedAt": {
+          "type": "string"
+        },
+        "StartedAt": {
+          "type": "string"
+        }
+      }
+    },
     "stepObject": {
       "type": "object",
       "required": [
@@ -1109,6 +1453,10 @@ func init() {
     {
       "description": "Operations about DAGs",
       "name": "dags"
+    },
+    {
+      "description": "Operations about the system",
+      "name": "system"
     }
   ]
 }`))
@@ -1310,9 +1658,16 @@ func init() {
                     "mark-success",
                     "mark-failed",
                     "save",
-                    "rename"
+                    "rename",
+                    "approve",
+                    "reject",
+                    "pause",
+                    "resume"
                   ]
                 },
+                "onlyStep": {
+                  "type": "boolean"
+                },
                 "params": {
                   "type": "string"
                 },
@@ -1374,6 +1729,90 @@ func init() {
         }
       }
     },
+    "/dags/{dagId}/artifacts": {
+      "get": {
+        "description": "Downloads an artifact produced by a step of a DAG run.",
+        "produces": [
+          "application/octet-stream",
+          "application/json"
+        ],
+        "tags": [
+          "dags"
+        ],
+        "operationId": "getDagArtifact",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "step",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "path",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "type": "file"
+            },
+            "headers": {
+              "Content-Disposition": {
+                "type": "string"
+              }
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/scheduler": {
+      "get": {
+        "description": "Returns the status of the schedulers.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "system"
+        ],
+        "operationId": "getSchedulerStatus",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/schedulerStatusResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
     "/search": {
       "get": {
         "description": "Searches for DAGs.",
@@ -1446,6 +1885,23 @@ func init() {
         "detailedMessage": {
           "type": "string"
         },
+        "fieldErrors": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ApiFieldError"
+          }
+        },
+        "message": {
+          "type": "string"
+        }
+      }
+    },
+    "ApiFieldError": {
+      "type": "object",
+      "properties": {
+        "field": {
+          "type": "string"
+        },
         "message": {
           "type": "string"
         }
@@ -1514,6 +1970,10 @@ func init() {
           "items": {
             "type": "string"
           }
+        },
+        "Timezone": {
+          "description": "The time zone of the schedules.",
+          "type": "string"
         }
       }
     },
@@ -1580,6 +2040,12 @@ func init() {
             "type": "string"
           }
         },
+        "ParamsSchema": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/paramSchema"
+          }
+        },
         "Preconditions": {
           "type": "array",
           "items": {
@@ -1603,6 +2069,10 @@ func init() {
           "items": {
             "type": "string"
           }
+        },
+        "Timezone": {
+          "description": "The time zone of the schedules.",
+          "type": "string"
         }
       }
     },
@@ -1633,6 +2103,14 @@ func init() {
         "File": {
           "type": "string"
         },
+        "NextRun": {
+          "description": "The next scheduled run in the time zone of the DAG (RFC3339).",
+          "type": "string"
+        },
+        "NextRunServer": {
+          "description": "The next scheduled run in the time zone of the server (RFC3339).",
+          "type": "string"
+        },
         "Status": {
           "$ref": "#/definitions/dagStatus"
         },
@@ -1680,6 +2158,31 @@ func init() {
         }
       }
     },
+    "dagRunRef": {
+      "type": "object",
+      "required": [
+        "Name",
+        "DagId",
+        "RequestId"
+      ],
+      "properties": {
+        "DagId": {
+          "type": "string"
+        },
+        "Error": {
+          "type": "string"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "On": {
+          "type": "string"
+        },
+        "RequestId": {
+          "type": "string"
+        }
+      }
+    },
     "dagSchedulerLogResponse": {
       "type": "object",
       "required": [
@@ -1757,6 +2260,9 @@ func init() {
         "Params"
       ],
       "properties": {
+        "Error": {
+          "type": "string"
+        },
         "FinishedAt": {
           "type": "string"
         },
@@ -1801,6 +2307,15 @@ func init() {
         },
         "StatusText": {
           "type": "string"
+        },
+        "Triggered": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/dagRunRef"
+          }
+        },
+        "TriggeredBy": {
+          "$ref": "#/definitions/dagRunRef"
         }
       }
     },
@@ -1845,6 +2360,14 @@ func init() {
         "File": {
           "type": "string"
         },
+        "NextRun": {
+          "description": "The next scheduled run in the time zone of the DAG (RFC3339).",
+          "type": "string"
+        },
+        "NextRunServer": {
+          "description": "The next scheduled run in the time zone of the server (RFC3339).",
+          "type": "string"
+        },
         "Status": {
           "$ref": "#/definitions/dagStatusDetail"
         },
@@ -1989,6 +2512,49 @@ func init() {
         }
       }
     },
+    "paramSchema": {
+      "type": "object",
+      "properties": {
+        "Default": {
+          "type": "string"
+        },
+        "Description": {
+          "type": "string"
+        },
+        "Enum": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "MaxLength": {
+          "type": "integer"
+        },
+        "Maximum": {
+          "type": "number",
+          "format": "double"
+        },
+        "MinLength": {
+          "type": "integer"
+        },
+        "Minimum": {
+          "type": "number",
+          "format": "double"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Pattern": {
+          "type": "string"
+        },
+        "Required": {
+          "type": "boolean"
+        },
+        "Type": {
+          "type": "string"
+        }
+      }
+    },
     "postDagActionResponse": {
       "type": "object",
       "properties": {
@@ -2019,6 +2585,65 @@ func init() {
         }
       }
     },
+    "schedulerInstance": {
+      "type": "object",
+      "required": [
+        "Id",
+        "Host",
+        "Pid",
+        "Role"
+      ],
+      "properties": {
+        "Host": {
+          "type": "string"
+        },
+        "Id": {
+          "type": "string"
+        },
+        "LastTick": {
+          "type": "string"
+        },
+        "Pid": {
+          "type": "integer"
+        },
+        "Role": {
+          "type": "string"
+        },
+        "Token": {
+          "type": "integer"
+        },
+        "UpdatedAt": {
+          "type": "string"
+        }
+      }
+    },
+    "schedulerStatusResponse": {
+      "type": "object",
+      "required": [
+        "LeaderElection",
+        "Schedulers"
+      ],
+      "properties": {
+        "Leader": {
+          "type": "string"
+        },
+        "LeaderElection": {
+          "type": "boolean"
+        },
+        "LeaseExpiresAt": {
+          "type": "string"
+        },
+        "Schedulers": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/schedulerInstance"
+          }
+        },
+        "Token": {
+          "type": "integer"
+        }
+      }
+    },
     "searchDagsMatchItem": {
       "type": "object",
       "properties": {
@@ -2085,6 +2710,21 @@ func init() {
         "StatusText"
       ],
       "properties": {
+        "Approval": {
+          "$ref": "#/definitions/statusNodeApproval"
+        },
+        "Artifacts": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Attempts": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/statusNodeAttempt"
+          }
+        },
         "DoneCount": {
           "type": "integer"
         },
@@ -2094,9 +2734,21 @@ func init() {
         "FinishedAt": {
           "type": "string"
         },
+        "Iterations": {
+          "type": "integer"
+        },
+        "LastResult": {
+          "type": "string"
+        },
         "Log": {
           "type": "string"
         },
+        "Outputs": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
         "RetryCount": {
           "type": "integer"
         },
@@ -2114,6 +2766,46 @@ func init() {
         }
       }
     },
+    "statusNodeApproval": {
+      "type": "object",
+      "properties": {
+        "Approved": {
+          "type": "boolean"
+        },
+        "At": {
+          "type": "string"
+        },
+        "Comment": {
+          "type": "string"
+        },
+        "Message": {
+          "type": "string"
+        },
+        "User": {
+          "type": "string"
+        }
+      }
+    },
+    "statusNodeAttempt": {
+      "type": "object",
+      "properties": {
+        "Attempt": {
+          "type": "integer"
+        },
+        "Error": {
+          "type": "string"
+        },
+        "ExitCode": {
+          "type": "integer"
+        },
+        "FinishedAt": {
+          "type": "string"
+        },
+        "StartedAt": {
+          "type": "string"
+        }
+      }
+    },
     "stepObject": {
       "type": "object",
       "required": [
@@ -2204,6 +2896,10 @@ func init() {
     {
       "description": "Operations about DAGs",
       "name": "dags"
+    },
+    {
+      "description": "Operations about the system",
+      "name": "system"
     }
   ]
 }`))
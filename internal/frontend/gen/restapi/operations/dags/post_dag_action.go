@@ -68,9 +68,12 @@ type PostDagActionBody struct {
 
 	// action
 	// Required: true
-	// Enum: [start suspend stop retry mark-success mark-failed save rename]
+	// Enum: [start suspend stop retry mark-success mark-failed save rename approve reject pause resume]
 	Action *string `json:"action"`
 
+	// only step
+	OnlyStep bool `json:"onlyStep,omitempty"`
+
 	// params
 	Params string `json:"params,omitempty"`
 
@@ -102,7 +105,7 @@ var postDagActionBodyTypeActionPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["start","suspend","stop","retry","mark-success","mark-failed","save","rename"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["start","suspend","stop","retry","mark-success","mark-failed","save","rename","approve","reject","pause","resume"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -135,6 +138,18 @@ const (
 
 	// PostDagActionBodyActionRename captures enum value "rename"
 	PostDagActionBodyActionRename string = "rename"
+
+	// PostDagActionBodyActionApprove captures enum value "approve"
+	PostDagActionBodyActionApprove string = "approve"
+
+	// PostDagActionBodyActionReject captures enum value "reject"
+	PostDagActionBodyActionReject string = "reject"
+
+	// PostDagActionBodyActionPause captures enum value "pause"
+	PostDagActionBodyActionPause string = "pause"
+
+	// PostDagActionBodyActionResume captures enum value "resume"
+	PostDagActionBodyActionResume string = "resume"
 )
 
 // prop value enum
@@ -20,6 +20,7 @@ import (
 	"github.com/go-openapi/swag"
 
 	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/dags"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/system"
 )
 
 // NewDaguAPI creates a new Dagu instance
@@ -42,6 +43,7 @@ func NewDaguAPI(spec *loads.Document) *DaguAPI {
 
 		JSONConsumer: runtime.JSONConsumer(),
 
+		BinProducer:  runtime.ByteStreamProducer(),
 		JSONProducer: runtime.JSONProducer(),
 
 		DagsCreateDagHandler: dags.CreateDagHandlerFunc(func(params dags.CreateDagParams) middleware.Responder {
@@ -50,6 +52,9 @@ func NewDaguAPI(spec *loads.Document) *DaguAPI {
 		DagsDeleteDagHandler: dags.DeleteDagHandlerFunc(func(params dags.DeleteDagParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.DeleteDag has not yet been implemented")
 		}),
+		DagsGetDagArtifactHandler: dags.GetDagArtifactHandlerFunc(func(params dags.GetDagArtifactParams) middleware.Responder {
+			return middleware.NotImplemented("operation dags.GetDagArtifact has not yet been implemented")
+		}),
 		DagsGetDagDetailsHandler: dags.GetDagDetailsHandlerFunc(func(params dags.GetDagDetailsParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.GetDagDetails has not yet been implemented")
 		}),
@@ -65,6 +70,9 @@ func NewDaguAPI(spec *loads.Document) *DaguAPI {
 		DagsSearchDagsHandler: dags.SearchDagsHandlerFunc(func(params dags.SearchDagsParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.SearchDags has not yet been implemented")
 		}),
+		SystemGetSchedulerStatusHandler: system.GetSchedulerStatusHandlerFunc(func(params system.GetSchedulerStatusParams) middleware.Responder {
+			return middleware.NotImplemented("operation system.GetSchedulerStatus has not yet been implemented")
+		}),
 	}
 }
 
@@ -100,6 +108,9 @@ type DaguAPI struct {
 	//   - application/json
 	JSONConsumer runtime.Consumer
 
+	// BinProducer registers a producer for the following mime types:
+	//   - application/octet-stream
+	BinProducer runtime.Producer
 	// JSONProducer registers a producer for the following mime types:
 	//   - application/json
 	JSONProducer runtime.Producer
@@ -108,6 +119,8 @@ type DaguAPI struct {
 	DagsCreateDagHandler dags.CreateDagHandler
 	// DagsDeleteDagHandler sets the operation handler for the delete dag operation
 	DagsDeleteDagHandler dags.DeleteDagHandler
+	// DagsGetDagArtifactHandler sets the operation handler for the get dag artifact operation
+	DagsGetDagArtifactHandler dags.GetDagArtifactHandler
 	// DagsGetDagDetailsHandler sets the operation handler for the get dag details operation
 	DagsGetDagDetailsHandler dags.GetDagDetailsHandler
 	// DagsListDagsHandler sets the operation handler for the list dags operation
@@ -118,6 +131,8 @@ type DaguAPI struct {
 	DagsPostDagActionHandler dags.PostDagActionHandler
 	// DagsSearchDagsHandler sets the operation handler for the search dags operation
 	DagsSearchDagsHandler dags.SearchDagsHandler
+	// SystemGetSchedulerStatusHandler sets the operation handler for the get scheduler status operation
+	SystemGetSchedulerStatusHandler system.GetSchedulerStatusHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -191,6 +206,9 @@ func (o *DaguAPI) Validate() error {
 		unregistered = append(unregistered, "JSONConsumer")
 	}
 
+	if o.BinProducer == nil {
+		unregistered = append(unregistered, "BinProducer")
+	}
 	if o.JSONProducer == nil {
 		unregistered = append(unregistered, "JSONProducer")
 	}
@@ -201,6 +219,9 @@ func (o *DaguAPI) Validate() error {
 	if o.DagsDeleteDagHandler == nil {
 		unregistered = append(unregistered, "dags.DeleteDagHandler")
 	}
+	if o.DagsGetDagArtifactHandler == nil {
+		unregistered = append(unregistered, "dags.GetDagArtifactHandler")
+	}
 	if o.DagsGetDagDetailsHandler == nil {
 		unregistered = append(unregistered, "dags.GetDagDetailsHandler")
 	}
@@ -216,6 +237,9 @@ func (o *DaguAPI) Validate() error {
 	if o.DagsSearchDagsHandler == nil {
 		unregistered = append(unregistered, "dags.SearchDagsHandler")
 	}
+	if o.SystemGetSchedulerStatusHandler == nil {
+		unregistered = append(unregistered, "system.GetSchedulerStatusHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -262,6 +286,8 @@ func (o *DaguAPI) ProducersFor(mediaTypes []string) map[string]runtime.Producer
 	result := make(map[string]runtime.Producer, len(mediaTypes))
 	for _, mt := range mediaTypes {
 		switch mt {
+		case "application/octet-stream":
+			result["application/octet-stream"] = o.BinProducer
 		case "application/json":
 			result["application/json"] = o.JSONProducer
 		}
@@ -315,6 +341,10 @@ func (o *DaguAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/dags/{dagId}/artifacts"] = dags.NewGetDagArtifact(o.context, o.DagsGetDagArtifactHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/dags/{dagId}"] = dags.NewGetDagDetails(o.context, o.DagsGetDagDetailsHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -332,6 +362,10 @@ func (o *DaguAPI) initHandlerCache() {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/search"] = dags.NewSearchDags(o.context, o.DagsSearchDagsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/scheduler"] = system.NewGetSchedulerStatus(o.context, o.SystemGetSchedulerStatusHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
@@ -0,0 +1,107 @@
+package system
+
+import (
+	"time"
+
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/system"
+	"github.com/dagu-org/dagu/internal/frontend/server"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+)
+
+// Handler is a handler for the system API.
+type Handler struct {
+	statusStore persistence.SchedulerStatusStore
+	leaderLock  persistence.LeaderLock
+}
+
+type NewHandlerArgs struct {
+	SchedulerStatusStore persistence.SchedulerStatusStore
+	// LeaderLock is nil if the leader election is disabled.
+	LeaderLock persistence.LeaderLock
+}
+
+func NewHandler(args *NewHandlerArgs) server.Handler {
+	return &Handler{
+		statusStore: args.SchedulerStatusStore,
+		leaderLock:  args.LeaderLock,
+	}
+}
+
+func (h *Handler) Configure(api *operations.DaguAPI) {
+	api.SystemGetSchedulerStatusHandler = system.GetSchedulerStatusHandlerFunc(
+		func(_ system.GetSchedulerStatusParams) middleware.Responder {
+			resp, err := h.getSchedulerStatus()
+			if err != nil {
+				return system.NewGetSchedulerStatusDefault(500).
+					WithPayload(&models.APIError{
+						Message:         swag.String("Internal Server Error"),
+						DetailedMessage: swag.String(err.Error()),
+					})
+			}
+			return system.NewGetSchedulerStatusOK().WithPayload(resp)
+		})
+}
+
+// getSchedulerStatus returns the schedulers that are running. The leader is
+// determined by the lease of the leader lock rather than the status reported
+// by the schedulers, which may be outdated.
+func (h *Handler) getSchedulerStatus() (*models.SchedulerStatusResponse, error) {
+	now := time.Now()
+	resp := &models.SchedulerStatusResponse{
+		LeaderElection: swag.Bool(h.leaderLock != nil),
+		Schedulers:     []*models.SchedulerInstance{},
+	}
+
+	var lease *model.Lease
+	if h.leaderLock != nil {
+		var err error
+		lease, err = h.leaderLock.Lease()
+		if err != nil {
+			return nil, err
+		}
+		if lease.IsValid(now) {
+			resp.Leader = lease.Holder
+			resp.Token = lease.Token
+			resp.LeaseExpiresAt = lease.ExpiresAt.Format(time.RFC3339)
+		}
+	}
+
+	statuses, err := h.statusStore.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		if now.After(status.ExpiresAt) {
+			// The scheduler is stopped without deleting its status.
+			continue
+		}
+		role := status.Role
+		if lease != nil {
+			role = model.SchedulerRoleStandby
+			if lease.IsValid(now) && lease.Holder == status.ID {
+				role = model.SchedulerRoleLeader
+			}
+		}
+		instance := &models.SchedulerInstance{
+			ID:        swag.String(status.ID),
+			Host:      swag.String(status.Host),
+			Pid:       swag.Int64(int64(status.PID)),
+			Role:      swag.String(string(role)),
+			UpdatedAt: status.UpdatedAt.Format(time.RFC3339),
+		}
+		if role == model.SchedulerRoleLeader {
+			instance.Token = status.Token
+		}
+		if !status.LastTick.IsZero() {
+			instance.LastTick = status.LastTick.Format(time.RFC3339)
+		}
+		resp.Schedulers = append(resp.Schedulers, instance)
+	}
+
+	return resp, nil
+}
@@ -14,10 +14,13 @@ var (
 	ErrRequestIDNotFound = fmt.Errorf("request id not found")
 	ErrNoStatusDataToday = fmt.Errorf("no status data today")
 	ErrNoStatusData      = fmt.Errorf("no status data")
+	ErrLeaderLockHeld    = fmt.Errorf("leader lock is held by another scheduler")
 )
 
 type HistoryStore interface {
 	Open(ctx context.Context, key string, timestamp time.Time, requestID string) error
+	// Reopen opens the status file of the request ID to append the status.
+	Reopen(ctx context.Context, key, requestID string) error
 	Write(ctx context.Context, status model.Status) error
 	Close(ctx context.Context) error
 	Update(ctx context.Context, key, requestID string, status model.Status) error
@@ -66,3 +69,36 @@ type FlagStore interface {
 	ToggleSuspend(id string, suspend bool) error
 	IsSuspended(id string) bool
 }
+
+// TickStore stores the last schedule tick processed by the scheduler for
+// each DAG to catch up the ticks missed while the scheduler was down.
+type TickStore interface {
+	// LastTick returns the last processed tick of the DAG, or the zero time
+	// if no tick has been processed yet.
+	LastTick(id string) (time.Time, error)
+	SetLastTick(id string, tick time.Time) error
+}
+
+// LeaderLock is the lock to elect the leader among the schedulers sharing the
+// DAGs. The leader must renew the lease before it expires; otherwise, another
+// scheduler acquires the lock. The fencing token of the lease increases every
+// time the lock is newly acquired, so that the previous leader can tell that
+// it's no longer the leader.
+type LeaderLock interface {
+	// Acquire acquires the lock for the holder or renews the lease of the
+	// holder. It returns ErrLeaderLockHeld with the current lease if the
+	// lease of another holder hasn't expired.
+	Acquire(holder string, duration time.Duration) (*model.Lease, error)
+	// Release releases the lock if the holder has it.
+	Release(holder string) error
+	// Lease returns the current lease, or nil if the lock has never been
+	// acquired.
+	Lease() (*model.Lease, error)
+}
+
+// SchedulerStatusStore stores the status of the running schedulers.
+type SchedulerStatusStore interface {
+	Write(status *model.SchedulerStatus) error
+	Delete(id string) error
+	List() ([]*model.SchedulerStatus, error)
+}
@@ -133,6 +133,25 @@ func (db *JSONDB) Open(ctx context.Context, key string, timestamp time.Time, req
 	return nil
 }
 
+// Reopen opens the existing status file of the request ID to append the
+// status, e.g. to continue the run whose agent process died.
+func (db *JSONDB) Reopen(ctx context.Context, key, requestID string) error {
+	statusFile, err := db.FindByRequestID(ctx, key, requestID)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof(ctx, "Reopening status file: %s", statusFile.File)
+
+	writer := newWriter(statusFile.File)
+	if err := writer.open(); err != nil {
+		return err
+	}
+
+	db.writer = writer
+	return nil
+}
+
 func (db *JSONDB) Write(_ context.Context, status model.Status) error {
 	return db.writer.write(status)
 }
@@ -64,6 +64,29 @@ func TestJSONDB_Basic(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, scheduler.StatusSuccess, statusFile.Status.Status)
 	})
+
+	t.Run("Reopen", func(t *testing.T) {
+		dag := th.DAG("test_reopen")
+		requestID := "request-id-test-reopen"
+
+		err := th.DB.Open(th.Context, dag.Location, time.Now(), requestID)
+		require.NoError(t, err)
+		status := model.NewStatusFactory(dag.DAG).Create(
+			requestID, scheduler.StatusRunning, testPID, time.Now(),
+		)
+		require.NoError(t, th.DB.Write(th.Context, status))
+		require.NoError(t, th.DB.Close(th.Context))
+
+		// Append the status to the same file
+		require.NoError(t, th.DB.Reopen(th.Context, dag.Location, requestID))
+		status.Status = scheduler.StatusSuccess
+		require.NoError(t, th.DB.Write(th.Context, status))
+		require.NoError(t, th.DB.Close(th.Context))
+
+		statuses := th.DB.ReadStatusRecent(th.Context, dag.Location, 10)
+		require.Len(t, statuses, 1)
+		assert.Equal(t, scheduler.StatusSuccess, statuses[0].Status.Status)
+	})
 }
 
 func TestJSONDB_ReadStatus(t *testing.T) {
@@ -23,7 +23,8 @@ var _ persistence.DAGStore = (*dagStoreImpl)(nil)
 type DAGStoreOption func(*DAGStoreOptions)
 
 type DAGStoreOptions struct {
-	FileCache *filecache.Cache[*digraph.DAG]
+	FileCache    *filecache.Cache[*digraph.DAG]
+	TemplatesDir string
 }
 
 func WithFileCache(cache *filecache.Cache[*digraph.DAG]) DAGStoreOption {
@@ -32,9 +33,17 @@ func WithFileCache(cache *filecache.Cache[*digraph.DAG]) DAGStoreOption {
 	}
 }
 
+// WithTemplatesDir sets the directory to look up the step templates.
+func WithTemplatesDir(dir string) DAGStoreOption {
+	return func(o *DAGStoreOptions) {
+		o.TemplatesDir = dir
+	}
+}
+
 type dagStoreImpl struct {
-	baseDir   string
-	fileCache *filecache.Cache[*digraph.DAG]
+	baseDir      string
+	fileCache    *filecache.Cache[*digraph.DAG]
+	templatesDir string
 }
 
 func NewDAGStore(dir string, opts ...DAGStoreOption) persistence.DAGStore {
@@ -44,8 +53,9 @@ func NewDAGStore(dir string, opts ...DAGStoreOption) persistence.DAGStore {
 	}
 
 	return &dagStoreImpl{
-		baseDir:   dir,
-		fileCache: options.FileCache,
+		baseDir:      dir,
+		fileCache:    options.FileCache,
+		templatesDir: options.TemplatesDir,
 	}
 }
 
@@ -69,7 +79,7 @@ func (d *dagStoreImpl) GetDetails(ctx context.Context, name string) (*digraph.DA
 	if err != nil {
 		return nil, fmt.Errorf("failed to locate DAG %s: %w", name, err)
 	}
-	dat, err := digraph.Load(ctx, filePath, digraph.WithoutEval())
+	dat, err := digraph.Load(ctx, filePath, digraph.WithoutEval(), digraph.WithTemplatesDir(d.templatesDir))
 	if err != nil {
 		return nil, fmt.Errorf("failed to load DAG %s: %w", name, err)
 	}
@@ -95,7 +105,7 @@ const defaultPerm os.FileMode = 0744
 // UpdateSpec updates the specification of a DAG by its name.
 func (d *dagStoreImpl) UpdateSpec(ctx context.Context, name string, spec []byte) error {
 	// Validate the spec before saving it.
-	_, err := digraph.LoadYAML(ctx, spec, digraph.WithoutEval())
+	_, err := digraph.LoadYAML(ctx, spec, digraph.WithoutEval(), digraph.WithTemplatesDir(d.templatesDir))
 	if err != nil {
 		return err
 	}
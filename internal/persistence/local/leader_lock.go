@@ -0,0 +1,200 @@
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/local/storage"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/google/uuid"
+)
+
+const (
+	leaseFile      = "leader.json"
+	leaseMutexFile = "leader.lock"
+	// leaseTakeoverPrefix is the prefix of the file created exclusively to
+	// take over the stale mutex file, followed by the nonce of the file.
+	leaseTakeoverPrefix = "leader.lock.takeover-"
+)
+
+var (
+	// leaseMutexTimeout is the time after which the mutex file is regarded
+	// as left by a crashed scheduler and removed.
+	leaseMutexTimeout       = 10 * time.Second
+	leaseMutexRetryInterval = 50 * time.Millisecond
+
+	errLeaseMutexTimeout = errors.New("timeout waiting for the leader lock file")
+)
+
+// leaderLockImpl is the leader lock with the lease file on the file system
+// shared by the schedulers. The lease file is read and updated while holding
+// the mutex file created exclusively, which works on NFS as well, and it's
+// replaced atomically so that it can be read without the mutex.
+type leaderLockImpl struct {
+	storage *storage.Storage
+}
+
+func NewLeaderLock(s *storage.Storage) persistence.LeaderLock {
+	return &leaderLockImpl{
+		storage: s,
+	}
+}
+
+func (l *leaderLockImpl) Acquire(holder string, duration time.Duration) (*model.Lease, error) {
+	var ret *model.Lease
+	err := l.withMutex(func() error {
+		current, err := l.Lease()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if current != nil && current.Holder != holder && current.IsValid(now) {
+			ret = current
+			return persistence.ErrLeaderLockHeld
+		}
+
+		lease := &model.Lease{
+			Holder:     holder,
+			Token:      1,
+			AcquiredAt: now,
+			RenewedAt:  now,
+			ExpiresAt:  now.Add(duration),
+		}
+		if current != nil {
+			if current.Holder == holder && current.IsValid(now) {
+				// Renew the lease of the same term.
+				lease.Token = current.Token
+				lease.AcquiredAt = current.AcquiredAt
+			} else {
+				lease.Token = current.Token + 1
+			}
+		}
+		if err := l.write(lease); err != nil {
+			return err
+		}
+		ret = lease
+		return nil
+	})
+	if err != nil && !errors.Is(err, persistence.ErrLeaderLockHeld) {
+		return nil, err
+	}
+	return ret, err
+}
+
+func (l *leaderLockImpl) Release(holder string) error {
+	return l.withMutex(func() error {
+		current, err := l.Lease()
+		if err != nil || current == nil || current.Holder != holder {
+			return err
+		}
+		// The lease is expired instead of removed to keep the token.
+		current.ExpiresAt = time.Now()
+		return l.write(current)
+	})
+}
+
+func (l *leaderLockImpl) Lease() (*model.Lease, error) {
+	data, err := l.storage.Read(leaseFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the leader lease: %w", err)
+	}
+	var lease model.Lease
+	if err := json.Unmarshal(data, &lease); err != nil {
+		return nil, fmt.Errorf("failed to parse the leader lease: %w", err)
+	}
+	return &lease, nil
+}
+
+func (l *leaderLockImpl) write(lease *model.Lease) error {
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return err
+	}
+	if err := l.storage.WriteAtomic(leaseFile, data); err != nil {
+		return fmt.Errorf("failed to write the leader lease: %w", err)
+	}
+	return nil
+}
+
+// withMutex runs the function while holding the mutex file. The mutex file
+// has the nonce of the holder to tell it from the one created after it.
+func (l *leaderLockImpl) withMutex(fn func() error) error {
+	nonce := uuid.NewString()
+	deadline := time.Now().Add(2 * leaseMutexTimeout)
+	for {
+		err := l.storage.CreateExclusive(leaseMutexFile, []byte(nonce))
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("failed to create the leader lock file: %w", err)
+		}
+		if owner, ok := l.staleMutexOwner(); ok && l.takeOverMutex(owner) {
+			continue
+		}
+		if time.Now().After(deadline) {
+			return errLeaseMutexTimeout
+		}
+		time.Sleep(leaseMutexRetryInterval)
+	}
+	defer func() {
+		// The mutex file is not ours if it's taken over as a stale one.
+		if owner, err := l.storage.Read(leaseMutexFile); err == nil && string(owner) == nonce {
+			_ = l.storage.Delete(leaseMutexFile)
+		}
+	}()
+	return fn()
+}
+
+// staleMutexOwner returns the nonce of the mutex file if it's left by a
+// crashed scheduler.
+func (l *leaderLockImpl) staleMutexOwner() (string, bool) {
+	// The nonce is read before the modification time so that it's of the
+	// stale file rather than the one created after it.
+	owner, err := l.storage.Read(leaseMutexFile)
+	if err != nil || !l.isStale(leaseMutexFile) {
+		return "", false
+	}
+	return string(owner), true
+}
+
+// takeOverMutex removes the stale mutex file of the owner and returns true if
+// it's removed. The contenders finding the same stale file race to create the
+// takeover file for the owner exclusively, so only one of them removes it, and
+// the mutex file created after that is never removed by the others.
+func (l *leaderLockImpl) takeOverMutex(owner string) bool {
+	takeover := leaseTakeoverPrefix + owner
+	if err := l.storage.CreateExclusive(takeover, nil); err != nil {
+		if errors.Is(err, os.ErrExist) && l.isStale(takeover) {
+			// The takeover file is left by a scheduler crashed while taking over.
+			_ = l.storage.Delete(takeover)
+		}
+		return false
+	}
+	defer func() {
+		_ = l.storage.Delete(takeover)
+	}()
+
+	// The other contender may have taken it over before the takeover file is
+	// created by this one.
+	current, err := l.storage.Read(leaseMutexFile)
+	if err != nil || string(current) != owner {
+		return false
+	}
+	_ = l.storage.Delete(leaseMutexFile)
+	return true
+}
+
+// isStale returns true if the file is older than leaseMutexTimeout.
+func (l *leaderLockImpl) isStale(file string) bool {
+	modTime, err := l.storage.ModTime(file)
+	return err == nil && time.Since(modTime) > leaseMutexTimeout
+}
@@ -0,0 +1,180 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/local/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderLock(t *testing.T) {
+	t.Run("AcquireAndRenew", func(t *testing.T) {
+		lock := NewLeaderLock(storage.NewStorage(t.TempDir()))
+
+		lease, err := lock.Acquire("a", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, "a", lease.Holder)
+		require.Equal(t, int64(1), lease.Token)
+
+		// The renewal keeps the token.
+		renewed, err := lock.Acquire("a", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), renewed.Token)
+		require.True(t, renewed.ExpiresAt.After(lease.ExpiresAt) || renewed.ExpiresAt.Equal(lease.ExpiresAt))
+
+		// The other holder can't acquire it while the lease is valid.
+		current, err := lock.Acquire("b", time.Minute)
+		require.ErrorIs(t, err, persistence.ErrLeaderLockHeld)
+		require.Equal(t, "a", current.Holder)
+	})
+	t.Run("TakeOverExpiredLease", func(t *testing.T) {
+		lock := NewLeaderLock(storage.NewStorage(t.TempDir()))
+
+		_, err := lock.Acquire("a", 50*time.Millisecond)
+		require.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+
+		lease, err := lock.Acquire("b", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, "b", lease.Holder)
+		require.Equal(t, int64(2), lease.Token)
+
+		// The previous leader finds the new token.
+		current, err := lock.Acquire("a", time.Minute)
+		require.ErrorIs(t, err, persistence.ErrLeaderLockHeld)
+		require.Equal(t, int64(2), current.Token)
+	})
+	t.Run("Release", func(t *testing.T) {
+		lock := NewLeaderLock(storage.NewStorage(t.TempDir()))
+
+		_, err := lock.Acquire("a", time.Minute)
+		require.NoError(t, err)
+
+		// Only the holder can release it.
+		require.NoError(t, lock.Release("b"))
+		_, err = lock.Acquire("b", time.Minute)
+		require.ErrorIs(t, err, persistence.ErrLeaderLockHeld)
+
+		require.NoError(t, lock.Release("a"))
+		lease, err := lock.Acquire("b", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), lease.Token)
+
+		current, err := lock.Lease()
+		require.NoError(t, err)
+		require.Equal(t, "b", current.Holder)
+	})
+	t.Run("StaleMutex", func(t *testing.T) {
+		s := storage.NewStorage(t.TempDir())
+		lock := NewLeaderLock(s)
+
+		timeout := leaseMutexTimeout
+		leaseMutexTimeout = 100 * time.Millisecond
+		defer func() { leaseMutexTimeout = timeout }()
+
+		// The mutex file left by a crashed scheduler.
+		require.NoError(t, s.CreateExclusive(leaseMutexFile, []byte("crashed")))
+
+		_, err := lock.Acquire("a", time.Minute)
+		require.NoError(t, err)
+		require.False(t, s.Exists(leaseMutexFile))
+	})
+	t.Run("OnlyOneLeader", func(t *testing.T) {
+		dir := t.TempDir()
+
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			leaders []string
+		)
+		for _, holder := range []string{"a", "b", "c", "d"} {
+			wg.Add(1)
+			go func(holder string) {
+				defer wg.Done()
+				lock := NewLeaderLock(storage.NewStorage(dir))
+				if _, err := lock.Acquire(holder, time.Minute); err == nil {
+					mu.Lock()
+					leaders = append(leaders, holder)
+					mu.Unlock()
+				}
+			}(holder)
+		}
+		wg.Wait()
+		require.Len(t, leaders, 1)
+	})
+	t.Run("TakeOverStaleMutexOnce", func(t *testing.T) {
+		dir := t.TempDir()
+		s := storage.NewStorage(dir)
+		a := NewLeaderLock(storage.NewStorage(dir)).(*leaderLockImpl)
+		b := NewLeaderLock(storage.NewStorage(dir)).(*leaderLockImpl)
+
+		require.NoError(t, s.CreateExclusive(leaseMutexFile, []byte("crashed")))
+		staleTime := time.Now().Add(-2 * leaseMutexTimeout)
+		require.NoError(t, os.Chtimes(filepath.Join(dir, leaseMutexFile), staleTime, staleTime))
+
+		// Both contenders find the same stale mutex file.
+		ownerA, ok := a.staleMutexOwner()
+		require.True(t, ok)
+		ownerB, ok := b.staleMutexOwner()
+		require.True(t, ok)
+		require.Equal(t, "crashed", ownerA)
+
+		require.True(t, a.takeOverMutex(ownerA))
+		err := a.withMutex(func() error {
+			// The late contender must not remove the fresh mutex file.
+			require.False(t, b.takeOverMutex(ownerB))
+			require.True(t, s.Exists(leaseMutexFile))
+			return nil
+		})
+		require.NoError(t, err)
+		require.False(t, s.Exists(leaseMutexFile))
+	})
+	t.Run("DistinctTokensOnStaleMutex", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			dir := t.TempDir()
+			s := storage.NewStorage(dir)
+
+			// The stale mutex file left by a crashed scheduler, which all the
+			// contenders find at the same time.
+			require.NoError(t, s.CreateExclusive(leaseMutexFile, []byte("crashed")))
+			staleTime := time.Now().Add(-2 * leaseMutexTimeout)
+			require.NoError(t, os.Chtimes(filepath.Join(dir, leaseMutexFile), staleTime, staleTime))
+
+			var (
+				wg     sync.WaitGroup
+				mu     sync.Mutex
+				tokens []int64
+				errs   []error
+			)
+			for _, holder := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+				wg.Add(1)
+				go func(holder string) {
+					defer wg.Done()
+					lock := NewLeaderLock(storage.NewStorage(dir))
+					// The lease expires right away so that every contender
+					// takes it over with a new token.
+					lease, err := lock.Acquire(holder, time.Nanosecond)
+					mu.Lock()
+					defer mu.Unlock()
+					if err != nil {
+						errs = append(errs, err)
+						return
+					}
+					tokens = append(tokens, lease.Token)
+				}(holder)
+			}
+			wg.Wait()
+			require.Empty(t, errs)
+			slices.Sort(tokens)
+			require.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7, 8}, tokens)
+			require.False(t, s.Exists(leaseMutexFile))
+		}
+	})
+}
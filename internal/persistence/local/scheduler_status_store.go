@@ -0,0 +1,73 @@
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/local/storage"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+)
+
+const schedulerStatusSuffix = ".status.json"
+
+type schedulerStatusStoreImpl struct {
+	storage *storage.Storage
+}
+
+func NewSchedulerStatusStore(s *storage.Storage) persistence.SchedulerStatusStore {
+	return &schedulerStatusStoreImpl{
+		storage: s,
+	}
+}
+
+func (s schedulerStatusStoreImpl) Write(status *model.SchedulerStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return s.storage.WriteAtomic(schedulerStatusFileName(status.ID), data)
+}
+
+func (s schedulerStatusStoreImpl) Delete(id string) error {
+	err := s.storage.Delete(schedulerStatusFileName(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s schedulerStatusStoreImpl) List() ([]*model.SchedulerStatus, error) {
+	files, err := s.storage.List(schedulerStatusSuffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the scheduler status: %w", err)
+	}
+
+	var ret []*model.SchedulerStatus
+	for _, file := range files {
+		data, err := s.storage.Read(file)
+		if err != nil {
+			// The scheduler may have stopped in the meantime.
+			continue
+		}
+		var status model.SchedulerStatus
+		if err := json.Unmarshal(data, &status); err != nil {
+			continue
+		}
+		ret = append(ret, &status)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID < ret[j].ID
+	})
+	return ret, nil
+}
+
+func schedulerStatusFileName(id string) string {
+	return normalizeFilename(id, "-") + schedulerStatusSuffix
+}
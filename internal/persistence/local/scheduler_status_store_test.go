@@ -0,0 +1,34 @@
+package local
+
+import (
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/persistence/local/storage"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerStatusStore(t *testing.T) {
+	store := NewSchedulerStatusStore(storage.NewStorage(t.TempDir()))
+
+	statuses, err := store.List()
+	require.NoError(t, err)
+	require.Empty(t, statuses)
+
+	require.NoError(t, store.Write(&model.SchedulerStatus{ID: "host-2", Role: model.SchedulerRoleStandby}))
+	require.NoError(t, store.Write(&model.SchedulerStatus{ID: "host-1", Role: model.SchedulerRoleLeader, Token: 3}))
+
+	statuses, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	require.Equal(t, "host-1", statuses[0].ID)
+	require.Equal(t, model.SchedulerRoleLeader, statuses[0].Role)
+	require.Equal(t, int64(3), statuses[0].Token)
+
+	require.NoError(t, store.Delete("host-2"))
+	require.NoError(t, store.Delete("host-2"))
+	statuses, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+}
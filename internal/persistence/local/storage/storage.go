@@ -3,6 +3,8 @@ package storage
 import (
 	"os"
 	"path"
+	"strings"
+	"time"
 )
 
 // Storage is a storage for flags.
@@ -35,7 +37,74 @@ func (s *Storage) Exists(file string) bool {
 	return err == nil
 }
 
+// Write writes the data to the given file.
+func (s *Storage) Write(file string, data []byte) error {
+	return os.WriteFile(path.Join(s.Dir, file), data, defaultPermission)
+}
+
+// Read reads the data of the given file.
+func (s *Storage) Read(file string) ([]byte, error) {
+	return os.ReadFile(path.Join(s.Dir, file))
+}
+
 // Delete deletes the given file.
 func (s *Storage) Delete(file string) error {
 	return os.Remove(path.Join(s.Dir, file))
 }
+
+// WriteAtomic writes the data to a temporary file and renames it to the given
+// file so that the readers never see the partially written data.
+func (s *Storage) WriteAtomic(file string, data []byte) error {
+	tmp, err := os.CreateTemp(s.Dir, file+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path.Join(s.Dir, file))
+}
+
+// CreateExclusive creates the given file with the data, and returns an error
+// satisfying os.ErrExist if it already exists.
+func (s *Storage) CreateExclusive(file string, data []byte) error {
+	f, err := os.OpenFile(path.Join(s.Dir, file), os.O_CREATE|os.O_EXCL|os.O_WRONLY, defaultPermission)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ModTime returns the modification time of the given file.
+func (s *Storage) ModTime(file string) (time.Time, error) {
+	fi, err := os.Stat(path.Join(s.Dir, file))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return fi.ModTime(), nil
+}
+
+// List returns the names of the files with the given suffix.
+func (s *Storage) List(suffix string) ([]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), suffix) {
+			files = append(files, entry.Name())
+		}
+	}
+	return files, nil
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"errors"
 	"os"
 	"testing"
 
@@ -30,3 +31,30 @@ func TestStorage(t *testing.T) {
 	exist = storage.Exists(f)
 	require.False(t, exist)
 }
+
+func TestStorage_Atomic(t *testing.T) {
+	tmpDir := fileutil.MustTempDir("test-storage")
+	defer os.RemoveAll(tmpDir)
+
+	storage := NewStorage(tmpDir)
+
+	require.NoError(t, storage.WriteAtomic("test.json", []byte("1")))
+	require.NoError(t, storage.WriteAtomic("test.json", []byte("2")))
+	data, err := storage.Read("test.json")
+	require.NoError(t, err)
+	require.Equal(t, "2", string(data))
+
+	files, err := storage.List(".json")
+	require.NoError(t, err)
+	require.Equal(t, []string{"test.json"}, files)
+
+	require.NoError(t, storage.CreateExclusive("test.lock", []byte("owner")))
+	err = storage.CreateExclusive("test.lock", []byte("other"))
+	require.True(t, errors.Is(err, os.ErrExist))
+	data, err = storage.Read("test.lock")
+	require.NoError(t, err)
+	require.Equal(t, "owner", string(data))
+
+	_, err = storage.ModTime("test.lock")
+	require.NoError(t, err)
+}
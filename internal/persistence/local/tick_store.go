@@ -0,0 +1,45 @@
+package local
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/local/storage"
+)
+
+type tickStoreImpl struct {
+	storage *storage.Storage
+}
+
+func NewTickStore(s *storage.Storage) persistence.TickStore {
+	return &tickStoreImpl{
+		storage: s,
+	}
+}
+
+func (t tickStoreImpl) LastTick(id string) (time.Time, error) {
+	data, err := t.storage.Read(tickFileName(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read the last tick of %s: %w", id, err)
+	}
+	tick, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse the last tick of %s: %w", id, err)
+	}
+	return tick, nil
+}
+
+func (t tickStoreImpl) SetLastTick(id string, tick time.Time) error {
+	return t.storage.Write(tickFileName(id), []byte(tick.Format(time.RFC3339)))
+}
+
+func tickFileName(id string) string {
+	return fmt.Sprintf("%s.tick", normalizeFilename(id, "-"))
+}
@@ -0,0 +1,25 @@
+package local
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/persistence/local/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTickStore(t *testing.T) {
+	tickStore := NewTickStore(storage.NewStorage(t.TempDir()))
+
+	tick, err := tickStore.LastTick("test")
+	require.NoError(t, err)
+	require.True(t, tick.IsZero())
+
+	expected := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, tickStore.SetLastTick("test", expected))
+
+	tick, err = tickStore.LastTick("test")
+	require.NoError(t, err)
+	require.True(t, expected.Equal(tick))
+}
@@ -37,6 +37,12 @@ func FromNode(node scheduler.NodeData) *Node {
 		RetryCount: node.State.RetryCount,
 		DoneCount:  node.State.DoneCount,
 		Error:      errText(node.State.Error),
+		Attempts:   fromAttempts(node.State.Attempts),
+		Iterations: node.State.Iterations,
+		LastResult: node.State.LastResult,
+		Outputs:    node.State.Outputs,
+		Artifacts:  node.State.Artifacts,
+		Approval:   fromApproval(node.State.Approval),
 	}
 }
 
@@ -51,6 +57,30 @@ type Node struct {
 	DoneCount  int                  `json:"DoneCount,omitempty"`
 	Error      string               `json:"Error,omitempty"`
 	StatusText string               `json:"StatusText"`
+	Attempts   []Attempt            `json:"Attempts,omitempty"`
+	Iterations int                  `json:"Iterations,omitempty"`
+	LastResult string               `json:"LastResult,omitempty"`
+	Outputs    map[string]string    `json:"Outputs,omitempty"`
+	Artifacts  []string             `json:"Artifacts,omitempty"`
+	Approval   *Approval            `json:"Approval,omitempty"`
+}
+
+// Approval is the decision on a step waiting for approval.
+type Approval struct {
+	Approved bool   `json:"Approved"`
+	User     string `json:"User,omitempty"`
+	Comment  string `json:"Comment,omitempty"`
+	At       string `json:"At"`
+	Timeout  bool   `json:"Timeout,omitempty"`
+}
+
+// Attempt is the result of a single execution of a node.
+type Attempt struct {
+	Attempt    int    `json:"Attempt"`
+	StartedAt  string `json:"StartedAt"`
+	FinishedAt string `json:"FinishedAt"`
+	ExitCode   int    `json:"ExitCode"`
+	Error      string `json:"Error,omitempty"`
 }
 
 func (n *Node) ToNode() *scheduler.Node {
@@ -66,9 +96,72 @@ func (n *Node) ToNode() *scheduler.Node {
 		RetryCount: n.RetryCount,
 		DoneCount:  n.DoneCount,
 		Error:      errFromText(n.Error),
+		Attempts:   toAttempts(n.Attempts),
+		Iterations: n.Iterations,
+		LastResult: n.LastResult,
+		Outputs:    n.Outputs,
+		Artifacts:  n.Artifacts,
+		Approval:   toApproval(n.Approval),
 	})
 }
 
+func fromApproval(decision *scheduler.ApprovalDecision) *Approval {
+	if decision == nil {
+		return nil
+	}
+	return &Approval{
+		Approved: decision.Approved,
+		User:     decision.User,
+		Comment:  decision.Comment,
+		At:       stringutil.FormatTime(decision.At),
+		Timeout:  decision.Timeout,
+	}
+}
+
+func toApproval(approval *Approval) *scheduler.ApprovalDecision {
+	if approval == nil {
+		return nil
+	}
+	at, _ := stringutil.ParseTime(approval.At)
+	return &scheduler.ApprovalDecision{
+		Approved: approval.Approved,
+		User:     approval.User,
+		Comment:  approval.Comment,
+		At:       at,
+		Timeout:  approval.Timeout,
+	}
+}
+
+func fromAttempts(attempts []scheduler.Attempt) []Attempt {
+	var ret []Attempt
+	for _, a := range attempts {
+		ret = append(ret, Attempt{
+			Attempt:    a.Attempt,
+			StartedAt:  stringutil.FormatTime(a.StartedAt),
+			FinishedAt: stringutil.FormatTime(a.FinishedAt),
+			ExitCode:   a.ExitCode,
+			Error:      errText(a.Error),
+		})
+	}
+	return ret
+}
+
+func toAttempts(attempts []Attempt) []scheduler.Attempt {
+	var ret []scheduler.Attempt
+	for _, a := range attempts {
+		startedAt, _ := stringutil.ParseTime(a.StartedAt)
+		finishedAt, _ := stringutil.ParseTime(a.FinishedAt)
+		ret = append(ret, scheduler.Attempt{
+			Attempt:    a.Attempt,
+			StartedAt:  startedAt,
+			FinishedAt: finishedAt,
+			ExitCode:   a.ExitCode,
+			Error:      errFromText(a.Error),
+		})
+	}
+	return ret
+}
+
 func NewNode(step digraph.Step) *Node {
 	return &Node{
 		Step:       step,
@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// Lease is the lease of the leader lock of the schedulers.
+type Lease struct {
+	// Holder is the ID of the scheduler holding the lock.
+	Holder string `json:"Holder"`
+	// Token is the fencing token, which increases every time the lock is
+	// newly acquired.
+	Token      int64     `json:"Token"`
+	AcquiredAt time.Time `json:"AcquiredAt"`
+	RenewedAt  time.Time `json:"RenewedAt"`
+	ExpiresAt  time.Time `json:"ExpiresAt"`
+}
+
+// IsValid returns true if the lease hasn't expired at the given time.
+func (l *Lease) IsValid(now time.Time) bool {
+	return l != nil && now.Before(l.ExpiresAt)
+}
+
+// SchedulerRole is the role of the scheduler in the leader election.
+type SchedulerRole string
+
+const (
+	SchedulerRoleLeader  SchedulerRole = "leader"
+	SchedulerRoleStandby SchedulerRole = "standby"
+)
+
+// SchedulerStatus is the status of a running scheduler.
+type SchedulerStatus struct {
+	ID   string        `json:"ID"`
+	Host string        `json:"Host"`
+	PID  int           `json:"PID"`
+	Role SchedulerRole `json:"Role"`
+	// Token is the fencing token of the leadership, or zero if the leader
+	// election is disabled.
+	Token int64 `json:"Token"`
+	// LastTick is the last schedule tick processed by the scheduler.
+	LastTick  time.Time `json:"LastTick"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+	// ExpiresAt is the time after which the status is stale unless it's
+	// updated, e.g. when the scheduler was killed.
+	ExpiresAt time.Time `json:"ExpiresAt"`
+}
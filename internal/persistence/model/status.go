@@ -2,7 +2,10 @@ package model
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -83,6 +86,39 @@ func WithOnCancelNode(node *scheduler.Node) StatusOption {
 	}
 }
 
+// WithArtifactDir sets the artifact directory of the run.
+func WithArtifactDir(dir string) StatusOption {
+	return func(s *Status) {
+		s.ArtifactDir = dir
+	}
+}
+
+func WithTriggeredBy(ref *DAGRunRef) StatusOption {
+	return func(s *Status) {
+		s.TriggeredBy = ref
+	}
+}
+
+func WithTriggered(refs []DAGRunRef) StatusOption {
+	return func(s *Status) {
+		s.Triggered = refs
+	}
+}
+
+func WithScheduledTime(t time.Time) StatusOption {
+	return func(s *Status) {
+		if !t.IsZero() {
+			s.ScheduledTime = FormatTime(t)
+		}
+	}
+}
+
+func WithBackfill(backfill bool) StatusOption {
+	return func(s *Status) {
+		s.Backfill = backfill
+	}
+}
+
 func WithLogFilePath(logFilePath string) StatusOption {
 	return func(s *Status) {
 		s.Log = logFilePath
@@ -106,6 +142,7 @@ func (f *StatusFactory) Create(
 	for _, opt := range opts {
 		opt(&statusObj)
 	}
+	statusObj.Artifacts = artifactsFromNodes(statusObj.Nodes)
 
 	return statusObj
 }
@@ -144,15 +181,87 @@ type Status struct {
 	Log        string           `json:"Log"`
 	Params     string           `json:"Params,omitempty"`
 	ParamsList []string         `json:"ParamsList,omitempty"`
+	// ArtifactDir is the directory that stores the artifacts of the run.
+	ArtifactDir string `json:"ArtifactDir,omitempty"`
+	// Artifacts is the list of the artifacts produced by the steps.
+	Artifacts []Artifact `json:"Artifacts,omitempty"`
+	// TriggeredBy is the run of the DAG that triggered this run.
+	TriggeredBy *DAGRunRef `json:"TriggeredBy,omitempty"`
+	// Triggered is the list of the runs of the DAGs triggered by this run.
+	Triggered []DAGRunRef `json:"Triggered,omitempty"`
+	// ScheduledTime is the logical time of the schedule tick of the run.
+	ScheduledTime string `json:"ScheduledTime,omitempty"`
+	// Backfill is true if the run is a backfill of a past schedule tick.
+	Backfill bool `json:"Backfill,omitempty"`
+	// Error is the error that failed the run before any step started.
+	Error string `json:"Error,omitempty"`
+}
+
+// DAGRunRef is a reference to a run of another DAG in the lineage of
+// triggers.
+type DAGRunRef struct {
+	// Name is the name of the DAG.
+	Name string `json:"Name"`
+	// Location is the path of the DAG file.
+	Location string `json:"Location,omitempty"`
+	// RequestID is the request ID of the run.
+	RequestID string `json:"RequestId"`
+	// On is the event of the parent run that triggered the DAG.
+	On string `json:"On,omitempty"`
+	// Error is the error that occurred when starting the DAG.
+	Error string `json:"Error,omitempty"`
+}
+
+// Artifact is a file produced by a step.
+type Artifact struct {
+	// Step is the name of the step that produced the artifact.
+	Step string `json:"Step"`
+	// Path is the path of the artifact relative to the artifact directory
+	// of the step.
+	Path string `json:"Path"`
+}
+
+var ErrArtifactNotFound = errors.New("artifact not found")
+
+// ArtifactPath returns the path of the file of the artifact.
+// It returns ErrArtifactNotFound if the step did not produce the artifact.
+func (st *Status) ArtifactPath(step, path string) (string, error) {
+	if st.ArtifactDir == "" || !slices.Contains(st.Artifacts, Artifact{Step: step, Path: path}) {
+		return "", fmt.Errorf("%w: %s/%s", ErrArtifactNotFound, step, path)
+	}
+	return filepath.Join(scheduler.ArtifactDir(st.ArtifactDir, step), filepath.FromSlash(path)), nil
+}
+
+func artifactsFromNodes(nodes []*Node) []Artifact {
+	var ret []Artifact
+	for _, node := range nodes {
+		for _, path := range node.Artifacts {
+			ret = append(ret, Artifact{Step: node.Step.Name, Path: path})
+		}
+	}
+	return ret
 }
 
 func (st *Status) CorrectRunningStatus() {
-	if st.Status == scheduler.StatusRunning {
+	if st.Status.IsActive() {
 		st.Status = scheduler.StatusError
 		st.StatusText = st.Status.String()
 	}
 }
 
+// ScheduledAt returns the logical time of the schedule tick of the run, or
+// the zero time if the run is not started by a schedule.
+func (st *Status) ScheduledAt() time.Time {
+	if st.ScheduledTime == "" {
+		return time.Time{}
+	}
+	t, err := stringutil.ParseTime(st.ScheduledTime)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
 func FormatTime(val time.Time) string {
 	if val.IsZero() {
 		return ""
@@ -75,3 +75,30 @@ func TestJsonMarshal(t *testing.T) {
 	}
 	t.Log(string(rawJSON))
 }
+
+func TestStatusArtifacts(t *testing.T) {
+	dag := &digraph.DAG{
+		Name:  "test",
+		Steps: []digraph.Step{{Name: "build"}, {Name: "test"}},
+	}
+	nodes := []scheduler.NodeData{
+		{Step: dag.Steps[0], State: scheduler.NodeState{Artifacts: []string{"dist/app", "dist/app.sha256"}}},
+		{Step: dag.Steps[1]},
+	}
+	status := NewStatusFactory(dag).Create(
+		"request-id", scheduler.StatusSuccess, 0, time.Now(),
+		WithNodes(nodes), WithArtifactDir("/tmp/artifacts"),
+	)
+
+	require.Equal(t, []Artifact{
+		{Step: "build", Path: "dist/app"},
+		{Step: "build", Path: "dist/app.sha256"},
+	}, status.Artifacts)
+
+	path, err := status.ArtifactPath("build", "dist/app")
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/artifacts/build/dist/app", path)
+
+	_, err = status.ArtifactPath("build", "../../etc/passwd")
+	require.ErrorIs(t, err, ErrArtifactNotFound)
+}
@@ -0,0 +1,147 @@
+package scheduler
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+)
+
+// catchupMissedTicks enqueues the ticks of the DAGs missed while the
+// scheduler was down according to their catchup policies. The ticks before
+// the current tick are missed if they are after the last processed tick.
+func (s *Scheduler) catchupMissedTicks(ctx context.Context, current time.Time) {
+	for _, dag := range s.entryReader.DAGs() {
+		if !dag.Catchup.Enabled() || len(dag.Schedule) == 0 {
+			continue
+		}
+		id := dagID(dag)
+		if s.client != nil && s.client.IsSuspended(ctx, id) {
+			continue
+		}
+
+		last, err := s.tickStore.LastTick(id)
+		if err != nil {
+			logger.Error(ctx, "Failed to read the last tick", "DAG", dag.Name, "err", err)
+			continue
+		}
+		if last.IsZero() {
+			// The DAG has never been scheduled with the catchup policy.
+			continue
+		}
+
+		ticks := dag.Catchup.Select(dag.ScheduleTicks(last.In(s.location), current))
+		if len(ticks) == 0 {
+			continue
+		}
+
+		logger.Info(ctx, "Catching up the missed ticks", "DAG", dag.Name, "policy", dag.Catchup.Policy,
+			"from", ticks[0].Format(time.RFC3339), "to", ticks[len(ticks)-1].Format(time.RFC3339), "count", len(ticks))
+
+		s.catchupLock.Lock()
+		current, running := s.catchupRunning[id]
+		if running {
+			// The runner still catching up from the previous leadership
+			// runs the new ticks after the ones in its queue.
+			ticks = slices.DeleteFunc(ticks, func(t time.Time) bool {
+				return !t.After(current)
+			})
+		}
+		s.catchupQueue[id] = mergeTicks(s.catchupQueue[id], ticks)
+		if !running {
+			s.catchupRunning[id] = time.Time{}
+		}
+		s.catchupLock.Unlock()
+
+		if !running {
+			go s.runCatchup(ctx, dag)
+		}
+	}
+}
+
+// mergeTicks merges the ticks into the queue in the chronological order
+// without duplicates.
+func mergeTicks(queue, ticks []time.Time) []time.Time {
+	merged := append(slices.Clone(queue), ticks...)
+	slices.SortFunc(merged, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+	return slices.CompactFunc(merged, func(a, b time.Time) bool {
+		return a.Equal(b)
+	})
+}
+
+// runCatchup runs the queued ticks of the DAG one by one. The ticks that
+// arrive while catching up are queued after the missed ones. Only one runner
+// runs for each DAG.
+func (s *Scheduler) runCatchup(ctx context.Context, dag *digraph.DAG) {
+	id := dagID(dag)
+	for {
+		select {
+		case <-s.stop:
+			// The queue is kept so that the ticks are not recorded as
+			// processed without running.
+			s.catchupLock.Lock()
+			delete(s.catchupRunning, id)
+			s.catchupLock.Unlock()
+			return
+		default:
+		}
+
+		s.catchupLock.Lock()
+		queue := s.catchupQueue[id]
+		if len(queue) == 0 || !s.isLeader() {
+			// The new leader catches up the rest of the ticks.
+			delete(s.catchupQueue, id)
+			delete(s.catchupRunning, id)
+			s.catchupLock.Unlock()
+			return
+		}
+		tick := queue[0]
+		s.catchupQueue[id] = queue[1:]
+		s.catchupRunning[id] = tick
+		s.catchupLock.Unlock()
+
+		logger.Info(ctx, "DAG catchup started", "DAG", dag.Name, "scheduledTime", tick.Format(time.RFC3339))
+		job := s.jobCreator.CreateJob(dag, tick, nil)
+		if err := job.Catchup(ctx); err != nil {
+			logger.Error(ctx, "DAG catchup failed", "DAG", dag.Name, "scheduledTime", tick.Format(time.RFC3339), "err", err)
+		}
+		s.setLastTick(ctx, dag, tick)
+	}
+}
+
+// enqueueTick queues the tick of the DAG if the DAG is catching up, and
+// returns true if it's queued. Otherwise, the tick is recorded as processed.
+func (s *Scheduler) enqueueTick(ctx context.Context, dag *digraph.DAG, tick time.Time) bool {
+	if dag == nil || !dag.Catchup.Enabled() || s.tickStore == nil {
+		return false
+	}
+
+	id := dagID(dag)
+	s.catchupLock.Lock()
+	if queue, ok := s.catchupQueue[id]; ok {
+		s.catchupQueue[id] = append(queue, tick)
+		s.catchupLock.Unlock()
+		return true
+	}
+	s.catchupLock.Unlock()
+
+	s.setLastTick(ctx, dag, tick)
+	return false
+}
+
+func (s *Scheduler) setLastTick(ctx context.Context, dag *digraph.DAG, tick time.Time) {
+	if err := s.tickStore.SetLastTick(dagID(dag), tick); err != nil {
+		logger.Error(ctx, "Failed to record the last tick", "DAG", dag.Name, "err", err)
+	}
+}
+
+// dagID returns the ID of the DAG used for the suspend flags and the ticks.
+func dagID(dag *digraph.DAG) string {
+	return strings.TrimSuffix(filepath.Base(dag.Location), filepath.Ext(dag.Location))
+}
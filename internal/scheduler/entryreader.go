@@ -68,12 +68,7 @@ func (er *entryReaderImpl) Read(ctx context.Context, now time.Time) ([]*entry, e
 	}
 
 	for _, dag := range er.dags {
-		id := strings.TrimSuffix(
-			filepath.Base(dag.Location),
-			filepath.Ext(dag.Location),
-		)
-
-		if er.client.IsSuspended(ctx, id) {
+		if er.client.IsSuspended(ctx, dagID(dag)) {
 			continue
 		}
 		addEntriesFn(dag, dag.Schedule, entryTypeStart)
@@ -84,6 +79,17 @@ func (er *entryReaderImpl) Read(ctx context.Context, now time.Time) ([]*entry, e
 	return entries, nil
 }
 
+func (er *entryReaderImpl) DAGs() []*digraph.DAG {
+	er.dagsLock.Lock()
+	defer er.dagsLock.Unlock()
+
+	dags := make([]*digraph.DAG, 0, len(er.dags))
+	for _, dag := range er.dags {
+		dags = append(dags, dag)
+	}
+	return dags
+}
+
 func (er *entryReaderImpl) initDAGs(ctx context.Context) error {
 	er.dagsLock.Lock()
 	defer er.dagsLock.Unlock()
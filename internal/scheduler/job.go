@@ -8,7 +8,6 @@ import (
 
 	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/digraph"
-	dagscheduler "github.com/dagu-org/dagu/internal/digraph/scheduler"
 	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/robfig/cron/v3"
 )
@@ -60,15 +59,21 @@ func (j *jobImpl) Start(ctx context.Context) error {
 		return err
 	}
 
-	if latestStatus.Status == dagscheduler.StatusRunning {
+	if latestStatus.Status.IsActive() {
 		// already running
 		return errJobRunning
 	}
 
-	// check the last execution time
+	// check the tick of the last scheduled run
+	if latestStatus.ScheduledAt().Equal(j.Next) {
+		return errJobFinished
+	}
+
+	// check the last execution time; it's in seconds so that the sub-minute
+	// ticks are not regarded as finished by the run of the earlier tick.
 	lastExecTime, err := stringutil.ParseTime(latestStatus.StartedAt)
 	if err == nil {
-		lastExecTime = lastExecTime.Truncate(time.Second * 60)
+		lastExecTime = lastExecTime.Truncate(time.Second)
 		if lastExecTime.After(j.Next) || j.Next.Equal(lastExecTime) {
 			return errJobFinished
 		}
@@ -87,9 +92,32 @@ func (j *jobImpl) Start(ctx context.Context) error {
 		}
 	}
 
-	return j.Client.Start(ctx, j.DAG, client.StartOptions{Quiet: true})
+	return j.Client.Start(ctx, j.DAG, client.StartOptions{Quiet: true, ScheduledTime: j.Next})
+}
+
+func (j *jobImpl) Catchup(ctx context.Context) error {
+	// Wait for the previous run to finish so that the ticks are run in order.
+	for {
+		latestStatus, err := j.Client.GetLatestStatus(ctx, j.DAG)
+		if err != nil {
+			return err
+		}
+		if !latestStatus.Status.IsActive() {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(catchupPollInterval):
+		}
+	}
+
+	return j.Client.Start(ctx, j.DAG, client.StartOptions{Quiet: true, ScheduledTime: j.Next})
 }
 
+// catchupPollInterval is the interval to check if the previous run finished.
+var catchupPollInterval = time.Second * 5
+
 func (j *jobImpl) Prev(_ context.Context) time.Time {
 	// Since robfig/cron does not provide a way to get the previous schedule time,
 	// we need to do it manually.
@@ -104,7 +132,7 @@ func (j *jobImpl) Stop(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	if latestStatus.Status != dagscheduler.StatusRunning {
+	if !latestStatus.Status.IsActive() {
 		return errJobIsNotRunning
 	}
 	return j.Client.Stop(ctx, j.DAG)
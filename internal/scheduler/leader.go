@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/google/uuid"
+)
+
+// defaultLeaseDuration is the duration of the lease of the leader if it's not
+// configured.
+const defaultLeaseDuration = 30 * time.Second
+
+// statusTTL is the time after which the status of the scheduler is stale
+// unless it's updated. The status is updated on every tick, which happens at
+// least once a minute, and on every renewal of the lease.
+const statusTTL = 2 * time.Minute
+
+// campaign tries to acquire or renew the leader lock, and returns true if the
+// scheduler newly became the leader.
+func (s *Scheduler) campaign(ctx context.Context) bool {
+	defer s.writeStatus(ctx)
+
+	prev := s.lease.Load()
+	lease, err := s.leaderLock.Acquire(s.id, s.leaseDuration)
+	switch {
+	case err == nil:
+		s.lease.Store(lease)
+		s.standby.Store(false)
+		if prev == nil || prev.Token != lease.Token {
+			logger.Info(ctx, "Scheduler became the leader", "id", s.id, "token", lease.Token)
+			return true
+		}
+
+	case errors.Is(err, persistence.ErrLeaderLockHeld):
+		s.lease.Store(nil)
+		if !s.standby.Swap(true) {
+			logger.Info(ctx, "Scheduler is on standby", "id", s.id, "leader", lease.Holder, "token", lease.Token)
+		}
+
+	default:
+		// The leader keeps running the DAGs until the lease expires.
+		logger.Error(ctx, "Failed to acquire the leader lock", "id", s.id, "err", err)
+
+	}
+	return false
+}
+
+// runElection renews the lease of the leader, or takes over the leadership
+// when the lease of the leader expires, until the scheduler stops.
+func (s *Scheduler) runElection(ctx context.Context) {
+	ticker := time.NewTicker(s.leaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			if s.campaign(ctx) && s.tickStore != nil {
+				// Catch up the ticks missed while there was no leader.
+				s.catchupMissedTicks(ctx, now())
+			}
+		}
+	}
+}
+
+// isLeader returns true if the scheduler runs the DAGs, i.e. the leader
+// election is disabled, or the scheduler holds the valid lease.
+func (s *Scheduler) isLeader() bool {
+	if s.leaderLock == nil {
+		return true
+	}
+	return s.lease.Load().IsValid(time.Now())
+}
+
+// verifyLeadership returns true if the scheduler is the leader. The fencing
+// token of the lease is checked against the lock before running the DAGs, so
+// that the previous leader doesn't run them after another scheduler took
+// over, e.g. when its clock is off or it was paused.
+func (s *Scheduler) verifyLeadership(ctx context.Context) bool {
+	if s.leaderLock == nil {
+		return true
+	}
+	lease := s.lease.Load()
+	if !lease.IsValid(time.Now()) {
+		return false
+	}
+
+	current, err := s.leaderLock.Lease()
+	if err != nil {
+		logger.Error(ctx, "Failed to read the leader lease", "id", s.id, "err", err)
+		return false
+	}
+	if current == nil || current.Holder != s.id || current.Token != lease.Token {
+		s.lease.CompareAndSwap(lease, nil)
+		logger.Warn(ctx, "Scheduler lost the leadership", "id", s.id, "token", lease.Token)
+		return false
+	}
+	return true
+}
+
+// resign releases the leader lock so that a standby scheduler takes over
+// without waiting for the lease to expire.
+func (s *Scheduler) resign(ctx context.Context) {
+	if s.leaderLock == nil || s.lease.Swap(nil) == nil {
+		return
+	}
+	if err := s.leaderLock.Release(s.id); err != nil {
+		logger.Error(ctx, "Failed to release the leader lock", "id", s.id, "err", err)
+	}
+}
+
+// recordTick records the tick the scheduler ran the DAGs for.
+func (s *Scheduler) recordTick(ctx context.Context, tick time.Time) {
+	s.statusLock.Lock()
+	s.lastTick = tick
+	s.statusLock.Unlock()
+
+	s.writeStatus(ctx)
+}
+
+func (s *Scheduler) writeStatus(ctx context.Context) {
+	if s.statusStore == nil {
+		return
+	}
+
+	s.statusLock.Lock()
+	lastTick := s.lastTick
+	s.statusLock.Unlock()
+
+	t := time.Now()
+	status := &model.SchedulerStatus{
+		ID:        s.id,
+		Host:      s.host,
+		PID:       os.Getpid(),
+		Role:      model.SchedulerRoleStandby,
+		LastTick:  lastTick,
+		UpdatedAt: t,
+		ExpiresAt: t.Add(statusTTL + s.leaseDuration),
+	}
+	if s.isLeader() {
+		status.Role = model.SchedulerRoleLeader
+		if lease := s.lease.Load(); lease != nil {
+			status.Token = lease.Token
+		}
+	}
+	if err := s.statusStore.Write(status); err != nil {
+		logger.Error(ctx, "Failed to write the scheduler status", "id", s.id, "err", err)
+	}
+}
+
+// schedulerID returns the ID of the scheduler process, which is unique among
+// the schedulers sharing the leader lock.
+func schedulerID(host string) string {
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), uuid.NewString()[:8])
+}
@@ -2,25 +2,46 @@ package scheduler
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/robfig/cron/v3"
 )
 
 var _ jobCreator = (*mockJobFactory)(nil)
 
-type mockJobFactory struct{}
+type mockJobFactory struct {
+	mu   sync.Mutex
+	Jobs []*mockJob
+	// Block blocks the catchup of the created jobs until it's closed.
+	Block chan struct{}
+}
+
+func (f *mockJobFactory) CreateJob(dag *digraph.DAG, next time.Time, _ cron.Schedule) job {
+	j := newMockJob(dag)
+	j.Next = next
+	j.Block = f.Block
+	f.mu.Lock()
+	f.Jobs = append(f.Jobs, j)
+	f.mu.Unlock()
+	return j
+}
 
-func (f *mockJobFactory) CreateJob(dag *digraph.DAG, _ time.Time, _ cron.Schedule) job {
-	return newMockJob(dag)
+func (f *mockJobFactory) CreatedJobs() []*mockJob {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*mockJob(nil), f.Jobs...)
 }
 
 var _ entryReader = (*mockEntryReader)(nil)
 
 type mockEntryReader struct {
 	Entries []*entry
+	DAGList []*digraph.DAG
 }
 
 func (er *mockEntryReader) Read(_ context.Context, _ time.Time) ([]*entry, error) {
@@ -31,6 +52,87 @@ func (er *mockEntryReader) Start(_ context.Context, _ chan any) error {
 	return nil
 }
 
+func (er *mockEntryReader) DAGs() []*digraph.DAG {
+	return er.DAGList
+}
+
+var _ persistence.TickStore = (*mockTickStore)(nil)
+
+type mockTickStore struct {
+	mu    sync.Mutex
+	ticks map[string]time.Time
+}
+
+func newMockTickStore() *mockTickStore {
+	return &mockTickStore{ticks: make(map[string]time.Time)}
+}
+
+func (s *mockTickStore) LastTick(id string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ticks[id], nil
+}
+
+func (s *mockTickStore) SetLastTick(id string, tick time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ticks[id] = tick
+	return nil
+}
+
+var _ persistence.LeaderLock = (*mockLeaderLock)(nil)
+
+type mockLeaderLock struct {
+	mu    sync.Mutex
+	lease *model.Lease
+}
+
+func (l *mockLeaderLock) Acquire(holder string, duration time.Duration) (*model.Lease, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.lease != nil && l.lease.Holder != holder && l.lease.IsValid(now) {
+		current := *l.lease
+		return &current, persistence.ErrLeaderLockHeld
+	}
+
+	lease := &model.Lease{Holder: holder, AcquiredAt: now}
+	if l.lease != nil && l.lease.Holder == holder && l.lease.IsValid(now) {
+		lease.Token = l.lease.Token
+		lease.AcquiredAt = l.lease.AcquiredAt
+	} else if l.lease != nil {
+		lease.Token = l.lease.Token + 1
+	} else {
+		lease.Token = 1
+	}
+	lease.RenewedAt = now
+	lease.ExpiresAt = now.Add(duration)
+	l.lease = lease
+
+	ret := *lease
+	return &ret, nil
+}
+
+func (l *mockLeaderLock) Release(holder string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.lease != nil && l.lease.Holder == holder {
+		l.lease.ExpiresAt = time.Now()
+	}
+	return nil
+}
+
+func (l *mockLeaderLock) Lease() (*model.Lease, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.lease == nil {
+		return nil, nil
+	}
+	ret := *l.lease
+	return &ret, nil
+}
+
 var _ job = (*mockJob)(nil)
 
 type mockJob struct {
@@ -39,7 +141,10 @@ type mockJob struct {
 	RunCount     atomic.Int32
 	StopCount    atomic.Int32
 	RestartCount atomic.Int32
+	CatchupCount atomic.Int32
+	Next         time.Time
 	Panic        error
+	Block        chan struct{}
 }
 
 func newMockJob(dag *digraph.DAG) *mockJob {
@@ -71,6 +176,14 @@ func (j *mockJob) Restart(_ context.Context) error {
 	return nil
 }
 
+func (j *mockJob) Catchup(_ context.Context) error {
+	j.CatchupCount.Add(1)
+	if j.Block != nil {
+		<-j.Block
+	}
+	return nil
+}
+
 func (j *mockJob) String() string {
 	return j.Name
 }
@@ -16,6 +16,8 @@ import (
 	"github.com/dagu-org/dagu/internal/config"
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
 )
 
 type Scheduler struct {
@@ -24,22 +26,65 @@ type Scheduler struct {
 	stop        chan struct{}
 	running     atomic.Bool
 	location    *time.Location
+	client      client.Client
+	autoRecover bool
+	jobCreator  jobCreator
+	tickStore   persistence.TickStore
+	// startedAt is the time the scheduler started running the ticks.
+	startedAt time.Time
+
+	catchupLock sync.Mutex
+	// catchupQueue is the queue of the ticks to run for each DAG catching up.
+	catchupQueue map[string][]time.Time
+	// catchupRunning is the tick being run by the runner of each DAG catching
+	// up. It's zero until the runner takes the first tick.
+	catchupRunning map[string]time.Time
+
+	// id is the ID of the scheduler process in the leader election.
+	id   string
+	host string
+	// leaderLock elects the leader that runs the DAGs among the schedulers.
+	// It's nil if the leader election is disabled.
+	leaderLock    persistence.LeaderLock
+	leaseDuration time.Duration
+	lease         atomic.Pointer[model.Lease]
+	standby       atomic.Bool
+	statusStore   persistence.SchedulerStatusStore
+	statusLock    sync.Mutex
+	lastTick      time.Time
 }
 
 // TODO: refactor to remove ctx from the constructor
-func New(cfg *config.Config, cli client.Client) *Scheduler {
+func New(
+	cfg *config.Config,
+	cli client.Client,
+	tickStore persistence.TickStore,
+	leaderLock persistence.LeaderLock,
+	statusStore persistence.SchedulerStatusStore,
+) *Scheduler {
 	jobCreator := &jobCreatorImpl{
 		WorkDir:    cfg.WorkDir,
 		Client:     cli,
 		Executable: cfg.Paths.Executable,
 	}
 	entryReader := newEntryReader(cfg.Paths.DAGsDir, jobCreator, cli)
-	return newScheduler(entryReader, cfg.Paths.LogDir, cfg.Location)
+	sc := newScheduler(entryReader, cfg.Paths.LogDir, cfg.Location)
+	sc.client = cli
+	sc.autoRecover = cfg.Scheduler.AutoRecover
+	sc.jobCreator = jobCreator
+	sc.tickStore = tickStore
+	sc.leaderLock = leaderLock
+	sc.statusStore = statusStore
+	if cfg.Scheduler.LeaseDuration > 0 {
+		sc.leaseDuration = cfg.Scheduler.LeaseDuration
+	}
+	return sc
 }
 
 type entryReader interface {
 	Start(ctx context.Context, done chan any) error
 	Read(ctx context.Context, now time.Time) ([]*entry, error)
+	DAGs() []*digraph.DAG
 }
 
 type entry struct {
@@ -53,6 +98,8 @@ type job interface {
 	Start(ctx context.Context) error
 	Stop(ctx context.Context) error
 	Restart(ctx context.Context) error
+	// Catchup runs the DAG for the missed tick after the previous run.
+	Catchup(ctx context.Context) error
 	String() string
 }
 
@@ -100,11 +147,17 @@ func newScheduler(entryReader entryReader, logDir string, location *time.Locatio
 	if location == nil {
 		location = time.Local
 	}
+	host, _ := os.Hostname()
 	return &Scheduler{
-		entryReader: entryReader,
-		logDir:      logDir,
-		stop:        make(chan struct{}),
-		location:    location,
+		entryReader:    entryReader,
+		logDir:         logDir,
+		stop:           make(chan struct{}),
+		location:       location,
+		catchupQueue:   make(map[string][]time.Time),
+		catchupRunning: make(map[string]time.Time),
+		id:             schedulerID(host),
+		host:           host,
+		leaseDuration:  defaultLeaseDuration,
 	}
 }
 
@@ -117,6 +170,30 @@ func (s *Scheduler) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to start entry reader: %w", err)
 	}
 
+	t := now().Truncate(time.Minute)
+
+	// Only the leader runs the DAGs. The orphaned runs are recovered only by
+	// the leader elected at startup since the processes of the runs are
+	// checked on the local host.
+	if s.leaderLock == nil || s.campaign(ctx) {
+		if s.autoRecover {
+			s.recoverOrphanedRuns(ctx)
+		}
+		if s.tickStore != nil {
+			s.catchupMissedTicks(ctx, t)
+		}
+	}
+	electionDone := make(chan struct{})
+	if s.leaderLock != nil {
+		go func() {
+			defer close(electionDone)
+			s.runElection(ctx)
+		}()
+	} else {
+		close(electionDone)
+		s.writeStatus(ctx)
+	}
+
 	signal.Notify(
 		sig, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT,
 	)
@@ -132,22 +209,55 @@ func (s *Scheduler) Start(ctx context.Context) error {
 		}
 	}()
 
-	s.start(ctx)
+	s.start(ctx, t)
+
+	// The lock is released after the election stops so that it's not
+	// acquired again, and a standby scheduler takes over immediately.
+	<-electionDone
+	s.resign(ctx)
+	if s.statusStore != nil {
+		if err := s.statusStore.Delete(s.id); err != nil {
+			logger.Error(ctx, "Failed to delete the scheduler status", "id", s.id, "err", err)
+		}
+	}
 
 	return nil
 }
 
-func (s *Scheduler) start(ctx context.Context) {
-	// TODO: refactor this to use a ticker
-	t := now().Truncate(time.Minute)
+// recoverOrphanedRuns recovers the latest runs of the DAGs whose agent
+// process died, e.g. when the host rebooted while they were running.
+func (s *Scheduler) recoverOrphanedRuns(ctx context.Context) {
+	for _, dag := range s.entryReader.DAGs() {
+		status, err := s.client.GetOrphanedRun(ctx, dag)
+		if err != nil {
+			logger.Error(ctx, "Failed to check the orphaned run", "DAG", dag.Name, "err", err)
+			continue
+		}
+		if status == nil {
+			continue
+		}
+		logger.Info(ctx, "Recovering the orphaned run", "DAG", dag.Name, "requestID", status.RequestID)
+		if err := s.client.Recover(ctx, dag, status.RequestID); err != nil {
+			logger.Error(ctx, "Failed to recover the orphaned run", "DAG", dag.Name, "requestID", status.RequestID, "err", err)
+		}
+	}
+}
+
+func (s *Scheduler) start(ctx context.Context, t time.Time) {
 	timer := time.NewTimer(0)
 
+	s.startedAt = now()
 	s.running.Store(true)
 	for {
 		select {
 		case <-timer.C:
+			if late := now().Sub(t); late > time.Second && !t.Before(s.startedAt) {
+				// The tick is run late rather than dropped, e.g. after a
+				// long GC pause or under load.
+				logger.Warn(ctx, "Running the tick later than scheduled", "tick", t.Format(time.RFC3339), "late", late)
+			}
 			s.run(ctx, t)
-			t = s.nextTick(t)
+			t = s.nextTick(ctx, t)
 			_ = timer.Stop()
 			timer.Reset(t.Sub(now()))
 		case <-s.stop:
@@ -160,6 +270,12 @@ func (s *Scheduler) start(ctx context.Context) {
 }
 
 func (s *Scheduler) run(ctx context.Context, now time.Time) {
+	if !s.verifyLeadership(ctx) {
+		// The standby scheduler doesn't run the DAGs.
+		return
+	}
+	defer s.recordTick(ctx, now)
+
 	entries, err := s.entryReader.Read(ctx, now.Add(-time.Second).In(s.location))
 	if err != nil {
 		logger.Error(ctx, "Scheduler failed to read DAG entries", "err", err)
@@ -173,6 +289,10 @@ func (s *Scheduler) run(ctx context.Context, now time.Time) {
 		if t.After(now) {
 			break
 		}
+		if e.EntryType == entryTypeStart && s.enqueueTick(ctx, e.Job.GetDAG(ctx), t) {
+			// The tick is run after the missed ticks.
+			continue
+		}
 		go func(e *entry) {
 			if err := e.Invoke(ctx); err != nil {
 				if errors.Is(err, errJobFinished) {
@@ -189,8 +309,29 @@ func (s *Scheduler) run(ctx context.Context, now time.Time) {
 	}
 }
 
-func (*Scheduler) nextTick(now time.Time) time.Time {
-	return now.Add(time.Minute).Truncate(time.Second * 60)
+// nextTick returns the time of the earliest entry after the tick so that the
+// scheduler wakes up for the sub-minute schedules. It's at the next minute
+// at the latest to pick up the DAGs added in the meantime. The ticks that
+// passed while running the previous ones are returned one by one to run them
+// late, while the ones before the scheduler started, e.g. in the minute it
+// started, are skipped.
+func (s *Scheduler) nextTick(ctx context.Context, t time.Time) time.Time {
+	next := t.Add(time.Minute).Truncate(time.Minute)
+
+	entries, err := s.entryReader.Read(ctx, t.In(s.location))
+	if err != nil {
+		logger.Error(ctx, "Scheduler failed to read DAG entries", "err", err)
+	}
+	for _, e := range entries {
+		if e.Next.After(t) && e.Next.Before(next) {
+			next = e.Next
+		}
+	}
+
+	if started := s.startedAt.Truncate(time.Second); next.Before(started) {
+		return started
+	}
+	return next
 }
 
 func (s *Scheduler) Stop(ctx context.Context) {
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/robfig/cron/v3"
 	"github.com/stretchr/testify/require"
 )
 
@@ -67,9 +69,52 @@ func TestScheduler(t *testing.T) {
 		now := time.Date(2020, 1, 1, 1, 0, 50, 0, time.UTC)
 		setFixedTime(now)
 		schedulerInstance := newScheduler(&mockEntryReader{}, testHomeDir, time.Local)
-		next := schedulerInstance.nextTick(now)
+		next := schedulerInstance.nextTick(context.Background(), now)
 		require.Equal(t, time.Date(2020, 1, 1, 1, 1, 0, 0, time.UTC), next)
 	})
+	t.Run("NextTickSubMinute", func(t *testing.T) {
+		now := time.Date(2020, 1, 1, 1, 0, 15, 0, time.UTC)
+		setFixedTime(now)
+		entryReader := &mockEntryReader{
+			Entries: []*entry{
+				{Job: &mockJob{}, Next: now},
+				{Job: &mockJob{}, Next: now.Add(15 * time.Second)},
+				{Job: &mockJob{}, Next: now.Add(time.Hour)},
+			},
+		}
+		schedulerInstance := newScheduler(entryReader, testHomeDir, time.Local)
+		next := schedulerInstance.nextTick(context.Background(), now)
+		require.Equal(t, time.Date(2020, 1, 1, 1, 0, 30, 0, time.UTC), next)
+	})
+	t.Run("NextTickSkipsTicksBeforeStart", func(t *testing.T) {
+		now := time.Date(2020, 1, 1, 1, 0, 40, 0, time.UTC)
+		setFixedTime(now)
+		tick := now.Truncate(time.Minute)
+		entryReader := &mockEntryReader{
+			Entries: []*entry{
+				{Job: &mockJob{}, Next: tick.Add(15 * time.Second)},
+			},
+		}
+		schedulerInstance := newScheduler(entryReader, testHomeDir, time.Local)
+		schedulerInstance.startedAt = now
+		next := schedulerInstance.nextTick(context.Background(), tick)
+		require.Equal(t, now, next)
+	})
+	t.Run("NextTickKeepsMissedTicks", func(t *testing.T) {
+		// The scheduler was paused over the ticks at 15s and 30s.
+		now := time.Date(2020, 1, 1, 1, 0, 40, 0, time.UTC)
+		setFixedTime(now)
+		tick := now.Truncate(time.Minute)
+		entryReader := &mockEntryReader{
+			Entries: []*entry{
+				{Job: &mockJob{}, Next: tick.Add(15 * time.Second)},
+			},
+		}
+		schedulerInstance := newScheduler(entryReader, testHomeDir, time.Local)
+		schedulerInstance.startedAt = tick
+		next := schedulerInstance.nextTick(context.Background(), tick)
+		require.Equal(t, tick.Add(15*time.Second), next)
+	})
 	t.Run("FixedTime", func(t *testing.T) {
 		fixedTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
 
@@ -81,3 +126,176 @@ func TestScheduler(t *testing.T) {
 		require.NotEqual(t, fixedTime, now())
 	})
 }
+
+func TestScheduler_Catchup(t *testing.T) {
+	now := time.Date(2020, 1, 1, 10, 0, 30, 0, time.UTC)
+	setFixedTime(now)
+	defer setFixedTime(time.Time{})
+
+	dag := hourlyDAG(t, digraph.Catchup{Policy: digraph.CatchupAll, Limit: 2})
+	tickStore := newMockTickStore()
+	require.NoError(t, tickStore.SetLastTick("catchup", time.Date(2020, 1, 1, 6, 0, 0, 0, time.UTC)))
+
+	jobFactory := &mockJobFactory{}
+	schedulerInstance := newScheduler(&mockEntryReader{DAGList: []*digraph.DAG{dag}}, testHomeDir, time.UTC)
+	schedulerInstance.jobCreator = jobFactory
+	schedulerInstance.tickStore = tickStore
+
+	go func() {
+		_ = schedulerInstance.Start(context.Background())
+	}()
+
+	require.Eventually(t, func() bool {
+		last, _ := tickStore.LastTick("catchup")
+		return last.Equal(time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC))
+	}, time.Second*5, time.Millisecond*50)
+	schedulerInstance.Stop(context.Background())
+
+	// Only the last two ticks of the missed 7:00, 8:00 and 9:00 are run.
+	jobs := jobFactory.CreatedJobs()
+	require.Len(t, jobs, 2)
+	require.Equal(t, time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC), jobs[0].Next)
+	require.Equal(t, time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC), jobs[1].Next)
+	for _, j := range jobs {
+		require.Equal(t, int32(1), j.CatchupCount.Load())
+	}
+}
+
+func TestScheduler_CatchupAgainWhileCatchingUp(t *testing.T) {
+	ctx := context.Background()
+	dag := hourlyDAG(t, digraph.Catchup{Policy: digraph.CatchupAll})
+	tickStore := newMockTickStore()
+	require.NoError(t, tickStore.SetLastTick("catchup", time.Date(2020, 1, 1, 6, 0, 0, 0, time.UTC)))
+
+	jobFactory := &mockJobFactory{Block: make(chan struct{})}
+	schedulerInstance := newScheduler(&mockEntryReader{DAGList: []*digraph.DAG{dag}}, testHomeDir, time.UTC)
+	schedulerInstance.jobCreator = jobFactory
+	schedulerInstance.tickStore = tickStore
+
+	// The runner is blocked at the first of the missed 7:00, 8:00 and 9:00.
+	schedulerInstance.catchupMissedTicks(ctx, time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC))
+	require.Eventually(t, func() bool {
+		return len(jobFactory.CreatedJobs()) == 1
+	}, time.Second, time.Millisecond*10)
+
+	// The scheduler catches up again, e.g. after regaining the leadership.
+	schedulerInstance.catchupMissedTicks(ctx, time.Date(2020, 1, 1, 11, 0, 0, 0, time.UTC))
+	close(jobFactory.Block)
+
+	require.Eventually(t, func() bool {
+		last, _ := tickStore.LastTick("catchup")
+		return last.Equal(time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC))
+	}, time.Second*5, time.Millisecond*10)
+
+	// Each tick is run once in order by the single runner.
+	jobs := jobFactory.CreatedJobs()
+	require.Len(t, jobs, 4)
+	for i, j := range jobs {
+		require.Equal(t, time.Date(2020, 1, 1, 7+i, 0, 0, 0, time.UTC), j.Next)
+	}
+}
+
+func TestScheduler_LeaderElection(t *testing.T) {
+	ctx := context.Background()
+	tick := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	lock := &mockLeaderLock{}
+
+	newInstance := func() (*Scheduler, *mockJob) {
+		job := &mockJob{}
+		sc := newScheduler(&mockEntryReader{Entries: []*entry{{Job: job, Next: tick}}}, testHomeDir, time.UTC)
+		sc.leaderLock = lock
+		sc.leaseDuration = time.Millisecond * 200
+		return sc, job
+	}
+
+	t.Run("OnlyLeaderRuns", func(t *testing.T) {
+		leader, leaderJob := newInstance()
+		standby, standbyJob := newInstance()
+
+		require.True(t, leader.campaign(ctx))
+		require.False(t, standby.campaign(ctx))
+
+		leader.run(ctx, tick)
+		standby.run(ctx, tick)
+
+		require.Eventually(t, func() bool {
+			return leaderJob.RunCount.Load() == 1
+		}, time.Second, time.Millisecond*10)
+		require.Equal(t, int32(0), standbyJob.RunCount.Load())
+
+		// The standby takes over when the lease of the leader expires.
+		time.Sleep(leader.leaseDuration)
+		require.True(t, standby.campaign(ctx))
+		require.False(t, leader.campaign(ctx))
+
+		leader.run(ctx, tick)
+		standby.run(ctx, tick)
+
+		require.Eventually(t, func() bool {
+			return standbyJob.RunCount.Load() == 1
+		}, time.Second, time.Millisecond*10)
+		require.Equal(t, int32(1), leaderJob.RunCount.Load())
+
+		standby.resign(ctx)
+	})
+	t.Run("FencingToken", func(t *testing.T) {
+		leader, leaderJob := newInstance()
+		standby, standbyJob := newInstance()
+		leader.leaseDuration = time.Minute
+
+		require.True(t, leader.campaign(ctx))
+
+		// Another scheduler takes over while the leader still believes its
+		// lease is valid, e.g. after it was paused.
+		require.NoError(t, lock.Release(leader.id))
+		require.True(t, standby.campaign(ctx))
+
+		leader.run(ctx, tick)
+		standby.run(ctx, tick)
+
+		require.Eventually(t, func() bool {
+			return standbyJob.RunCount.Load() == 1
+		}, time.Second, time.Millisecond*10)
+		require.Equal(t, int32(0), leaderJob.RunCount.Load())
+		require.False(t, leader.isLeader())
+
+		standby.resign(ctx)
+	})
+	t.Run("TakeOverOnStop", func(t *testing.T) {
+		leader, _ := newInstance()
+		standby, _ := newInstance()
+
+		go func() {
+			_ = leader.Start(ctx)
+		}()
+		require.Eventually(t, func() bool {
+			return leader.running.Load()
+		}, time.Second, time.Millisecond*10)
+		require.True(t, leader.isLeader())
+
+		go func() {
+			_ = standby.Start(ctx)
+		}()
+		defer standby.Stop(ctx)
+
+		require.Eventually(t, func() bool {
+			return standby.running.Load()
+		}, time.Second, time.Millisecond*10)
+		require.False(t, standby.isLeader())
+
+		leader.Stop(ctx)
+		require.Eventually(t, standby.isLeader, time.Second, time.Millisecond*10)
+	})
+}
+
+func hourlyDAG(t *testing.T, catchup digraph.Catchup) *digraph.DAG {
+	t.Helper()
+	parsed, err := cron.ParseStandard("0 * * * *")
+	require.NoError(t, err)
+	return &digraph.DAG{
+		Name:     "catchup",
+		Location: "/dags/catchup.yaml",
+		Schedule: []digraph.Schedule{{Expression: "0 * * * *", Parsed: parsed}},
+		Catchup:  catchup,
+	}
+}
@@ -0,0 +1,221 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// keySize is the size of the AES-256 key to encrypt the keystore.
+const keySize = 32
+
+// Keystore is a local file that stores the secret values encrypted with
+// AES-GCM. The key is stored in a separate file that is created with the
+// first value.
+type Keystore struct {
+	file    string
+	keyFile string
+}
+
+// keystoreData is the content of the keystore file.
+type keystoreData struct {
+	// Secrets is the map of the names to the encrypted values encoded in
+	// base64.
+	Secrets map[string]string `json:"secrets"`
+}
+
+// NewKeystore creates a new Keystore backed by the files.
+func NewKeystore(file, keyFile string) *Keystore {
+	return &Keystore{file: file, keyFile: keyFile}
+}
+
+// Get returns the decrypted value of the secret.
+func (k *Keystore) Get(name string) (string, error) {
+	data, err := k.read()
+	if err != nil {
+		return "", err
+	}
+	encrypted, ok := data.Secrets[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s is not in the keystore", ErrSecretNotFound, name)
+	}
+
+	key, err := k.readKey()
+	if err != nil {
+		return "", err
+	}
+	return decrypt(key, encrypted)
+}
+
+// Set encrypts and stores the value of the secret. The key file is created
+// if it does not exist.
+func (k *Keystore) Set(name, value string) error {
+	data, err := k.read()
+	if err != nil {
+		return err
+	}
+
+	key, err := k.readKey()
+	if errors.Is(err, os.ErrNotExist) {
+		key, err = k.createKey()
+	}
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := encrypt(key, value)
+	if err != nil {
+		return err
+	}
+	data.Secrets[name] = encrypted
+	return k.write(data)
+}
+
+// Delete removes the secret from the keystore.
+func (k *Keystore) Delete(name string) error {
+	data, err := k.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := data.Secrets[name]; !ok {
+		return fmt.Errorf("%w: %s is not in the keystore", ErrSecretNotFound, name)
+	}
+	delete(data.Secrets, name)
+	return k.write(data)
+}
+
+// List returns the sorted names of the secrets in the keystore.
+func (k *Keystore) List() ([]string, error) {
+	data, err := k.read()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(data.Secrets))
+	for name := range data.Secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (k *Keystore) read() (*keystoreData, error) {
+	data := &keystoreData{Secrets: make(map[string]string)}
+	raw, err := os.ReadFile(k.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, fmt.Errorf("failed to parse keystore %s: %w", k.file, err)
+	}
+	if data.Secrets == nil {
+		data.Secrets = make(map[string]string)
+	}
+	return data, nil
+}
+
+func (k *Keystore) write(data *keystoreData) error {
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(k.file, raw)
+}
+
+func (k *Keystore) readKey() ([]byte, error) {
+	encoded, err := os.ReadFile(k.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore key: %w", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(string(encoded))
+	if err != nil || len(key) != keySize {
+		return nil, fmt.Errorf("invalid keystore key %s", k.keyFile)
+	}
+	return key, nil
+}
+
+func (k *Keystore) createKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString(key)
+	if err := writeFileAtomic(k.keyFile, []byte(encoded)); err != nil {
+		return nil, fmt.Errorf("failed to create keystore key: %w", err)
+	}
+	return key, nil
+}
+
+func encrypt(key []byte, value string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func decrypt(key []byte, encrypted string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return "", errors.New("invalid encrypted value in the keystore")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt the value in the keystore: %w", err)
+	}
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// writeFileAtomic writes the data to the file readable only by the owner.
+func writeFileAtomic(file string, data []byte) error {
+	dir := filepath.Dir(file)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(file)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
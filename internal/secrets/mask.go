@@ -0,0 +1,102 @@
+package secrets
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Mask is the string that replaces the secret values.
+const Mask = "*****"
+
+// Masker replaces the secret values in strings with Mask.
+// The zero value and nil do not mask anything.
+type Masker struct {
+	replacer *strings.Replacer
+}
+
+// NewMasker creates a new Masker for the values. Each line of a multi-line
+// value is masked as well since the outputs are masked line by line.
+func NewMasker(values []string) *Masker {
+	seen := make(map[string]bool)
+	var targets []string
+	add := func(v string) {
+		if v == "" || seen[v] {
+			return
+		}
+		seen[v] = true
+		targets = append(targets, v)
+	}
+	for _, v := range values {
+		add(v)
+		if strings.Contains(v, "\n") {
+			for _, line := range strings.Split(v, "\n") {
+				add(strings.TrimRight(line, "\r"))
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	// Replace the longer values first so that a value containing another
+	// value is masked as a whole.
+	sort.SliceStable(targets, func(i, j int) bool {
+		return len(targets[i]) > len(targets[j])
+	})
+	oldnew := make([]string, 0, len(targets)*2)
+	for _, v := range targets {
+		oldnew = append(oldnew, v, Mask)
+	}
+	return &Masker{replacer: strings.NewReplacer(oldnew...)}
+}
+
+// Mask returns the string with the secret values replaced.
+func (m *Masker) Mask(s string) string {
+	if m == nil || m.replacer == nil {
+		return s
+	}
+	return m.replacer.Replace(s)
+}
+
+// MaskingWriter is a writer that masks the secret values in the data before
+// writing it to the underlying writer. The data is written line by line so
+// that a value split into multiple writes is masked. Flush must be called to
+// write the last incomplete line.
+type MaskingWriter struct {
+	w      io.Writer
+	masker *Masker
+	buf    []byte
+}
+
+// NewMaskingWriter creates a new MaskingWriter.
+func NewMaskingWriter(w io.Writer, masker *Masker) *MaskingWriter {
+	return &MaskingWriter{w: w, masker: masker}
+}
+
+// Write implements io.Writer.
+func (w *MaskingWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	i := bytes.LastIndexByte(w.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	lines := string(w.buf[:i+1])
+	w.buf = append(w.buf[:0], w.buf[i+1:]...)
+	if _, err := io.WriteString(w.w, w.masker.Mask(lines)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush writes the buffered incomplete line.
+func (w *MaskingWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	rest := string(w.buf)
+	w.buf = w.buf[:0]
+	_, err := io.WriteString(w.w, w.masker.Mask(rest))
+	return err
+}
@@ -0,0 +1,41 @@
+package secrets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMasker(t *testing.T) {
+	t.Run("Mask", func(t *testing.T) {
+		m := NewMasker([]string{"abc", "abcdef", ""})
+		require.Equal(t, "token=***** short=*****", m.Mask("token=abcdef short=abc"))
+	})
+	t.Run("MultiLine", func(t *testing.T) {
+		m := NewMasker([]string{"line1\nline2"})
+		require.Equal(t, "*****", m.Mask("line1\nline2"))
+		require.Equal(t, "a ***** b", m.Mask("a line2 b"))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		m := NewMasker(nil)
+		require.Nil(t, m)
+		require.Equal(t, "abc", m.Mask("abc"))
+	})
+}
+
+func TestMaskingWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewMaskingWriter(&buf, NewMasker([]string{"s3cret"}))
+
+	// The value is split into multiple writes.
+	for _, s := range []string{"password: s3", "cret\nnext ", "line s3cr", "et"} {
+		n, err := w.Write([]byte(s))
+		require.NoError(t, err)
+		require.Equal(t, len(s), n)
+	}
+	require.Equal(t, "password: *****\n", buf.String())
+
+	require.NoError(t, w.Flush())
+	require.Equal(t, "password: *****\nnext line *****", buf.String())
+}
@@ -0,0 +1,83 @@
+// Package secrets resolves the values of the secrets referenced by DAGs and
+// masks them in the outputs.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Names of the providers of the secret values.
+const (
+	// ProviderEnv reads the value from the environment variable.
+	ProviderEnv = "env"
+	// ProviderFile reads the value from the file.
+	ProviderFile = "file"
+	// ProviderKeystore reads the value from the encrypted local keystore.
+	ProviderKeystore = "keystore"
+)
+
+var (
+	ErrSecretNotFound        = errors.New("secret not found")
+	ErrUnknownProvider       = errors.New("unknown secret provider")
+	ErrKeystoreNotConfigured = errors.New("keystore is not configured")
+)
+
+// Providers returns the names of the supported providers.
+func Providers() []string {
+	return []string{ProviderEnv, ProviderFile, ProviderKeystore}
+}
+
+// IsValidProvider returns true if the provider is supported.
+func IsValidProvider(provider string) bool {
+	return slices.Contains(Providers(), provider)
+}
+
+// Resolver resolves the values of the secrets from the providers.
+type Resolver struct {
+	keystore *Keystore
+}
+
+// NewResolver creates a new Resolver. The keystore can be nil if it is not
+// configured.
+func NewResolver(keystore *Keystore) *Resolver {
+	return &Resolver{keystore: keystore}
+}
+
+// Resolve returns the value of the secret identified by the key in the
+// provider.
+func (r *Resolver) Resolve(_ context.Context, provider, key string) (string, error) {
+	switch provider {
+	case ProviderEnv:
+		value, ok := os.LookupEnv(key)
+		if !ok {
+			return "", fmt.Errorf("%w: environment variable %s is not set", ErrSecretNotFound, key)
+		}
+		return value, nil
+
+	case ProviderFile:
+		data, err := os.ReadFile(key)
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%w: file %s does not exist", ErrSecretNotFound, key)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file %s: %w", key, err)
+		}
+		// Trailing newlines are usually not a part of the secret.
+		return strings.TrimRight(string(data), "\r\n"), nil
+
+	case ProviderKeystore:
+		if r == nil || r.keystore == nil {
+			return "", ErrKeystoreNotConfigured
+		}
+		return r.keystore.Get(key)
+
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownProvider, provider)
+
+	}
+}
@@ -0,0 +1,96 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Env", func(t *testing.T) {
+		t.Setenv("SECRETS_TEST_TOKEN", "s3cret")
+
+		value, err := NewResolver(nil).Resolve(ctx, ProviderEnv, "SECRETS_TEST_TOKEN")
+		require.NoError(t, err)
+		require.Equal(t, "s3cret", value)
+
+		_, err = NewResolver(nil).Resolve(ctx, ProviderEnv, "SECRETS_TEST_NOT_SET")
+		require.ErrorIs(t, err, ErrSecretNotFound)
+	})
+	t.Run("File", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(file, []byte("s3cret\n"), 0600))
+
+		value, err := NewResolver(nil).Resolve(ctx, ProviderFile, file)
+		require.NoError(t, err)
+		require.Equal(t, "s3cret", value)
+
+		_, err = NewResolver(nil).Resolve(ctx, ProviderFile, file+".missing")
+		require.ErrorIs(t, err, ErrSecretNotFound)
+	})
+	t.Run("Keystore", func(t *testing.T) {
+		dir := t.TempDir()
+		keystore := NewKeystore(filepath.Join(dir, "keystore.json"), filepath.Join(dir, "keystore.key"))
+		require.NoError(t, keystore.Set("token", "s3cret"))
+
+		value, err := NewResolver(keystore).Resolve(ctx, ProviderKeystore, "token")
+		require.NoError(t, err)
+		require.Equal(t, "s3cret", value)
+
+		_, err = NewResolver(nil).Resolve(ctx, ProviderKeystore, "token")
+		require.ErrorIs(t, err, ErrKeystoreNotConfigured)
+	})
+	t.Run("UnknownProvider", func(t *testing.T) {
+		_, err := NewResolver(nil).Resolve(ctx, "vault", "token")
+		require.ErrorIs(t, err, ErrUnknownProvider)
+	})
+}
+
+func TestKeystore(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "keystore.json")
+	keyFile := filepath.Join(dir, "keystore.key")
+	keystore := NewKeystore(file, keyFile)
+
+	names, err := keystore.List()
+	require.NoError(t, err)
+	require.Empty(t, names)
+
+	require.NoError(t, keystore.Set("b", "value-b"))
+	require.NoError(t, keystore.Set("a", "value-a"))
+
+	// The values are encrypted in the file.
+	raw, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), "value-a")
+
+	for _, f := range []string{file, keyFile} {
+		info, err := os.Stat(f)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	names, err = keystore.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, names)
+
+	value, err := NewKeystore(file, keyFile).Get("a")
+	require.NoError(t, err)
+	require.Equal(t, "value-a", value)
+
+	require.NoError(t, keystore.Delete("a"))
+	_, err = keystore.Get("a")
+	require.ErrorIs(t, err, ErrSecretNotFound)
+	require.ErrorIs(t, keystore.Delete("a"), ErrSecretNotFound)
+
+	// Another key cannot decrypt the values.
+	otherKeyFile := filepath.Join(dir, "other.key")
+	require.NoError(t, NewKeystore(filepath.Join(dir, "other.json"), otherKeyFile).Set("x", "y"))
+	_, err = NewKeystore(file, otherKeyFile).Get("b")
+	require.Error(t, err)
+}
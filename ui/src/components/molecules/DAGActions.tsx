@@ -4,7 +4,13 @@ import { DAG, SchedulerStatus, Status } from '../../models';
 import ActionButton from '../atoms/ActionButton';
 import { useNavigate } from 'react-router-dom';
 import { FontAwesomeIcon } from '@fortawesome/react-fontawesome';
-import { faPlay, faStop, faReply } from '@fortawesome/free-solid-svg-icons';
+import {
+  faPlay,
+  faStop,
+  faReply,
+  faPause,
+  faForward,
+} from '@fortawesome/free-solid-svg-icons';
 import VisuallyHidden from '../atoms/VisuallyHidden';
 import StartDAGModal from './StartDAGModal';
 import ConfirmModal from './ConfirmModal';
@@ -78,15 +84,18 @@ function DAGActions({
     [refresh]
   );
 
-  const buttonState = React.useMemo(
-    () => ({
-      start: status?.Status != SchedulerStatus.Running,
-      stop: status?.Status == SchedulerStatus.Running,
-      retry:
-        status?.Status != SchedulerStatus.Running && status?.RequestId != '',
-    }),
-    [status]
-  );
+  const buttonState = React.useMemo(() => {
+    const active =
+      status?.Status == SchedulerStatus.Running ||
+      status?.Status == SchedulerStatus.Paused;
+    return {
+      start: !active,
+      stop: active,
+      pause: status?.Status == SchedulerStatus.Running,
+      resume: status?.Status == SchedulerStatus.Paused,
+      retry: !active && status?.RequestId != '',
+    };
+  }, [status]);
   return (
     <Stack direction="row" spacing={2}>
       <ActionButton
@@ -119,6 +128,39 @@ function DAGActions({
       >
         {label && 'Stop'}
       </ActionButton>
+      {buttonState['resume'] ? (
+        <ActionButton
+          label={label}
+          icon={
+            <>
+              <Label show={false}>Resume</Label>
+              <span className="icon">
+                <FontAwesomeIcon icon={faForward} />
+              </span>
+            </>
+          }
+          disabled={!buttonState['resume']}
+          onClick={() => onSubmit({ name: name, action: 'resume' })}
+        >
+          {label && 'Resume'}
+        </ActionButton>
+      ) : (
+        <ActionButton
+          label={label}
+          icon={
+            <>
+              <Label show={false}>Pause</Label>
+              <span className="icon">
+                <FontAwesomeIcon icon={faPause} />
+              </span>
+            </>
+          }
+          disabled={!buttonState['pause']}
+          onClick={() => onSubmit({ name: name, action: 'pause' })}
+        >
+          {label && 'Pause'}
+        </ActionButton>
+      )}
       <ActionButton
         label={label}
         icon={
@@ -141,7 +183,11 @@ function DAGActions({
         dismissModal={() => setIsStopModal(false)}
         onSubmit={() => {
           setIsStopModal(false);
-          onSubmit({ name: name, action: 'stop' });
+          onSubmit({
+            name: name,
+            action: 'stop',
+            requestId: status?.RequestId,
+          });
         }}
       >
         <Box>Do you really want to cancel the DAG?</Box>
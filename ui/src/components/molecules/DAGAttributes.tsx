@@ -5,9 +5,10 @@ import { DAG } from '../../models';
 
 type Props = {
   dag: DAG;
+  nextRun?: string;
 };
 
-function DAGAttributes({ dag: config }: Props) {
+function DAGAttributes({ dag: config, nextRun }: Props) {
   const preconditions = config.Preconditions?.map((c) => (
     <li>
       {c.Condition}
@@ -33,6 +34,10 @@ function DAGAttributes({ dag: config }: Props) {
           ))}
         </Stack>
       </LabeledItem>
+      {config.Timezone ? (
+        <LabeledItem label="Timezone">{config.Timezone}</LabeledItem>
+      ) : null}
+      {nextRun ? <LabeledItem label="Next Run">{nextRun}</LabeledItem> : null}
       <LabeledItem label="Description">{config.Description}</LabeledItem>
       <LabeledItem label="Max Active Runs">{config.MaxActiveRuns}</LabeledItem>
       <LabeledItem label="Params">{config.Params?.join(' ')}</LabeledItem>
@@ -1,5 +1,5 @@
 import React from 'react';
-import { Status } from '../../models';
+import { DAGRunRef, Status } from '../../models';
 import StatusChip from '../atoms/StatusChip';
 import { Stack } from '@mui/material';
 import LabeledItem from '../atoms/LabeledItem';
@@ -11,6 +11,16 @@ type Props = {
   file?: string;
 };
 
+function RunLink({ run }: { run: DAGRunRef }) {
+  return (
+    <span>
+      <Link to={`/dags/${encodeURI(run.DagId)}/history`}>{run.Name}</Link>
+      {run.On ? ` (${run.On})` : ''}
+      {run.Error ? ` - ${run.Error}` : ` - ${run.RequestId}`}
+    </span>
+  );
+}
+
 function DAGStatusOverview({ status, name, file = '' }: Props) {
   const url = `/dags/${name}/scheduler-log?&file=${encodeURI(file)}`;
   if (!status) {
@@ -27,6 +37,23 @@ function DAGStatusOverview({ status, name, file = '' }: Props) {
         <LabeledItem label="Finished At">{status.FinishedAt}</LabeledItem>
       </Stack>
       <LabeledItem label="Params">{status.Params}</LabeledItem>
+      {status.Error ? (
+        <LabeledItem label="Error">{status.Error}</LabeledItem>
+      ) : null}
+      {status.TriggeredBy ? (
+        <LabeledItem label="Triggered By">
+          <RunLink run={status.TriggeredBy} />
+        </LabeledItem>
+      ) : null}
+      {status.Triggered && status.Triggered.length > 0 ? (
+        <LabeledItem label="Triggered">
+          <Stack direction="column">
+            {status.Triggered.map((run, i) => (
+              <RunLink key={`${run.DagId}-${i}`} run={run} />
+            ))}
+          </Stack>
+        </LabeledItem>
+      ) : null}
       <LabeledItem label="Scheduler Log">
         <Link to={url}>{status.Log}</Link>
       </LabeledItem>
@@ -35,6 +35,7 @@ import {
   DAGItem,
   DAGDataType,
   getNextSchedule,
+  getNextRunText,
 } from '../../models';
 import StyledTableRow from '../atoms/StyledTableRow';
 import {
@@ -307,7 +308,7 @@ const defaultColumns = [
                     .diff(moment.now());
                   const format = ms / 1000 > 60 ? durFormatMin : durFormatSec;
                   return (
-                    <span>
+                    <span title={getNextRunText(data.DAGStatus)}>
                       {moment
                         .duration(ms)
                         // eslint-disable-next-line @typescript-eslint/ban-ts-comment
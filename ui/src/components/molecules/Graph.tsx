@@ -72,6 +72,10 @@ const Graph: React.FC<Props> = ({
       "<i class='fas fa-check-circle' style='color: #16a34a'></i>",
     [NodeStatus.Skipped]:
       "<i class='fas fa-forward' style='color: #64748b'></i>",
+    [NodeStatus.Timeout]:
+      "<i class='fas fa-hourglass-end' style='color: #f97316'></i>",
+    [NodeStatus.Waiting]:
+      "<i class='fas fa-hand-paper' style='color: #ca8a04'></i>",
   };
   if (!animate) {
     // Remove animations if disabled
@@ -164,6 +168,12 @@ const Graph: React.FC<Props> = ({
     dat.push(
       'classDef skipped fill:#f8fafc,stroke:#cbd5e1,color:#475569,stroke-width:1.2px,white-space:nowrap'
     );
+    dat.push(
+      'classDef timeout fill:#fff7ed,stroke:#fdba74,color:#9a3412,stroke-width:1.2px,white-space:nowrap'
+    );
+    dat.push(
+      'classDef waiting fill:#fefce8,stroke:#fde047,color:#854d0e,stroke-width:1.2px,white-space:nowrap'
+    );
 
     // Add custom link styles
     dat.push(...linkStyles);
@@ -240,4 +250,6 @@ const graphStatusMap = {
   [NodeStatus.Cancel]: ':::cancel',
   [NodeStatus.Success]: ':::done',
   [NodeStatus.Skipped]: ':::skipped',
+  [NodeStatus.Timeout]: ':::timeout',
+  [NodeStatus.Waiting]: ':::waiting',
 };
@@ -1,7 +1,7 @@
 import React, { CSSProperties } from 'react';
 import { stepTabColStyles } from '../../consts';
 import { useDAGPostAPI } from '../../hooks/useDAGPostAPI';
-import { Node } from '../../models';
+import { Node, NodeStatus } from '../../models';
 import { SchedulerStatus, Status } from '../../models';
 import { Step } from '../../models';
 import NodeStatusTableRow from './NodeStatusTableRow';
@@ -25,18 +25,21 @@ type Props = {
 
 function NodeStatusTable({ nodes, status, name, refresh, file = '' }: Props) {
   const [modal, setModal] = React.useState(false);
-  const [current, setCurrent] = React.useState<Step | undefined>(undefined);
+  const [current, setCurrent] = React.useState<Node | undefined>(undefined);
   const { doPost } = useDAGPostAPI({
     name,
     onSuccess: refresh,
     requestId: status.RequestId,
   });
   const requireModal = (step: Step) => {
+    const node = nodes?.find((n) => n.Step.Name == step.Name);
     if (
-      status?.Status != SchedulerStatus.Running &&
-      status?.Status != SchedulerStatus.None
+      node?.Status == NodeStatus.Waiting ||
+      (status?.Status != SchedulerStatus.Running &&
+        status?.Status != SchedulerStatus.Paused &&
+        status?.Status != SchedulerStatus.None)
     ) {
-      setCurrent(step);
+      setCurrent(node);
       setModal(true);
     }
   };
@@ -82,6 +85,7 @@ function NodeStatusTable({ nodes, status, name, refresh, file = '' }: Props) {
                 node={n}
                 file={file}
                 name={name}
+                requestId={status.RequestId}
                 onRequireModal={requireModal}
               ></NodeStatusTableRow>
             ))}
@@ -90,7 +94,12 @@ function NodeStatusTable({ nodes, status, name, refresh, file = '' }: Props) {
       </BorderedBox>
       <StatusUpdateModal
         visible={modal}
-        step={current}
+        step={current?.Step}
+        approval={
+          current?.Status == NodeStatus.Waiting
+            ? current.Approval || {}
+            : undefined
+        }
         dismissModal={dismissModal}
         onSubmit={onUpdateStatus}
       />
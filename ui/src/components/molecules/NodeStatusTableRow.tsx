@@ -12,6 +12,7 @@ type Props = {
   node: Node;
   file: string;
   name: string;
+  requestId: string;
   onRequireModal: (step: Step) => void;
 };
 
@@ -20,6 +21,7 @@ function NodeStatusTableRow({
   rownum,
   node,
   file,
+  requestId,
   onRequireModal,
 }: Props) {
   const url = `/dags/${name}/log?file=${file}&step=${encodeURIComponent(node.Step.Name)}`;
@@ -35,6 +37,29 @@ function NodeStatusTableRow({
     // Use uninterpolated args to avoid render issues with very long params
     args = node.Step.CmdWithArgs.replace(node.Step.Command, '').trimStart();
   }
+  let attempts = '';
+  if (node.Attempts && node.Attempts.length > 1) {
+    attempts = node.Attempts.map(
+      (a) =>
+        `#${a.Attempt} ${a.FinishedAt} exit ${a.ExitCode}${
+          a.Error ? `: ${a.Error}` : ''
+        }`
+    ).join('\n');
+  }
+  let outputs = '';
+  if (node.Outputs) {
+    outputs = Object.entries(node.Outputs)
+      .map(([k, v]) => `${k}=${v}`)
+      .join('\n');
+  }
+  const artifactURL = (path: string) =>
+    `${getConfig().apiURL}/dags/${encodeURIComponent(
+      name
+    )}/artifacts?requestId=${encodeURIComponent(
+      requestId
+    )}&step=${encodeURIComponent(node.Step.Name)}&path=${encodeURIComponent(
+      path
+    )}`;
   return (
     <StyledTableRow>
       <TableCell> {rownum} </TableCell>
@@ -52,8 +77,45 @@ function NodeStatusTableRow({
             {node.StatusText}
           </NodeStatusChip>
         </button>
+        {node.Iterations ? (
+          <div title={node.LastResult}>
+            {node.Iterations} iteration{node.Iterations > 1 ? 's' : ''}
+          </div>
+        ) : null}
+        {node.Approval?.At ? (
+          <div title={node.Approval.Comment}>
+            {node.Approval.Approved ? 'approved' : 'rejected'}
+            {node.Approval.User ? ` by ${node.Approval.User}` : ''}
+          </div>
+        ) : null}
+        {outputs ? (
+          <details>
+            <summary>Outputs</summary>
+            <MultilineText>{outputs}</MultilineText>
+          </details>
+        ) : null}
+        {node.Artifacts && node.Artifacts.length ? (
+          <details>
+            <summary>Artifacts ({node.Artifacts.length})</summary>
+            {node.Artifacts.map((path) => (
+              <div key={path}>
+                <a href={artifactURL(path)} download>
+                  {path}
+                </a>
+              </div>
+            ))}
+          </details>
+        ) : null}
+      </TableCell>
+      <TableCell>
+        {node.Error}
+        {attempts ? (
+          <details>
+            <summary>Attempts ({node.Attempts?.length})</summary>
+            <MultilineText>{attempts}</MultilineText>
+          </details>
+        ) : null}
       </TableCell>
-      <TableCell> {node.Error} </TableCell>
       <TableCell>
         {node.Log ? (
           <Link to={url}>
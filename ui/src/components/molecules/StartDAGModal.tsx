@@ -1,6 +1,7 @@
 import {
   Box,
   Button,
+  MenuItem,
   Modal,
   Stack,
   TextField,
@@ -8,7 +9,7 @@ import {
 } from '@mui/material';
 import React from 'react';
 import { Parameter, parseParams, stringifyParams } from '../../lib/parseParams';
-import { DAG } from '../../models';
+import { DAG, ParamSchema } from '../../models';
 import { Workflow } from '../../models/api';
 
 type Props = {
@@ -55,6 +56,16 @@ function StartDAGModal({ visible, dag, dismissModal, onSubmit }: Props) {
 
   const [params, setParams] = React.useState<Parameter[]>([]);
 
+  const schemas = React.useMemo(() => {
+    const ret: { [name: string]: ParamSchema } = {};
+    if ('ParamsSchema' in dag && dag.ParamsSchema) {
+      dag.ParamsSchema.forEach((s) => {
+        ret[s.Name] = s;
+      });
+    }
+    return ret;
+  }, [dag]);
+
   React.useEffect(() => {
     setParams(parsedParams);
   }, [parsedParams]);
@@ -74,36 +85,61 @@ function StartDAGModal({ visible, dag, dismissModal, onSubmit }: Props) {
         >
           {parsedParams.map((p, i) => {
             if (p.Name != undefined) {
+              const schema = schemas[p.Name];
+              const options =
+                schema?.Type == 'boolean' && !schema.Enum?.length
+                  ? ['true', 'false']
+                  : schema?.Enum;
               return (
                 <React.Fragment key={i}>
                   <TextField
                     label={p.Name}
                     placeholder={p.Value}
                     variant="outlined"
+                    select={!!options?.length}
+                    type={
+                      schema?.Type == 'integer' || schema?.Type == 'number'
+                        ? 'number'
+                        : 'text'
+                    }
+                    required={schema?.Required}
+                    helperText={schema?.Description}
+                    inputProps={{
+                      min: schema?.Minimum,
+                      max: schema?.Maximum,
+                      step: schema?.Type == 'integer' ? 1 : undefined,
+                      minLength: schema?.MinLength,
+                      maxLength: schema?.MaxLength,
+                      pattern: schema?.Pattern,
+                    }}
                     style={{
                       flex: 0.5,
                     }}
                     inputRef={ref}
-                    InputProps={{
-                      value: params.find((pp) => pp.Name == p.Name)?.Value,
-                      onChange: (e) => {
-                        if (p.Name) {
-                          setParams(
-                            params.map((pp) => {
-                              if (pp.Name == p.Name) {
-                                return {
-                                  ...pp,
-                                  Value: e.target.value,
-                                };
-                              } else {
-                                return pp;
-                              }
-                            })
-                          );
-                        }
-                      },
+                    value={params.find((pp) => pp.Name == p.Name)?.Value ?? ''}
+                    onChange={(e) => {
+                      if (p.Name) {
+                        setParams(
+                          params.map((pp) => {
+                            if (pp.Name == p.Name) {
+                              return {
+                                ...pp,
+                                Value: e.target.value,
+                              };
+                            } else {
+                              return pp;
+                            }
+                          })
+                        );
+                      }
                     }}
-                  />
+                  >
+                    {options?.map((o) => (
+                      <MenuItem key={o} value={o}>
+                        {o}
+                      </MenuItem>
+                    ))}
+                  </TextField>
                 </React.Fragment>
               );
             } else {
@@ -1,11 +1,12 @@
 import { Box, Button, Modal, Stack, Typography } from '@mui/material';
 import React from 'react';
-import { Step } from '../../models';
+import { Approval, Step } from '../../models';
 
 type Props = {
   visible: boolean;
   dismissModal: () => void;
   step?: Step;
+  approval?: Approval;
   onSubmit: (step: Step, action: string) => void;
 };
 
@@ -21,7 +22,13 @@ const style = {
   p: 4,
 };
 
-function StatusUpdateModal({ visible, dismissModal, step, onSubmit }: Props) {
+function StatusUpdateModal({
+  visible,
+  dismissModal,
+  step,
+  approval,
+  onSubmit,
+}: Props) {
   React.useEffect(() => {
     const callback = (event: KeyboardEvent) => {
       const e = event || window.event;
@@ -37,12 +44,21 @@ function StatusUpdateModal({ visible, dismissModal, step, onSubmit }: Props) {
   if (!step) {
     return null;
   }
+  // The step is waiting for approval.
+  const waiting = !!approval;
   return (
     <Modal open={visible} onClose={dismissModal}>
       <Box sx={style}>
         <Stack direction="row" alignContent="center" justifyContent="center">
-          <Typography variant="h6">Update status of "{step.Name}"</Typography>
+          <Typography variant="h6">
+            {waiting ? 'Approve' : 'Update status of'} "{step.Name}"
+          </Typography>
         </Stack>
+        {approval?.Message ? (
+          <Typography mt={2} align="center">
+            {approval.Message}
+          </Typography>
+        ) : null}
         <Stack
           direction="column"
           alignContent="center"
@@ -56,18 +72,43 @@ function StatusUpdateModal({ visible, dismissModal, step, onSubmit }: Props) {
             justifyContent="center"
             spacing={2}
           >
-            <Button
-              variant="outlined"
-              onClick={() => onSubmit(step, 'mark-success')}
-            >
-              Mark Success
-            </Button>
-            <Button
-              variant="outlined"
-              onClick={() => onSubmit(step, 'mark-failed')}
-            >
-              Mark Failed
-            </Button>
+            {waiting ? (
+              <React.Fragment>
+                <Button
+                  variant="outlined"
+                  onClick={() => onSubmit(step, 'approve')}
+                >
+                  Approve
+                </Button>
+                <Button
+                  variant="outlined"
+                  onClick={() => onSubmit(step, 'reject')}
+                >
+                  Reject
+                </Button>
+              </React.Fragment>
+            ) : (
+              <React.Fragment>
+                <Button
+                  variant="outlined"
+                  onClick={() => onSubmit(step, 'mark-success')}
+                >
+                  Mark Success
+                </Button>
+                <Button
+                  variant="outlined"
+                  onClick={() => onSubmit(step, 'mark-failed')}
+                >
+                  Mark Failed
+                </Button>
+                <Button
+                  variant="outlined"
+                  onClick={() => onSubmit(step, 'retry')}
+                >
+                  Retry From Here
+                </Button>
+              </React.Fragment>
+            )}
           </Stack>
           <Stack direction="row" alignContent="center" justifyContent="center">
             <Button variant="outlined" color="error" onClick={dismissModal}>
@@ -12,7 +12,8 @@ const timeFormat = 'YYYY-MM-DD HH:mm:ss';
 function TimelineChart({ status }: Props) {
   if (
     status.Status == SchedulerStatus.None ||
-    status.Status == SchedulerStatus.Running
+    status.Status == SchedulerStatus.Running ||
+    status.Status == SchedulerStatus.Paused
   ) {
     return null;
   }
@@ -2,7 +2,7 @@ import { Box, Button, Stack } from '@mui/material';
 import React from 'react';
 import { GetDAGResponse } from '../../models/api';
 import { DAGContext } from '../../contexts/DAGContext';
-import { DAG, Step } from '../../models';
+import { DAG, Step, getNextRunText } from '../../models';
 import DAGEditor from '../atoms/DAGEditor';
 import DAGAttributes from '../molecules/DAGAttributes';
 import DAGDefinition from '../molecules/DAGDefinition';
@@ -81,7 +81,10 @@ function DAGSpec({ data }: Props) {
 
             <Box sx={{ mt: 3 }}>
               <Box sx={{ mt: 2 }}>
-                <DAGAttributes dag={data.DAG.DAG!}></DAGAttributes>
+                <DAGAttributes
+                  dag={data.DAG.DAG!}
+                  nextRun={data.DAG.Suspended ? '' : getNextRunText(data.DAG)}
+                ></DAGAttributes>
               </Box>
             </Box>
             <Box sx={{ mt: 3 }}>
@@ -8,7 +8,7 @@ import DAGStatusOverview from '../molecules/DAGStatusOverview';
 import TimelineChart from '../molecules/TimelineChart';
 import { useDAGPostAPI } from '../../hooks/useDAGPostAPI';
 import StatusUpdateModal from '../molecules/StatusUpdateModal';
-import { Step } from '../../models';
+import { Node, NodeStatus, Step } from '../../models';
 import { Box, Stack, Tab, Tabs } from '@mui/material';
 import SubTitle from '../atoms/SubTitle';
 import BorderedBox from '../atoms/BorderedBox';
@@ -26,7 +26,7 @@ type Props = {
 function DAGStatus({ DAG, name, refresh }: Props) {
   const [modal, setModal] = React.useState(false);
   const [sub, setSub] = React.useState('0');
-  const [selectedStep, setSelectedStep] = React.useState<Step | undefined>(
+  const [selectedNode, setSelectedNode] = React.useState<Node | undefined>(
     undefined
   );
   const { doPost } = useDAGPostAPI({
@@ -46,18 +46,24 @@ function DAGStatus({ DAG, name, refresh }: Props) {
   );
   const onSelectStepOnGraph = React.useCallback(
     async (id: string) => {
-      const status = DAG.Status?.Status;
-      if (status == SchedulerStatus.Running || status == SchedulerStatus.None) {
-        return;
-      }
       // find the clicked step
       const n = DAG.Status?.Nodes.find(
         (n) => n.Step.Name.replace(/\s/g, '_') == id
       );
-      if (n) {
-        setSelectedStep(n.Step);
-        setModal(true);
+      if (!n) {
+        return;
+      }
+      const status = DAG.Status?.Status;
+      if (
+        n.Status != NodeStatus.Waiting &&
+        (status == SchedulerStatus.Running ||
+          status == SchedulerStatus.Paused ||
+          status == SchedulerStatus.None)
+      ) {
+        return;
       }
+      setSelectedNode(n);
+      setModal(true);
     },
     [DAG]
   );
@@ -180,7 +186,12 @@ function DAGStatus({ DAG, name, refresh }: Props) {
 
       <StatusUpdateModal
         visible={modal}
-        step={selectedStep}
+        step={selectedNode?.Step}
+        approval={
+          selectedNode?.Status == NodeStatus.Waiting
+            ? selectedNode.Approval || {}
+            : undefined
+        }
         dismissModal={dismissModal}
         onSubmit={onUpdateStatus}
       />
@@ -11,7 +11,7 @@ export const statusColorMapping: statusColorMapping = {
   [SchedulerStatus.Error]: { backgroundColor: 'red', color: 'white' },
   [SchedulerStatus.Cancel]: { backgroundColor: 'pink' },
   [SchedulerStatus.Success]: { backgroundColor: 'green', color: 'white' },
-  [SchedulerStatus.Skipped_Unused]: { backgroundColor: 'gray', color: 'white' },
+  [SchedulerStatus.Paused]: { backgroundColor: 'gold' },
 };
 
 export const nodeStatusColorMapping = {
@@ -20,7 +20,9 @@ export const nodeStatusColorMapping = {
   [NodeStatus.Error]: statusColorMapping[SchedulerStatus.Error],
   [NodeStatus.Cancel]: statusColorMapping[SchedulerStatus.Cancel],
   [NodeStatus.Success]: statusColorMapping[SchedulerStatus.Success],
-  [NodeStatus.Skipped]: statusColorMapping[SchedulerStatus.Skipped_Unused],
+  [NodeStatus.Skipped]: { backgroundColor: 'gray', color: 'white' },
+  [NodeStatus.Timeout]: { backgroundColor: 'orange', color: 'white' },
+  [NodeStatus.Waiting]: { backgroundColor: 'gold' },
 };
 
 export const stepTabColStyles = [
@@ -68,6 +68,8 @@ export type WorkflowListItem = {
   Suspended: boolean;
   ErrorT: string;
   DAG: Workflow;
+  NextRun?: string;
+  NextRunServer?: string;
 };
 
 export type Workflow = {
@@ -78,6 +80,7 @@ export type Workflow = {
   Params: string[];
   DefaultParams?: string;
   Schedule: Schedule[];
+  Timezone?: string;
 };
 
 export type WorkflowStatus = {
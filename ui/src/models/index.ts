@@ -8,7 +8,7 @@ export enum SchedulerStatus {
   Error,
   Cancel,
   Success,
-  Skipped_Unused,
+  Paused,
 }
 
 export type Status = {
@@ -26,6 +26,17 @@ export type Status = {
   FinishedAt: string;
   Log: string;
   Params: string;
+  TriggeredBy?: DAGRunRef;
+  Triggered?: DAGRunRef[];
+  Error?: string;
+};
+
+export type DAGRunRef = {
+  Name: string;
+  DagId: string;
+  RequestId: string;
+  On?: string;
+  Error?: string;
 };
 
 export function Handlers(s: Status) {
@@ -66,14 +77,30 @@ export type DAG = {
   MaxActiveRuns: number;
   Params: string[];
   DefaultParams?: string;
+  ParamsSchema?: ParamSchema[];
   Delay: number;
   MaxCleanUpTime: number;
+  Timezone?: string;
 };
 
 export type Schedule = {
   Expression: string;
 };
 
+export type ParamSchema = {
+  Name: string;
+  Type?: 'string' | 'integer' | 'number' | 'boolean';
+  Description?: string;
+  Default?: string;
+  Required?: boolean;
+  Enum?: string[];
+  Pattern?: string;
+  Minimum?: number;
+  Maximum?: number;
+  MinLength?: number;
+  MaxLength?: number;
+};
+
 export type HandlerOn = {
   Failure: Step;
   Success: Step;
@@ -88,6 +115,8 @@ export type DAGStatus = {
   Status?: Status;
   Suspended: boolean;
   ErrorT: string;
+  NextRun?: string;
+  NextRunServer?: string;
 };
 
 export enum DAGDataType {
@@ -151,8 +180,17 @@ export function getNextSchedule(data: WorkflowListItem): number {
   if (!schedules || schedules.length == 0 || data.Suspended) {
     return Number.MAX_SAFE_INTEGER;
   }
-  const tz = getConfig().tz || moment.tz.guess();
+  if (data.NextRun && moment(data.NextRun).isAfter(moment())) {
+    // The server computes the next run in the time zone of the DAG.
+    return moment(data.NextRun).unix();
+  }
+  const tz = data.DAG.Timezone || getConfig().tz || moment.tz.guess();
   const datesToRun = schedules.map((s) => {
+    const every = getEveryInterval(s.Expression);
+    if (every) {
+      // The ticks of @every are aligned to the interval since the epoch.
+      return new Date((Math.floor(Date.now() / every) + 1) * every);
+    }
     const cronTzMatch = s.Expression.match(/(?<=CRON_TZ=)[^\s]+/);
     if (cronTzMatch) {
       const cronTz = cronTzMatch[0]
@@ -174,6 +212,47 @@ export function getNextSchedule(data: WorkflowListItem): number {
   return sorted[0].getTime() / 1000;
 }
 
+// getEveryInterval returns the interval of the @every expression in
+// milliseconds, e.g. 15000 for "@every 15s", or 0 for the other expressions.
+function getEveryInterval(expression: string): number {
+  const match = expression.match(/@every\s+(\S+)/);
+  if (!match) {
+    return 0;
+  }
+  const units: { [unit: string]: number } = {
+    h: 3600000,
+    m: 60000,
+    s: 1000,
+    ms: 1,
+  };
+  const re = /(\d+(?:\.\d+)?)(ms|h|m|s)/g;
+  let ms = 0;
+  let m: RegExpExecArray | null;
+  while ((m = re.exec(match[1])) !== null) {
+    ms += parseFloat(m[1]) * units[m[2]];
+  }
+  return Math.max(Math.floor(ms / 1000) * 1000, 1000);
+}
+
+// getNextRunText returns the next run in the time zone of the DAG and in the
+// time zone of the server.
+export function getNextRunText(data: {
+  NextRun?: string;
+  NextRunServer?: string;
+  DAG: { Timezone?: string };
+}): string {
+  if (!data.NextRun) {
+    return '';
+  }
+  const format = 'YYYY-MM-DD HH:mm:ss Z';
+  const dagTime = moment.parseZone(data.NextRun).format(format);
+  const serverTime = moment
+    .parseZone(data.NextRunServer || data.NextRun)
+    .format(format);
+  const zone = data.DAG.Timezone ? ` (${data.DAG.Timezone})` : '';
+  return `DAG: ${dagTime}${zone} / Server: ${serverTime}`;
+}
+
 export enum NodeStatus {
   None = 0,
   Running,
@@ -181,6 +260,8 @@ export enum NodeStatus {
   Cancel,
   Success,
   Skipped,
+  Timeout,
+  Waiting,
 }
 
 export type Node = {
@@ -193,6 +274,28 @@ export type Node = {
   DoneCount: number;
   Error: string;
   StatusText: string;
+  Attempts?: Attempt[];
+  Iterations?: number;
+  LastResult?: string;
+  Outputs?: { [name: string]: string };
+  Artifacts?: string[];
+  Approval?: Approval;
+};
+
+export type Approval = {
+  Message?: string;
+  Approved?: boolean;
+  User?: string;
+  Comment?: string;
+  At?: string;
+};
+
+export type Attempt = {
+  Attempt: number;
+  StartedAt: string;
+  FinishedAt: string;
+  ExitCode: number;
+  Error?: string;
 };
 
 export type StatusFile = {